@@ -14,6 +14,7 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"reviewservice/internal/config"
 	"reviewservice/internal/handler"
 	"reviewservice/internal/repository/postgres"
@@ -43,7 +44,8 @@ func run() error {
 
 	logger.Info("starting application",
 		zap.String("env", cfg.App.Env),
-		zap.String("log_level", cfg.App.LogLevel))
+		zap.String("log_level", cfg.App.LogLevel),
+		zap.Strings("features", cfg.Features.Enabled()))
 
 	// Запуск миграций
 	if err := runMigrations(cfg.Database, logger); err != nil {
@@ -53,10 +55,11 @@ func run() error {
 
 	// Подключение к БД
 	db, err := postgres.NewDB(postgres.Config{
-		DSN:             cfg.Database.DSN(),
-		MaxOpenConns:    cfg.Database.MaxOpenConns,
-		MaxIdleConns:    cfg.Database.MaxIdleConns,
-		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		DSN:              cfg.Database.DSN(),
+		MaxOpenConns:     cfg.Database.MaxOpenConns,
+		MaxIdleConns:     cfg.Database.MaxIdleConns,
+		ConnMaxLifetime:  cfg.Database.ConnMaxLifetime,
+		StatementTimeout: cfg.Database.StatementTimeout,
 	})
 	if err != nil {
 		logger.Error("failed to connect to database", zap.Error(err))
@@ -67,7 +70,12 @@ func run() error {
 	logger.Info("connected to database")
 
 	// Инициализация зависимостей
-	app := initApp(db, logger)
+	app, err := initApp(db, cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
+	// Фоновая запись решений отбора завершается до закрытия соединения с БД
+	defer app.prService.WaitSelectionDecisions()
 
 	// Создание HTTP сервера
 	srv := &http.Server{
@@ -82,6 +90,12 @@ func run() error {
 	serverErrors := make(chan error, 1)
 
 	go func() {
+		if cfg.Server.TLSEnabled() {
+			logger.Info("starting HTTPS server", zap.String("address", srv.Addr))
+			serverErrors <- srv.ListenAndServeTLS(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+			return
+		}
+
 		logger.Info("starting HTTP server", zap.String("address", srv.Addr))
 		serverErrors <- srv.ListenAndServe()
 	}()
@@ -115,11 +129,19 @@ func run() error {
 
 // App содержит все зависимости приложения
 type App struct {
-	router http.Handler
+	router    http.Handler
+	prService *service.PullRequestService
 }
 
 // initApp инициализирует приложение
-func initApp(db *sql.DB, logger *zap.Logger) *App {
+func initApp(db *sql.DB, cfg *config.Config, logger *zap.Logger) (*App, error) {
+	if cfg.Server.RequestTimeout <= 0 {
+		return nil, fmt.Errorf("invalid request timeout %s: must be positive", cfg.Server.RequestTimeout)
+	}
+	if cfg.App.LogSampleRate < 0 || cfg.App.LogSampleRate > 1 {
+		return nil, fmt.Errorf("invalid log sample rate %g: must be between 0 and 1", cfg.App.LogSampleRate)
+	}
+
 	// Transaction Manager
 	txManager := postgres.NewTxManager(db)
 
@@ -129,23 +151,95 @@ func initApp(db *sql.DB, logger *zap.Logger) *App {
 	prRepo := postgres.NewPullRequestRepository(db)
 
 	// Services
-	teamService := service.NewTeamService(teamRepo, userRepo, txManager, logger)
-	userService := service.NewUserService(userRepo, prRepo, logger)
-	prService := service.NewPullRequestService(prRepo, userRepo, logger)
-	statsService := service.NewStatsService(prRepo, userRepo, logger)
+	selectionLogLevel, err := zapcore.ParseLevel(cfg.Assignment.SelectionLogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selection log level: %w", err)
+	}
+
+	excludeUsername, err := cfg.Assignment.ExcludeUsernameRegexp()
+	if err != nil {
+		return nil, err
+	}
+
+	fallbackOrder, err := service.ParseFallbackOrder(cfg.Assignment.ReassignFallbackOrder)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reassign fallback order: %w", err)
+	}
+
+	selector, err := service.NewReviewerSelector(cfg.Assignment.ReviewerStrategy, prRepo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reviewer strategy: %w", err)
+	}
+
+	if cfg.Assignment.BulkReassignConcurrency < 1 {
+		return nil, fmt.Errorf("invalid bulk reassign concurrency %d: must be at least 1",
+			cfg.Assignment.BulkReassignConcurrency)
+	}
+
+	if cfg.App.ReviewersPerPR < 1 {
+		return nil, fmt.Errorf("invalid reviewers per PR %d: must be at least 1", cfg.App.ReviewersPerPR)
+	}
+
+	sizeReviewers, err := service.ParseSizeReviewers(cfg.Assignment.SizeReviewers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PR size reviewers: %w", err)
+	}
+
+	if cfg.Assignment.ReviewSLAHours < 0 {
+		return nil, fmt.Errorf("invalid review SLA %d hours: must not be negative", cfg.Assignment.ReviewSLAHours)
+	}
+
+	if cfg.Assignment.UndoWindowMinutes < 0 {
+		return nil, fmt.Errorf("invalid undo window %d minutes: must not be negative", cfg.Assignment.UndoWindowMinutes)
+	}
+
+	assignmentCfg := service.AssignmentConfig{
+		Features:                   service.FeatureFlags(cfg.Features),
+		SelectionLogLevel:          selectionLogLevel,
+		CodeOwners:                 cfg.Assignment.CodeOwnersMap(),
+		SizeReviewers:              sizeReviewers,
+		MaxDailyAssignments:        cfg.Assignment.MaxDailyAssignments,
+		MaxWeeklyAssignments:       cfg.Assignment.MaxWeeklyAssignments,
+		MaxPendingReviews:          cfg.Assignment.MaxPendingReviews,
+		MinTeamSize:                cfg.Assignment.MinTeamSize,
+		ExcludeUsername:            excludeUsername,
+		FallbackOrder:              fallbackOrder,
+		ExternalReviewerTeam:       cfg.Assignment.ExternalReviewerTeam,
+		IDs:                        service.IDNormalizer{Lowercase: cfg.App.LowercaseIDs},
+		Selector:                   selector,
+		BulkReassignConcurrency:    cfg.Assignment.BulkReassignConcurrency,
+		ReviewSLA:                  time.Duration(cfg.Assignment.ReviewSLAHours) * time.Hour,
+		BlockMergeWithoutReviewers: cfg.Assignment.BlockMergeWithoutReviewers,
+		MaxOpenPRsPerAuthor:        cfg.Assignment.MaxOpenPRsPerAuthor,
+		UndoWindow:                 time.Duration(cfg.Assignment.UndoWindowMinutes) * time.Minute,
+	}
+	reviewerPolicy := service.ReviewerPolicy{ReviewersPerPR: cfg.App.ReviewersPerPR}
+	userService := service.NewUserService(userRepo, prRepo, assignmentCfg, logger)
+	teamService := service.NewTeamService(teamRepo, userRepo, userService, txManager, logger)
+	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo, txManager, reviewerPolicy, assignmentCfg, logger)
+	statsService := service.NewStatsService(prRepo, userRepo, teamRepo, assignmentCfg, logger)
 
 	// Handlers
-	teamHandler := handler.NewTeamHandler(teamService, statsService, logger)
-	userHandler := handler.NewUserHandler(userService, prService, logger)
-	prHandler := handler.NewPullRequestHandler(prService, logger)
+	if cfg.Pagination.DefaultPageSize <= 0 || cfg.Pagination.MaxPageSize < cfg.Pagination.DefaultPageSize {
+		return nil, fmt.Errorf("invalid pagination config: default page size %d, max page size %d",
+			cfg.Pagination.DefaultPageSize, cfg.Pagination.MaxPageSize)
+	}
+	pagination := handler.PaginationConfig{
+		DefaultPageSize: cfg.Pagination.DefaultPageSize,
+		MaxPageSize:     cfg.Pagination.MaxPageSize,
+	}
+	teamHandler := handler.NewTeamHandler(teamService, statsService, prService, logger)
+	userHandler := handler.NewUserHandler(userService, prService, pagination, logger)
+	prHandler := handler.NewPullRequestHandler(prService, pagination, logger)
 	statsHandler := handler.NewStatsHandler(statsService, logger)
 
 	// Router
-	router := handler.Router(teamHandler, userHandler, prHandler, statsHandler, logger)
+	router := handler.Router(teamHandler, userHandler, prHandler, statsHandler, cfg.Server.RequestTimeout, cfg.App.LogSampleRate, logger)
 
 	return &App{
-		router: router,
-	}
+		router:    router,
+		prService: prService,
+	}, nil
 }
 
 // initLogger инициализирует структурированный логгер
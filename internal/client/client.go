@@ -0,0 +1,220 @@
+// Package client - типизированный HTTP-клиент API сервиса назначения ревьюверов
+// для внутренних сервисов. Ответы с ошибкой декодируются обратно в доменные ошибки,
+// поэтому вызывающий код проверяет их через errors.Is(err, domain.ErrPRMerged) и т.п.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"reviewservice/internal/domain"
+	"reviewservice/internal/service"
+)
+
+// Client вызывает HTTP API сервиса
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient создаёт клиент для сервиса по адресу baseURL (например, http://localhost:8080).
+// Если httpClient равен nil, используется http.DefaultClient
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// CreatePullRequestRequest - параметры создания PR
+type CreatePullRequestRequest struct {
+	PullRequestID   string        `json:"pull_request_id"`
+	PullRequestName string        `json:"pull_request_name"`
+	AuthorID        string        `json:"author_id"`
+	Paths           []string      `json:"paths,omitempty"`
+	CandidatePool   []string      `json:"candidate_pool,omitempty"`
+	Size            domain.PRSize `json:"size,omitempty"`
+}
+
+// ReassignResult - результат переназначения ревьювера
+type ReassignResult struct {
+	PR              *domain.PullRequest `json:"pr"`
+	ReplacedBy      string              `json:"replaced_by"`
+	BeforeReviewers []string            `json:"before_reviewers"`
+	AfterReviewers  []string            `json:"after_reviewers"`
+}
+
+// prResponse - обёртка ответов, возвращающих PR
+type prResponse struct {
+	PR *domain.PullRequest `json:"pr"`
+}
+
+// CreatePullRequest создаёт PR с автоназначением ревьюверов (POST /pullRequest/create)
+func (c *Client) CreatePullRequest(ctx context.Context, req CreatePullRequestRequest) (*domain.PullRequest, error) {
+	var resp prResponse
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/create", req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PR, nil
+}
+
+// MergePullRequest помечает PR как MERGED (POST /pullRequest/merge)
+func (c *Client) MergePullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	body := map[string]string{"pull_request_id": prID}
+
+	var resp prResponse
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/merge", body, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PR, nil
+}
+
+// Reassign заменяет ревьювера oldUserID на автоматически выбранного (POST /pullRequest/reassign)
+func (c *Client) Reassign(ctx context.Context, prID, oldUserID string) (*ReassignResult, error) {
+	body := map[string]string{"pull_request_id": prID, "old_user_id": oldUserID}
+
+	var resp ReassignResult
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/reassign", body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetStats возвращает статистику назначений (GET /stats)
+func (c *Client) GetStats(ctx context.Context) (*service.GlobalStats, error) {
+	var stats service.GlobalStats
+	if err := c.do(ctx, http.MethodGet, "/stats", nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// do выполняет запрос с JSON-телом body (nil - без тела) и декодирует успешный ответ в out.
+// Ответ со статусом не 2xx превращается в *APIError
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", method, path, err)
+	}
+	return nil
+}
+
+// APIError - ответ сервиса с ошибкой. Unwrap возвращает соответствующую коду доменную ошибку
+type APIError struct {
+	StatusCode int
+	Code       domain.ErrorCode
+	Message    string
+}
+
+// Error реализует интерфейс error
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s (HTTP %d): %s", e.Code, e.StatusCode, e.Message)
+}
+
+// Unwrap позволяет проверять ошибку через errors.Is с доменными ошибками
+func (e *APIError) Unwrap() error {
+	return domainError(e.StatusCode, e.Code, e.Message)
+}
+
+// decodeError читает тело ответа в формате handler.ErrorResponse
+func decodeError(resp *http.Response) error {
+	var payload struct {
+		Error struct {
+			Code    domain.ErrorCode `json:"code"`
+			Message string           `json:"message"`
+		} `json:"error"`
+	}
+
+	apiErr := &APIError{StatusCode: resp.StatusCode, Code: domain.CodeInternalError}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err == nil && payload.Error.Code != "" {
+		apiErr.Code = payload.Error.Code
+		apiErr.Message = payload.Error.Message
+	} else {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return apiErr
+}
+
+// codeErrors - однозначное соответствие кодов API доменным ошибкам
+var codeErrors = map[domain.ErrorCode]error{
+	domain.CodeTeamExists:       domain.ErrTeamExists,
+	domain.CodePRExists:         domain.ErrPRExists,
+	domain.CodePRMerged:         domain.ErrPRMerged,
+	domain.CodeNotAssigned:      domain.ErrNotAssigned,
+	domain.CodeAlreadyAssigned:  domain.ErrAlreadyAssigned,
+	domain.CodeNoCandidate:      domain.ErrNoCandidate,
+	domain.CodeTargetIneligible: domain.ErrTargetIneligible,
+	domain.CodeAuthorReviewer:   domain.ErrAuthorReviewer,
+	domain.CodeUnavailable:      domain.ErrUnavailable,
+	domain.CodeTimeout:          domain.ErrTimeout,
+}
+
+// unprocessableErrors - доменные ошибки, которые сервис отдаёт с кодом UNPROCESSABLE
+var unprocessableErrors = []error{
+	domain.ErrAuthorInactive,
+	domain.ErrNoReviewers,
+	domain.ErrAuthorPRLimit,
+	domain.ErrUndoExpired,
+}
+
+// domainError восстанавливает доменную ошибку по ответу. Ошибки валидации сервис отдаёт
+// с кодом NOT_FOUND и статусом 400 (см. handleDomainError), а UNPROCESSABLE общий
+// для нескольких ошибок, которые различаются по тексту сообщения
+func domainError(status int, code domain.ErrorCode, message string) error {
+	if err, ok := codeErrors[code]; ok {
+		return err
+	}
+
+	switch code {
+	case domain.CodeNotFound:
+		if status == http.StatusBadRequest {
+			return domain.ErrInvalidInput
+		}
+		return domain.ErrNotFound
+	case domain.CodeUnprocessable:
+		for _, err := range unprocessableErrors {
+			if strings.HasSuffix(message, err.Error()) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
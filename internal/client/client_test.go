@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+	"reviewservice/internal/handler"
+	"reviewservice/internal/service"
+	"reviewservice/internal/testutil"
+)
+
+// newTestServer serves the real API router backed by mock repositories
+// with an author and three active teammates in team "backend"
+func newTestServer(t *testing.T) (*httptest.Server, *testutil.MockPRRepository) {
+	t.Helper()
+
+	logger := zap.NewNop()
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+	teamRepo := testutil.NewMockTeamRepository()
+	for _, id := range []string{"author", "u1", "u2", "u3"} {
+		userRepo.Users[id] = &domain.User{UserID: id, TeamName: "backend", IsActive: true}
+	}
+
+	userService := service.NewUserService(userRepo, prRepo, service.AssignmentConfig{}, logger)
+	teamService := service.NewTeamService(teamRepo, userRepo, userService, nil, logger)
+	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo, nil, service.ReviewerPolicy{}, service.AssignmentConfig{}, logger)
+	statsService := service.NewStatsService(prRepo, userRepo, teamRepo, service.AssignmentConfig{}, logger)
+
+	pagination := handler.PaginationConfig{DefaultPageSize: 50, MaxPageSize: 500}
+	router := handler.Router(
+		handler.NewTeamHandler(teamService, statsService, prService, logger),
+		handler.NewUserHandler(userService, prService, pagination, logger),
+		handler.NewPullRequestHandler(prService, pagination, logger),
+		handler.NewStatsHandler(statsService, logger),
+		time.Minute,
+		1,
+		logger,
+	)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	t.Cleanup(prService.WaitSelectionDecisions)
+	return server, prRepo
+}
+
+// TestClient_PullRequestLifecycle tests create, reassign, merge and stats against the API
+func TestClient_PullRequestLifecycle(t *testing.T) {
+	server, _ := newTestServer(t)
+	c := NewClient(server.URL+"/", server.Client())
+	ctx := context.Background()
+
+	pr, err := c.CreatePullRequest(ctx, CreatePullRequestRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Feature",
+		AuthorID:        "author",
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, pr.PullRequestID, "pr-1", "PR ID")
+	testutil.AssertEqual(t, pr.Status, domain.PRStatusOpen, "Status")
+	testutil.AssertEqual(t, len(pr.AssignedReviewers), 2, "Reviewers assigned")
+
+	old := pr.AssignedReviewers[0]
+	result, err := c.Reassign(ctx, "pr-1", old)
+	testutil.AssertNoError(t, err)
+	if result.ReplacedBy == "" || result.ReplacedBy == old {
+		t.Errorf("ReplacedBy = %q, want a new reviewer instead of %q", result.ReplacedBy, old)
+	}
+	testutil.AssertEqual(t, len(result.PR.AssignedReviewers), 2, "Reviewers after reassign")
+	testutil.AssertEqual(t, len(result.BeforeReviewers), 2, "Before reviewers")
+
+	merged, err := c.MergePullRequest(ctx, "pr-1")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, merged.Status, domain.PRStatusMerged, "Status after merge")
+
+	stats, err := c.GetStats(ctx)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, stats.PRStats.TotalPRs, 1, "Total PRs")
+	testutil.AssertEqual(t, stats.PRStats.MergedPRs, 1, "Merged PRs")
+}
+
+// TestClient_DomainErrors tests that API errors from the real handlers unwrap to domain errors
+func TestClient_DomainErrors(t *testing.T) {
+	server, prRepo := newTestServer(t)
+	c := NewClient(server.URL, server.Client())
+	ctx := context.Background()
+
+	prRepo.PRs["pr-merged"] = &domain.PullRequest{
+		PullRequestID:     "pr-merged",
+		AuthorID:          "author",
+		Status:            domain.PRStatusMerged,
+		AssignedReviewers: []string{"u1"},
+	}
+
+	_, err := c.CreatePullRequest(ctx, CreatePullRequestRequest{PullRequestID: "pr-merged", PullRequestName: "Dup", AuthorID: "author"})
+	if !errors.Is(err, domain.ErrPRExists) {
+		t.Errorf("create duplicate: got %v, want ErrPRExists", err)
+	}
+
+	_, err = c.Reassign(ctx, "pr-merged", "u1")
+	if !errors.Is(err, domain.ErrPRMerged) {
+		t.Errorf("reassign merged: got %v, want ErrPRMerged", err)
+	}
+
+	_, err = c.MergePullRequest(ctx, "missing")
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("merge missing: got %v, want ErrNotFound", err)
+	}
+
+	_, err = c.CreatePullRequest(ctx, CreatePullRequestRequest{PullRequestID: "pr-2"})
+	if !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("create without fields: got %v, want ErrInvalidInput", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	testutil.AssertEqual(t, apiErr.StatusCode, http.StatusBadRequest, "Status code")
+	testutil.AssertEqual(t, apiErr.Code, domain.CodeNotFound, "Error code")
+}
+
+// TestClient_ErrorMapping tests decoding of every error code the API returns
+func TestClient_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		code    domain.ErrorCode
+		message string
+		want    error
+	}{
+		{name: "team exists", status: http.StatusBadRequest, code: domain.CodeTeamExists, want: domain.ErrTeamExists},
+		{name: "invalid input", status: http.StatusBadRequest, code: domain.CodeNotFound, want: domain.ErrInvalidInput},
+		{name: "not found", status: http.StatusNotFound, code: domain.CodeNotFound, want: domain.ErrNotFound},
+		{name: "pr exists", status: http.StatusConflict, code: domain.CodePRExists, want: domain.ErrPRExists},
+		{name: "pr merged", status: http.StatusConflict, code: domain.CodePRMerged, want: domain.ErrPRMerged},
+		{name: "not assigned", status: http.StatusConflict, code: domain.CodeNotAssigned, want: domain.ErrNotAssigned},
+		{name: "already assigned", status: http.StatusConflict, code: domain.CodeAlreadyAssigned, want: domain.ErrAlreadyAssigned},
+		{name: "no candidate", status: http.StatusConflict, code: domain.CodeNoCandidate, want: domain.ErrNoCandidate},
+		{name: "target ineligible", status: http.StatusConflict, code: domain.CodeTargetIneligible, want: domain.ErrTargetIneligible},
+		{name: "author reviewer", status: http.StatusConflict, code: domain.CodeAuthorReviewer, want: domain.ErrAuthorReviewer},
+		{name: "author inactive", status: http.StatusUnprocessableEntity, code: domain.CodeUnprocessable,
+			message: domain.ErrAuthorInactive.Error(), want: domain.ErrAuthorInactive},
+		{name: "wrapped pr limit", status: http.StatusUnprocessableEntity, code: domain.CodeUnprocessable,
+			message: "author a has 3 open pull requests (limit 3): " + domain.ErrAuthorPRLimit.Error(), want: domain.ErrAuthorPRLimit},
+		{name: "no reviewers", status: http.StatusUnprocessableEntity, code: domain.CodeUnprocessable,
+			message: domain.ErrNoReviewers.Error(), want: domain.ErrNoReviewers},
+		{name: "undo expired", status: http.StatusUnprocessableEntity, code: domain.CodeUnprocessable,
+			message: domain.ErrUndoExpired.Error(), want: domain.ErrUndoExpired},
+		{name: "unavailable", status: http.StatusServiceUnavailable, code: domain.CodeUnavailable, want: domain.ErrUnavailable},
+		{name: "timeout", status: http.StatusGatewayTimeout, code: domain.CodeTimeout, want: domain.ErrTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				json.NewEncoder(w).Encode(handler.ErrorResponse{
+					Error: handler.ErrorDetail{Code: tt.code, Message: tt.message},
+				})
+			}))
+			defer server.Close()
+
+			_, err := NewClient(server.URL, server.Client()).MergePullRequest(context.Background(), "pr-1")
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestClient_UnknownError tests that internal and non-JSON errors keep the status without a domain error
+func TestClient_UnknownError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream exploded", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, server.Client()).GetStats(context.Background())
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	testutil.AssertEqual(t, apiErr.StatusCode, http.StatusBadGateway, "Status code")
+	testutil.AssertEqual(t, apiErr.Code, domain.CodeInternalError, "Error code")
+	if errors.Unwrap(err) != nil {
+		t.Errorf("unexpected domain error %v", errors.Unwrap(err))
+	}
+}
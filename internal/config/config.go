@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
@@ -17,6 +19,15 @@ type Config struct {
 
 	// App конфигурация приложения
 	App AppConfig
+
+	// Assignment конфигурация назначения ревьюверов
+	Assignment AssignmentConfig
+
+	// Features флаги включения отдельных правил назначения
+	Features FeatureConfig
+
+	// Pagination конфигурация пагинации списков
+	Pagination PaginationConfig
 }
 
 // ServerConfig конфигурация HTTP сервера
@@ -26,6 +37,15 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `envconfig:"SERVER_READ_TIMEOUT" default:"10s"`
 	WriteTimeout time.Duration `envconfig:"SERVER_WRITE_TIMEOUT" default:"10s"`
 	IdleTimeout  time.Duration `envconfig:"SERVER_IDLE_TIMEOUT" default:"60s"`
+
+	// RequestTimeout дедлайн обработки запроса; по его истечении клиент получает 504 TIMEOUT.
+	// Должен быть меньше WriteTimeout, иначе соединение закроется раньше ответа
+	RequestTimeout time.Duration `envconfig:"SERVER_REQUEST_TIMEOUT" default:"9s"`
+
+	// TLSCertFile и TLSKeyFile - сертификат и ключ для HTTPS без TLS-терминирующего прокси.
+	// Задаются вместе; если не заданы, сервер работает по HTTP
+	TLSCertFile string `envconfig:"TLS_CERT_FILE"`
+	TLSKeyFile  string `envconfig:"TLS_KEY_FILE"`
 }
 
 // DatabaseConfig конфигурация PostgreSQL
@@ -40,12 +60,174 @@ type DatabaseConfig struct {
 	MaxIdleConns    int           `envconfig:"DB_MAX_IDLE_CONNS" default:"5"`
 	ConnMaxLifetime time.Duration `envconfig:"DB_CONN_MAX_LIFETIME" default:"5m"`
 	MigrationsPath  string        `envconfig:"DB_MIGRATIONS_PATH" default:"file://migrations"`
+
+	// StatementTimeout серверный statement_timeout соединений: PostgreSQL прерывает
+	// запросы дольше этого значения независимо от контекста приложения (0 - без ограничения)
+	StatementTimeout time.Duration `envconfig:"DB_STATEMENT_TIMEOUT" default:"0"`
 }
 
 // AppConfig конфигурация приложения
 type AppConfig struct {
 	LogLevel string `envconfig:"LOG_LEVEL" default:"info"`
 	Env      string `envconfig:"APP_ENV" default:"development"`
+
+	// LogSampleRate доля логируемых успешных (2xx) запросов от 0 до 1; ответы
+	// с ошибками логируются всегда
+	LogSampleRate float64 `envconfig:"LOG_SAMPLE_RATE" default:"1"`
+
+	// LowercaseIDs приводит user_id, team_name и pull_request_id к нижнему регистру
+	LowercaseIDs bool `envconfig:"LOWERCASE_IDS" default:"false"`
+
+	// ReviewersPerPR число ревьюверов на PR для команд, которым оно не задано
+	ReviewersPerPR int `envconfig:"REVIEWERS_PER_PR" default:"2"`
+}
+
+// AssignmentConfig конфигурация назначения ревьюверов
+type AssignmentConfig struct {
+	// SelectionLogLevel уровень логов отбора ревьюверов, независимый от LOG_LEVEL
+	SelectionLogLevel string `envconfig:"SELECTION_LOG_LEVEL" default:"info"`
+
+	// CodeOwners владельцы путей в формате "prefix:user1|user2,prefix2:user3"
+	CodeOwners map[string]string `envconfig:"CODE_OWNERS"`
+
+	// SizeReviewers число ревьюверов по размеру PR в формате "small:1,medium:2,large:3".
+	// Размер без числа не меняет число ревьюверов команды
+	SizeReviewers map[string]int `envconfig:"PR_SIZE_REVIEWERS"`
+
+	// MaxDailyAssignments дневной лимит назначений на пользователя по умолчанию (0 - без лимита)
+	MaxDailyAssignments int `envconfig:"MAX_DAILY_ASSIGNMENTS" default:"0"`
+
+	// MaxWeeklyAssignments недельный лимит назначений на пользователя (0 - без лимита)
+	MaxWeeklyAssignments int `envconfig:"MAX_WEEKLY_ASSIGNMENTS" default:"0"`
+
+	// MaxPendingReviews максимум незавершённых ревью (на открытых PR) у кандидата (0 - без ограничения)
+	MaxPendingReviews int `envconfig:"MAX_PENDING_REVIEWS" default:"0"`
+
+	// MinTeamSize минимальный размер команды для автоназначения (0 - без ограничения)
+	MinTeamSize int `envconfig:"MIN_TEAM_SIZE_FOR_AUTOASSIGN" default:"0"`
+
+	// ExcludeUsernamePattern регулярное выражение имён служебных аккаунтов,
+	// которые не назначаются ревьюверами автоматически (пусто - без фильтрации)
+	ExcludeUsernamePattern string `envconfig:"REVIEWER_EXCLUDE_USERNAME_PATTERN"`
+
+	// ReassignFallbackOrder порядок источников замены ревьювера при деактивации
+	ReassignFallbackOrder []string `envconfig:"REASSIGN_FALLBACK_ORDER" default:"reviewer_team,author_team,any"`
+
+	// ExternalReviewerTeam команда ревьюверов для PR авторов без команды (пусто - все команды)
+	ExternalReviewerTeam string `envconfig:"EXTERNAL_REVIEWER_TEAM"`
+
+	// ReviewerStrategy стратегия выбора ревьюверов: random, alphabetical (по username),
+	// throughput (с весом по числу смердженных ревью) или least_loaded (по открытым ревью)
+	ReviewerStrategy string `envconfig:"REVIEWER_STRATEGY" default:"random"`
+
+	// BulkReassignConcurrency число PR, переназначаемых параллельно при массовой деактивации
+	BulkReassignConcurrency int `envconfig:"BULK_REASSIGN_CONCURRENCY" default:"1"`
+
+	// ReviewSLAHours ожидаемое время ревью в часах для отчёта о нарушениях SLA (0 - SLA не задан)
+	ReviewSLAHours int `envconfig:"REVIEW_SLA_HOURS" default:"24"`
+
+	// BlockMergeWithoutReviewers запрещает мердж открытого PR без ревьюверов (422)
+	BlockMergeWithoutReviewers bool `envconfig:"BLOCK_MERGE_WITHOUT_REVIEWERS" default:"false"`
+
+	// MaxOpenPRsPerAuthor максимум открытых PR у одного автора; новый PR сверх лимита отклоняется (0 - без лимита)
+	MaxOpenPRsPerAuthor int `envconfig:"MAX_OPEN_PRS_PER_AUTHOR" default:"0"`
+
+	// UndoWindowMinutes окно в минутах, в течение которого последнее назначение ревьюверов PR можно отменить (0 - отмена отключена)
+	UndoWindowMinutes int `envconfig:"UNDO_WINDOW_MINUTES" default:"15"`
+}
+
+// CodeOwnersMap возвращает владельцев путей в виде prefix -> []user_id
+func (a AssignmentConfig) CodeOwnersMap() map[string][]string {
+	owners := make(map[string][]string, len(a.CodeOwners))
+	for prefix, users := range a.CodeOwners {
+		for _, userID := range strings.Split(users, "|") {
+			if userID = strings.TrimSpace(userID); userID != "" {
+				owners[prefix] = append(owners[prefix], userID)
+			}
+		}
+	}
+	return owners
+}
+
+// FeatureConfig собирает булевы флаги правил назначения ревьюверов. Порядок и имена полей
+// совпадают с service.FeatureFlags, чтобы флаги передавались в сервисы одним преобразованием
+type FeatureConfig struct {
+	// DebugTrace включает детальную трассировку отбора кандидатов (причины исключения)
+	DebugTrace bool `envconfig:"ASSIGNMENT_DEBUG_TRACE" default:"false"`
+
+	// RequireSenior гарантирует хотя бы одного сеньора среди ревьюверов, если он есть в команде
+	RequireSenior bool `envconfig:"REQUIRE_SENIOR_REVIEWER" default:"false"`
+
+	// PreferAvailable предпочитает ревьюверов, находящихся в своих часах доступности
+	PreferAvailable bool `envconfig:"PREFER_AVAILABLE_REVIEWERS" default:"false"`
+
+	// DiverseReviewGroups запрещает двух ревьюверов из одной группы ревью, если есть альтернативы
+	DiverseReviewGroups bool `envconfig:"DIVERSE_REVIEW_GROUPS" default:"false"`
+
+	// ReassignAuthorTeamOnly ищет замену ревьювера только в команде автора PR
+	ReassignAuthorTeamOnly bool `envconfig:"REASSIGN_SAME_TEAM_AS_AUTHOR_ONLY" default:"false"`
+
+	// AvoidRepeatPairs избегает пар ревьюверов, уже назначавшихся вместе на недавние PR автора
+	AvoidRepeatPairs bool `envconfig:"AVOID_REPEAT_REVIEWER_PAIRS" default:"false"`
+
+	// AvoidReciprocal откладывает кандидатов, чей последний PR ревьюил автор текущего PR
+	AvoidReciprocal bool `envconfig:"AVOID_RECIPROCAL_REVIEWS" default:"false"`
+}
+
+// Enabled возвращает имена (переменные окружения) включённых флагов в порядке объявления
+func (f FeatureConfig) Enabled() []string {
+	flags := []struct {
+		name string
+		on   bool
+	}{
+		{"ASSIGNMENT_DEBUG_TRACE", f.DebugTrace},
+		{"REQUIRE_SENIOR_REVIEWER", f.RequireSenior},
+		{"PREFER_AVAILABLE_REVIEWERS", f.PreferAvailable},
+		{"DIVERSE_REVIEW_GROUPS", f.DiverseReviewGroups},
+		{"REASSIGN_SAME_TEAM_AS_AUTHOR_ONLY", f.ReassignAuthorTeamOnly},
+		{"AVOID_REPEAT_REVIEWER_PAIRS", f.AvoidRepeatPairs},
+		{"AVOID_RECIPROCAL_REVIEWS", f.AvoidReciprocal},
+	}
+
+	enabled := []string{}
+	for _, flag := range flags {
+		if flag.on {
+			enabled = append(enabled, flag.name)
+		}
+	}
+	return enabled
+}
+
+// Validate проверяет допустимость флагов для окружения env (APP_ENV). Трассировка отбора
+// раскрывает в API причины исключения кандидатов (неактивность, лимиты) и в production запрещена
+func (f FeatureConfig) Validate(env string) error {
+	if f.DebugTrace && strings.EqualFold(env, "production") {
+		return fmt.Errorf("ASSIGNMENT_DEBUG_TRACE must not be enabled when APP_ENV=production")
+	}
+	return nil
+}
+
+// PaginationConfig конфигурация пагинации списков
+type PaginationConfig struct {
+	// DefaultPageSize размер страницы, если limit не указан
+	DefaultPageSize int `envconfig:"DEFAULT_PAGE_SIZE" default:"50"`
+
+	// MaxPageSize максимальный размер страницы (больший limit урезается)
+	MaxPageSize int `envconfig:"MAX_PAGE_SIZE" default:"500"`
+}
+
+// ExcludeUsernameRegexp компилирует шаблон исключаемых имён (nil, если шаблон не задан)
+func (a AssignmentConfig) ExcludeUsernameRegexp() (*regexp.Regexp, error) {
+	if a.ExcludeUsernamePattern == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(a.ExcludeUsernamePattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REVIEWER_EXCLUDE_USERNAME_PATTERN: %w", err)
+	}
+
+	return re, nil
 }
 
 // Address возвращает адрес для прослушивания HTTP сервера
@@ -53,6 +235,19 @@ func (s ServerConfig) Address() string {
 	return fmt.Sprintf("%s:%d", s.Host, s.Port)
 }
 
+// TLSEnabled проверяет, задан ли сертификат для HTTPS
+func (s ServerConfig) TLSEnabled() bool {
+	return s.TLSCertFile != "" && s.TLSKeyFile != ""
+}
+
+// Validate проверяет, что файлы сертификата и ключа TLS заданы вместе
+func (s ServerConfig) Validate() error {
+	if (s.TLSCertFile == "") != (s.TLSKeyFile == "") {
+		return fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	return nil
+}
+
 // DSN возвращает строку подключения к PostgreSQL
 func (d DatabaseConfig) DSN() string {
 	return fmt.Sprintf(
@@ -69,5 +264,17 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to process config: %w", err)
 	}
 
+	if err := cfg.Server.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := cfg.Assignment.ExcludeUsernameRegexp(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Features.Validate(cfg.App.Env); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"testing"
+
+	"reviewservice/internal/testutil"
+)
+
+// TestLoad_FeatureDefaults tests that every feature flag is off unless set
+func TestLoad_FeatureDefaults(t *testing.T) {
+	cfg, err := Load()
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertEqual(t, cfg.Features, FeatureConfig{})
+	testutil.AssertLen(t, cfg.Features.Enabled(), 0, "No features enabled by default")
+}
+
+// TestLoad_FeatureOverrides tests that flags are read from their existing environment variables
+func TestLoad_FeatureOverrides(t *testing.T) {
+	t.Setenv("ASSIGNMENT_DEBUG_TRACE", "true")
+	t.Setenv("REQUIRE_SENIOR_REVIEWER", "1")
+	t.Setenv("DIVERSE_REVIEW_GROUPS", "false")
+	t.Setenv("REASSIGN_SAME_TEAM_AS_AUTHOR_ONLY", "TRUE")
+	t.Setenv("AVOID_RECIPROCAL_REVIEWS", "true")
+
+	cfg, err := Load()
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertEqual(t, cfg.Features, FeatureConfig{
+		DebugTrace:             true,
+		RequireSenior:          true,
+		ReassignAuthorTeamOnly: true,
+		AvoidReciprocal:        true,
+	})
+	testutil.AssertEqual(t, cfg.Features.Enabled(),
+		[]string{"ASSIGNMENT_DEBUG_TRACE", "REQUIRE_SENIOR_REVIEWER", "REASSIGN_SAME_TEAM_AS_AUTHOR_ONLY", "AVOID_RECIPROCAL_REVIEWS"})
+}
+
+// TestLoad_ReviewersPerPR tests that the app-wide reviewer count defaults to 2 and reads REVIEWERS_PER_PR
+func TestLoad_ReviewersPerPR(t *testing.T) {
+	cfg, err := Load()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, cfg.App.ReviewersPerPR, 2, "Default reviewers per PR")
+
+	t.Setenv("REVIEWERS_PER_PR", "3")
+
+	cfg, err = Load()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, cfg.App.ReviewersPerPR, 3, "Configured reviewers per PR")
+}
+
+// TestLoad_TLSFiles tests that the TLS certificate and key must be configured together
+func TestLoad_TLSFiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		certFile    string
+		keyFile     string
+		wantErr     bool
+		wantEnabled bool
+	}{
+		{name: "plaintext by default"},
+		{name: "both files", certFile: "/etc/tls/server.crt", keyFile: "/etc/tls/server.key", wantEnabled: true},
+		{name: "certificate without key", certFile: "/etc/tls/server.crt", wantErr: true},
+		{name: "key without certificate", keyFile: "/etc/tls/server.key", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TLS_CERT_FILE", tt.certFile)
+			t.Setenv("TLS_KEY_FILE", tt.keyFile)
+
+			cfg, err := Load()
+
+			if tt.wantErr {
+				testutil.AssertTrue(t, err != nil, "Unpaired TLS file rejected")
+				return
+			}
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, cfg.Server.TLSEnabled(), tt.wantEnabled, "TLS enabled")
+		})
+	}
+}
+
+// TestLoad_FeatureValidation tests that invalid values and disallowed combinations are rejected
+func TestLoad_FeatureValidation(t *testing.T) {
+	t.Run("not a boolean", func(t *testing.T) {
+		t.Setenv("PREFER_AVAILABLE_REVIEWERS", "sometimes")
+
+		_, err := Load()
+		testutil.AssertTrue(t, err != nil, "Invalid boolean rejected")
+	})
+
+	t.Run("debug trace in production", func(t *testing.T) {
+		t.Setenv("APP_ENV", "production")
+		t.Setenv("ASSIGNMENT_DEBUG_TRACE", "true")
+
+		_, err := Load()
+		testutil.AssertTrue(t, err != nil, "Debug trace rejected in production")
+	})
+
+	t.Run("debug trace in development", func(t *testing.T) {
+		t.Setenv("APP_ENV", "development")
+		t.Setenv("ASSIGNMENT_DEBUG_TRACE", "true")
+
+		_, err := Load()
+		testutil.AssertNoError(t, err)
+	})
+}
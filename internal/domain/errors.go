@@ -19,27 +19,66 @@ var (
 	// ErrNotAssigned - ревьювер не назначен на этот PR
 	ErrNotAssigned = errors.New("reviewer is not assigned to this PR")
 
+	// ErrAlreadyAssigned - пользователь уже назначен ревьювером этого PR
+	ErrAlreadyAssigned = errors.New("reviewer is already assigned to this PR")
+
+	// ErrAuthorReviewer - попытка назначить автора ревьювером собственного PR
+	ErrAuthorReviewer = errors.New("author cannot review own pull request")
+
+	// ErrSelfReview - то же правило для ручного добавления автора ревьювером (AddReviewer);
+	// это та же ошибка, чтобы клиенты обрабатывали один код AUTHOR_REVIEWER
+	ErrSelfReview = ErrAuthorReviewer
+
 	// ErrNoCandidate - нет доступных кандидатов для назначения
 	ErrNoCandidate = errors.New("no active replacement candidate in team")
 
+	// ErrTargetIneligible - явно выбранный ревьювер (new_user_id замены или пользователь
+	// AddReviewer) неактивен или не проходит правила отбора
+	ErrTargetIneligible = errors.New("requested reviewer is not eligible")
+
+	// ErrAuthorInactive - автор PR деактивирован
+	ErrAuthorInactive = errors.New("author is inactive")
+
+	// ErrNoReviewers - мердж PR без ревьюверов запрещён настройкой BLOCK_MERGE_WITHOUT_REVIEWERS
+	ErrNoReviewers = errors.New("cannot merge pull request without reviewers")
+
+	// ErrAuthorPRLimit - у автора уже максимум открытых PR (MAX_OPEN_PRS_PER_AUTHOR)
+	ErrAuthorPRLimit = errors.New("author has reached the open pull request limit")
+
+	// ErrUndoExpired - последнее назначение сделано раньше окна отмены (UNDO_WINDOW_MINUTES)
+	ErrUndoExpired = errors.New("assignment undo window has expired")
+
 	// ErrNotFound - ресурс не найден
 	ErrNotFound = errors.New("resource not found")
 
 	// ErrInvalidInput - некорректные входные данные
 	ErrInvalidInput = errors.New("invalid input data")
+
+	// ErrUnavailable - хранилище временно недоступно (нет соединения с БД).
+	// Текст ошибки отдаётся клиенту вместо исходной, чтобы не раскрывать детали подключения
+	ErrUnavailable = errors.New("service temporarily unavailable")
+
+	// ErrTimeout - обработка запроса не уложилась в отведённое время
+	ErrTimeout = errors.New("request timed out")
 )
 
 // ErrorCode представляет код ошибки API
 type ErrorCode string
 
 const (
-	CodeTeamExists    ErrorCode = "TEAM_EXISTS"
-	CodePRExists      ErrorCode = "PR_EXISTS"
-	CodePRMerged      ErrorCode = "PR_MERGED"
-	CodeNotAssigned   ErrorCode = "NOT_ASSIGNED"
-	CodeNoCandidate   ErrorCode = "NO_CANDIDATE"
-	CodeNotFound      ErrorCode = "NOT_FOUND"
-	CodeInternalError ErrorCode = "INTERNAL_ERROR"
+	CodeTeamExists       ErrorCode = "TEAM_EXISTS"
+	CodePRExists         ErrorCode = "PR_EXISTS"
+	CodePRMerged         ErrorCode = "PR_MERGED"
+	CodeNotAssigned      ErrorCode = "NOT_ASSIGNED"
+	CodeAlreadyAssigned  ErrorCode = "ALREADY_ASSIGNED"
+	CodeNoCandidate      ErrorCode = "NO_CANDIDATE"
+	CodeTargetIneligible ErrorCode = "TARGET_INELIGIBLE"
+	CodeAuthorReviewer   ErrorCode = "AUTHOR_REVIEWER"
+	CodeNotFound         ErrorCode = "NOT_FOUND"
+	CodeUnprocessable    ErrorCode = "UNPROCESSABLE"
+	CodeUnavailable      ErrorCode = "SERVICE_UNAVAILABLE"
+	CodeTimeout          ErrorCode = "TIMEOUT"
+	CodeInternalError    ErrorCode = "INTERNAL_ERROR"
 )
 
 // MapErrorToCode преобразует доменную ошибку в код API
@@ -53,8 +92,21 @@ func MapErrorToCode(err error) ErrorCode {
 		return CodePRMerged
 	case errors.Is(err, ErrNotAssigned):
 		return CodeNotAssigned
+	case errors.Is(err, ErrAlreadyAssigned):
+		return CodeAlreadyAssigned
 	case errors.Is(err, ErrNoCandidate):
 		return CodeNoCandidate
+	case errors.Is(err, ErrTargetIneligible):
+		return CodeTargetIneligible
+	case errors.Is(err, ErrAuthorReviewer):
+		return CodeAuthorReviewer
+	case errors.Is(err, ErrAuthorInactive), errors.Is(err, ErrNoReviewers), errors.Is(err, ErrAuthorPRLimit),
+		errors.Is(err, ErrUndoExpired):
+		return CodeUnprocessable
+	case errors.Is(err, ErrUnavailable):
+		return CodeUnavailable
+	case errors.Is(err, ErrTimeout):
+		return CodeTimeout
 	case errors.Is(err, ErrNotFound):
 		return CodeNotFound
 	default:
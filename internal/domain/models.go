@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
 
 // PRStatus представляет статус Pull Request
 type PRStatus string
@@ -15,25 +19,143 @@ func (s PRStatus) IsValid() bool {
 	return s == PRStatusOpen || s == PRStatusMerged
 }
 
+// PRSize - размер PR по числу изменённых строк; от него зависит число ревьюверов
+type PRSize string
+
+const (
+	PRSizeSmall  PRSize = "small"
+	PRSizeMedium PRSize = "medium"
+	PRSizeLarge  PRSize = "large"
+)
+
+// IsValid проверяет, что размер PR известен
+func (s PRSize) IsValid() bool {
+	return s == PRSizeSmall || s == PRSizeMedium || s == PRSizeLarge
+}
+
+// Уровни сеньорности пользователя (0 - не указан)
+const (
+	SeniorityJunior = 1
+	SeniorityMiddle = 2
+	SenioritySenior = 3
+)
+
 // User представляет пользователя системы
 type User struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	TeamName    string `json:"team_name"`
+	IsActive    bool   `json:"is_active"`
+	ReviewGroup string `json:"review_group,omitempty"`
+	Seniority   int    `json:"seniority,omitempty"`
+
+	// MaxDailyAssignments - персональный дневной лимит назначений (0 - лимит из конфигурации)
+	MaxDailyAssignments int `json:"max_daily_assignments,omitempty"`
+
+	// AvailableFrom/AvailableTo - часы доступности в течение суток [from, to); nil - доступен всегда
+	AvailableFrom *int `json:"available_from,omitempty"`
+	AvailableTo   *int `json:"available_to,omitempty"`
+}
+
+// IsSenior проверяет, является ли пользователь сеньором
+func (u User) IsSenior() bool {
+	return u.Seniority >= SenioritySenior
+}
+
+// IsAvailableAt проверяет, попадает ли час hour в окно доступности пользователя.
+// Окно может переходить через полночь (например, 22-6). Без окна пользователь доступен всегда
+func (u User) IsAvailableAt(hour int) bool {
+	if u.AvailableFrom == nil || u.AvailableTo == nil {
+		return true
+	}
+
+	from, to := *u.AvailableFrom, *u.AvailableTo
+	if from <= to {
+		return hour >= from && hour < to
+	}
+	return hour >= from || hour < to
 }
 
 // TeamMember представляет участника команды
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	IsActive    bool   `json:"is_active"`
+	ReviewGroup string `json:"review_group,omitempty"`
+	Seniority   int    `json:"seniority,omitempty"`
+
+	// MaxDailyAssignments - персональный дневной лимит назначений (0 - лимит из конфигурации)
+	MaxDailyAssignments int `json:"max_daily_assignments,omitempty"`
+
+	// AvailableFrom/AvailableTo - часы доступности в течение суток [from, to); nil - доступен всегда
+	AvailableFrom *int `json:"available_from,omitempty"`
+	AvailableTo   *int `json:"available_to,omitempty"`
 }
 
 // Team представляет команду
 type Team struct {
 	TeamName string       `json:"team_name"`
 	Members  []TeamMember `json:"members"`
+
+	// ReviewerCount - число ревьюверов на PR команды (0 - значение по умолчанию)
+	ReviewerCount int `json:"reviewer_count,omitempty"`
+
+	// BackupTeamName - резервная команда, из которой добираются ревьюверы,
+	// если в своей команде кандидатов не хватает (пусто - не задана)
+	BackupTeamName string `json:"backup_team_name,omitempty"`
+
+	// Calendar - рабочий календарь команды (nil - все дни рабочие)
+	Calendar *TeamCalendar `json:"calendar,omitempty"`
+}
+
+// TeamCalendar - рабочий календарь команды: рабочие дни недели и праздники
+type TeamCalendar struct {
+	// WorkingDays - рабочие дни недели по ISO (1 - понедельник, 7 - воскресенье); пусто - все дни
+	WorkingDays []int `json:"working_days,omitempty"`
+
+	// Holidays - нерабочие даты в формате YYYY-MM-DD
+	Holidays []string `json:"holidays,omitempty"`
+}
+
+// IsEmpty сообщает, что календарь ничего не ограничивает
+func (c TeamCalendar) IsEmpty() bool {
+	return len(c.WorkingDays) == 0 && len(c.Holidays) == 0
+}
+
+// IsWorkingDay проверяет, рабочий ли день t для команды (дата берётся в часовом поясе t)
+func (c TeamCalendar) IsWorkingDay(t time.Time) bool {
+	if slices.Contains(c.Holidays, t.Format(time.DateOnly)) {
+		return false
+	}
+	if len(c.WorkingDays) == 0 {
+		return true
+	}
+
+	weekday := int(t.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	return slices.Contains(c.WorkingDays, weekday)
+}
+
+// TeamPatch описывает частичное изменение команды: не упомянутые участники не затрагиваются
+type TeamPatch struct {
+	TeamName string `json:"team_name"`
+
+	// AddMembers - добавляемые участники; существующие пользователи переводятся в команду
+	AddMembers []TeamMember `json:"add_members,omitempty"`
+
+	// RemoveMembers - user_id исключаемых участников
+	RemoveMembers []string `json:"remove_members,omitempty"`
+
+	// SetReviewerCount - новое число ревьюверов на PR (nil - не менять)
+	SetReviewerCount *int `json:"set_reviewer_count,omitempty"`
+
+	// SetBackupTeamName - новая резервная команда (nil - не менять, пустая строка - снять)
+	SetBackupTeamName *string `json:"set_backup_team_name,omitempty"`
+
+	// SetCalendar - новый рабочий календарь целиком (nil - не менять, пустой объект - снять)
+	SetCalendar *TeamCalendar `json:"set_calendar,omitempty"`
 }
 
 // PullRequest представляет Pull Request с полной информацией
@@ -43,20 +165,254 @@ type PullRequest struct {
 	AuthorID          string     `json:"author_id"`
 	Status            PRStatus   `json:"status"`
 	AssignedReviewers []string   `json:"assigned_reviewers"`
+	PrimaryReviewer   string     `json:"primary_reviewer,omitempty"`
 	CreatedAt         *time.Time `json:"createdAt,omitempty"`
 	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+
+	// AutoAssignSkipped - причина, по которой автоназначение при создании PR
+	// было пропущено (не хранится в БД, заполняется только в ответе на создание)
+	AutoAssignSkipped string `json:"auto_assign_skipped,omitempty"`
+
+	// ReviewerSource - откуда взяты ревьюверы, если у автора нет команды
+	// (не хранится в БД, заполняется только в ответе на создание)
+	ReviewerSource string `json:"reviewer_source,omitempty"`
+
+	// Warnings - некритичные замечания к отбору ревьюверов (не хранится в БД,
+	// заполняется только в ответе на создание)
+	Warnings []string `json:"warnings,omitempty"`
+
+	// SelectionStrategy - стратегия, которой отобраны ревьюверы (не хранится в БД,
+	// заполняется только в ответе на создание, если автоназначение выполнялось)
+	SelectionStrategy string `json:"selection_strategy,omitempty"`
+
+	// Frozen - PR создан во время заморозки и остался без ревьюверов (не хранится в БД,
+	// заполняется только в ответе на создание)
+	Frozen bool `json:"frozen,omitempty"`
+
+	// Size - переданный при создании размер PR (не хранится в БД,
+	// заполняется только в ответе на создание)
+	Size PRSize `json:"size,omitempty"`
+
+	// ReviewerCount - сколько ревьюверов требовалось с учётом размера PR (не хранится в БД,
+	// заполняется только в ответе на создание, если автоназначение выполнялось)
+	ReviewerCount int `json:"reviewer_count,omitempty"`
+}
+
+// HasReviewer проверяет, назначен ли пользователь userID ревьювером PR.
+// Ревьювер назначается на PR не более одного раза (уникальность pr_reviewers)
+func (pr *PullRequest) HasReviewer(userID string) bool {
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// AutoAssignSkippedTeamTooSmall - в команде автора меньше активных участников,
+// чем MIN_TEAM_SIZE_FOR_AUTOASSIGN
+const AutoAssignSkippedTeamTooSmall = "team_below_min_size"
+
+// AutoAssignSkippedFreeze - PR создан во время заморозки автоназначения (POST /admin/setFreeze)
+const AutoAssignSkippedFreeze = "deploy_freeze"
+
+// Источники ревьюверов для PR автора без команды (внешнего контрибьютора)
+const (
+	// ReviewerSourceDefaultTeam - команда ревьюверов по умолчанию (EXTERNAL_REVIEWER_TEAM)
+	ReviewerSourceDefaultTeam = "default_team"
+
+	// ReviewerSourceGlobalPool - активные пользователи всех команд
+	ReviewerSourceGlobalPool = "global_pool"
+)
+
+// OrphanedReviewer - запись pr_reviewers, ссылающаяся на несуществующего пользователя
+type OrphanedReviewer struct {
+	PullRequestID string   `json:"pull_request_id"`
+	UserID        string   `json:"user_id"`
+	Status        PRStatus `json:"status"`
+}
+
+// RepairedReviewer - результат исправления одной осиротевшей записи pr_reviewers:
+// ReplacedBy заполнен, если ревьювер заменён, иначе запись удалена
+type RepairedReviewer struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	ReplacedBy    string `json:"replaced_by,omitempty"`
+}
+
+// Виды нарушений инвариантов назначения ревьюверов
+const (
+	// ViolationAuthorIsReviewer - автор назначен ревьювером своего PR
+	ViolationAuthorIsReviewer = "author_is_reviewer"
+	// ViolationChangedAfterMerge - состав ревьюверов смердженного PR менялся после мерджа
+	ViolationChangedAfterMerge = "changed_after_merge"
+	// ViolationMissingPullRequest - запись pr_reviewers ссылается на несуществующий PR
+	ViolationMissingPullRequest = "missing_pull_request"
+	// ViolationMissingUser - запись pr_reviewers ссылается на несуществующего пользователя
+	ViolationMissingUser = "missing_user"
+)
+
+// InvariantViolation - нарушение инварианта назначения ревьюверов в данных
+type InvariantViolation struct {
+	Kind          string `json:"kind"`
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+}
+
+// AuditActionForceAssign - принудительное назначение ревьювера в обход правил отбора
+const AuditActionForceAssign = "force_assign"
+
+// AssignmentAuditEntry представляет запись журнала ручных изменений назначений
+type AssignmentAuditEntry struct {
+	PullRequestID string
+	UserID        string
+	Action        string
+	Forced        bool
+	CreatedAt     time.Time
+}
+
+// Действия в истории ревьюверов PR
+const (
+	ReviewerActionAssign   = "assign"
+	ReviewerActionRemove   = "remove"
+	ReviewerActionReassign = "reassign"
+
+	// ReviewerActionUndo - отмена последнего назначения: UserID снят с PR,
+	// ReplacedBy - возвращённый прежний ревьювер (пусто при отмене назначения)
+	ReviewerActionUndo = "undo"
+)
+
+// ReviewerHistoryEntry представляет событие истории ревьюверов PR.
+// Для reassign UserID - заменённый ревьювер, ReplacedBy - его замена
+type ReviewerHistoryEntry struct {
+	PullRequestID string    `json:"-"`
+	UserID        string    `json:"user_id"`
+	Action        string    `json:"action"`
+	ReplacedBy    string    `json:"replaced_by,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// SelectionTrace - трассировка автоотбора, при котором назначен ревьювер (JSON,
+	// только для назначений и замен). Не входит в ответ истории, её отдаёт explainAssignment
+	SelectionTrace json.RawMessage `json:"-"`
+}
+
+// SelectionDecision - решение автоотбора ревьюверов при создании PR: размер пула кандидатов,
+// выбранные ревьюверы (возможно, ни одного) и стратегия отбора
+type SelectionDecision struct {
+	PullRequestID string    `json:"pull_request_id"`
+	PoolSize      int       `json:"pool_size"`
+	Reviewers     []string  `json:"reviewers"`
+	Strategy      string    `json:"strategy"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// SLABreach - открытый PR, самое раннее назначение ревьювера которого старше SLA ревью.
+// BreachedHours - на сколько часов SLA превышен
+type SLABreach struct {
+	PullRequestID    string    `json:"pull_request_id"`
+	PullRequestName  string    `json:"pull_request_name"`
+	AuthorID         string    `json:"author_id"`
+	OldestAssignedAt time.Time `json:"oldest_assigned_at"`
+	BreachedHours    float64   `json:"breached_hours"`
+}
+
+// TeamPRSummary - сводка по PR авторов команды. UnderstaffedPRs - открытые PR, у которых
+// ревьюверов меньше числа команды; AvgTimeToMerge - среднее время от создания до мерджа
+// (0, если смердженных PR нет)
+type TeamPRSummary struct {
+	OpenPRs         int
+	UnderstaffedPRs int
+	MergedPRs       int
+	AvgTimeToMerge  time.Duration
+}
+
+// ReviewerPair - пара ревьюверов, назначенных на один PR. First < Second,
+// поэтому пара не зависит от порядка назначения
+type ReviewerPair struct {
+	First  string
+	Second string
+}
+
+// NewReviewerPair создаёт пару из двух user_id в каноническом порядке
+func NewReviewerPair(a, b string) ReviewerPair {
+	if b < a {
+		a, b = b, a
+	}
+	return ReviewerPair{First: a, Second: b}
+}
+
+// ReviewConflict - конфликт интересов: пользователи пары не ревьюят PR друг друга.
+// UserA < UserB, поэтому конфликт не зависит от порядка, в котором его задали
+type ReviewConflict struct {
+	UserA     string     `json:"user_a"`
+	UserB     string     `json:"user_b"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// NewReviewConflict создаёт конфликт двух user_id в каноническом порядке
+func NewReviewConflict(a, b string) ReviewConflict {
+	pair := NewReviewerPair(a, b)
+	return ReviewConflict{UserA: pair.First, UserB: pair.Second}
+}
+
+// TrendInterval задаёт шаг агрегации временного ряда статистики
+type TrendInterval string
+
+const (
+	TrendIntervalDay  TrendInterval = "day"
+	TrendIntervalWeek TrendInterval = "week"
+)
+
+// IsValid проверяет валидность шага агрегации
+func (i TrendInterval) IsValid() bool {
+	return i == TrendIntervalDay || i == TrendIntervalWeek
+}
+
+// TrendBucket содержит число созданных и смердженных PR за один интервал
+type TrendBucket struct {
+	Date    string `json:"date"`
+	Created int    `json:"created"`
+	Merged  int    `json:"merged"`
+}
+
+// AuthorStats содержит число PR одного автора по статусам
+type AuthorStats struct {
+	AuthorID  string `json:"author_id"`
+	Username  string `json:"username"`
+	TotalPRs  int    `json:"total_prs"`
+	OpenPRs   int    `json:"open_prs"`
+	MergedPRs int    `json:"merged_prs"`
+}
+
+// TeamLoadStats содержит нагрузку ревью на одну команду
+type TeamLoadStats struct {
+	TeamName        string `json:"team_name"`
+	OpenAssignments int    `json:"open_assignments"`
+	ActiveReviewers int    `json:"active_reviewers"`
+}
+
+// Page задаёт окно выборки для списков (Limit 0 - без ограничения)
+type Page struct {
+	Limit  int
+	Offset int
 }
 
 // PullRequestShort представляет краткую информацию о PR
 type PullRequestShort struct {
-	PullRequestID   string   `json:"pull_request_id"`
-	PullRequestName string   `json:"pull_request_name"`
-	AuthorID        string   `json:"author_id"`
-	Status          PRStatus `json:"status"`
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	Status          PRStatus   `json:"status"`
+	CreatedAt       *time.Time `json:"createdAt,omitempty"`
+	MergedAt        *time.Time `json:"mergedAt,omitempty"`
 }
 
 // UserPullRequests представляет список PR'ов пользователя
 type UserPullRequests struct {
 	UserID       string             `json:"user_id"`
 	PullRequests []PullRequestShort `json:"pull_requests"`
+	// Total - число всех PR пользователя без учёта окна Limit/Offset
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
 }
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // UserAssignmentStats представляет статистику назначений пользователя
 type UserAssignmentStats struct {
@@ -21,6 +24,25 @@ type TeamRepository interface {
 
 	// Exists проверяет существование команды
 	Exists(ctx context.Context, teamName string) (bool, error)
+
+	// GetReviewerCount возвращает число ревьюверов на PR команды (0 - не задано)
+	GetReviewerCount(ctx context.Context, teamName string) (int, error)
+
+	// SetReviewerCount задаёт число ревьюверов на PR команды
+	SetReviewerCount(ctx context.Context, teamName string, count int) error
+
+	// GetBackupTeam возвращает резервную команду (пусто - не задана)
+	GetBackupTeam(ctx context.Context, teamName string) (string, error)
+
+	// SetBackupTeam задаёт резервную команду (пустая строка снимает её)
+	SetBackupTeam(ctx context.Context, teamName, backupTeamName string) error
+
+	// GetCalendars возвращает рабочие календари команд teamNames; команды без
+	// календаря в результат не попадают
+	GetCalendars(ctx context.Context, teamNames []string) (map[string]*TeamCalendar, error)
+
+	// SetCalendar заменяет рабочий календарь команды (пустой календарь снимает его)
+	SetCalendar(ctx context.Context, teamName string, calendar *TeamCalendar) error
 }
 
 // UserRepository определяет интерфейс для работы с пользователями
@@ -37,6 +59,9 @@ type UserRepository interface {
 	// GetByTeam получает всех пользователей команды
 	GetByTeam(ctx context.Context, teamName string) ([]User, error)
 
+	// GetByReviewGroup получает всех пользователей группы ревью внутри команды
+	GetByReviewGroup(ctx context.Context, teamName, reviewGroup string) ([]User, error)
+
 	// GetActiveUsersExcludingTeam получает всех активных пользователей кроме указанной команды
 	GetActiveUsersExcludingTeam(ctx context.Context, excludeTeamName string) ([]User, error)
 
@@ -45,6 +70,21 @@ type UserRepository interface {
 
 	// BulkDeactivateByTeam массово деактивирует пользователей команды
 	BulkDeactivateByTeam(ctx context.Context, teamName string) ([]string, error)
+
+	// BulkActivateByTeam массово активирует пользователей команды
+	BulkActivateByTeam(ctx context.Context, teamName string) ([]string, error)
+
+	// AddReviewConflict сохраняет конфликт интересов; повторное добавление ничего не меняет
+	AddReviewConflict(ctx context.Context, conflict ReviewConflict) error
+
+	// RemoveReviewConflict удаляет конфликт интересов (ErrNotFound, если его нет)
+	RemoveReviewConflict(ctx context.Context, conflict ReviewConflict) error
+
+	// ListReviewConflicts возвращает все конфликты интересов
+	ListReviewConflicts(ctx context.Context) ([]ReviewConflict, error)
+
+	// GetReviewConflicts возвращает user_id пользователей в конфликте интересов с userID
+	GetReviewConflicts(ctx context.Context, userID string) ([]string, error)
 }
 
 // PullRequestRepository определяет интерфейс для работы с PR
@@ -61,24 +101,64 @@ type PullRequestRepository interface {
 	// Merge помечает PR как смердженный
 	Merge(ctx context.Context, prID string) (*PullRequest, error)
 
+	// Delete удаляет PR вместе с ревьюверами и его историей (ErrNotFound, если PR нет)
+	Delete(ctx context.Context, prID string) error
+
 	// GetByReviewer получает PR'ы, где пользователь назначен ревьювером
-	GetByReviewer(ctx context.Context, userID string) ([]PullRequestShort, error)
+	GetByReviewer(ctx context.Context, userID string, page Page) ([]PullRequestShort, error)
+
+	// GetByPrimaryReviewer получает PR'ы, где пользователь - основной ревьювер:
+	// сначала открытые, затем от новых к старым
+	GetByPrimaryReviewer(ctx context.Context, userID string) ([]PullRequestShort, error)
+
+	// CountByReviewer возвращает число PR, где пользователь назначен ревьювером
+	CountByReviewer(ctx context.Context, userID string) (int, error)
+
+	// CountOpenByAuthor возвращает число открытых PR автора
+	CountOpenByAuthor(ctx context.Context, authorID string) (int, error)
 
 	// GetOpenByReviewer получает открытые PR'ы пользователя
 	GetOpenByReviewer(ctx context.Context, userID string) ([]string, error)
 
+	// GetOpenByAuthorTeam получает открытые PR (с ревьюверами), авторы которых состоят в команде teamName
+	GetOpenByAuthorTeam(ctx context.Context, teamName string) ([]*PullRequest, error)
+
 	// Exists проверяет существование PR
 	Exists(ctx context.Context, prID string) (bool, error)
 
+	// Методы изменения состава ревьюверов (AssignReviewers, ReplaceReviewers, RemoveReviewer,
+	// AddReviewer, SetPrimaryReviewer, ReassignReviewer) возвращают ErrPRMerged для смердженного PR
+
 	// AssignReviewers назначает ревьюверов на PR
 	AssignReviewers(ctx context.Context, prID string, reviewerIDs []string) error
 
+	// ReplaceReviewers атомарно заменяет весь состав ревьюверов PR (первый становится основным)
+	ReplaceReviewers(ctx context.Context, prID string, reviewerIDs []string) error
+
 	// RemoveReviewer удаляет ревьювера из PR
 	RemoveReviewer(ctx context.Context, prID string, reviewerID string) error
 
-	// AddReviewer добавляет ревьювера в PR
+	// AddReviewer добавляет ревьювера в PR (ErrAlreadyAssigned, если он уже назначен)
 	AddReviewer(ctx context.Context, prID string, reviewerID string) error
 
+	// SetPrimaryReviewer делает назначенного ревьювера основным (ровно один на PR)
+	SetPrimaryReviewer(ctx context.Context, prID, reviewerID string) error
+
+	// RecordAssignmentAudit добавляет запись в журнал изменений назначений
+	RecordAssignmentAudit(ctx context.Context, entry *AssignmentAuditEntry) error
+
+	// RecordReviewerHistory добавляет события в историю ревьюверов PR
+	RecordReviewerHistory(ctx context.Context, entries []ReviewerHistoryEntry) error
+
+	// RecordSelectionDecision сохраняет решение автоотбора ревьюверов
+	RecordSelectionDecision(ctx context.Context, decision *SelectionDecision) error
+
+	// GetSelectionDecisions возвращает решения автоотбора по PR в порядке записи
+	GetSelectionDecisions(ctx context.Context, prID string) ([]SelectionDecision, error)
+
+	// GetReviewerHistory возвращает историю ревьюверов PR в порядке событий
+	GetReviewerHistory(ctx context.Context, prID string) ([]ReviewerHistoryEntry, error)
+
 	// GetReviewers получает список ревьюверов PR
 	GetReviewers(ctx context.Context, prID string) ([]string, error)
 
@@ -88,9 +168,70 @@ type PullRequestRepository interface {
 	// GetPRStats возвращает общую статистику по PR (total, open, merged, avg_reviewers)
 	GetPRStats(ctx context.Context) (map[string]int, error)
 
+	// GetTrends возвращает непрерывный ряд интервалов с числом созданных и смердженных PR
+	// между from и to включительно (интервалы без активности содержат нули).
+	// Непустой teamName ограничивает ряд PR, авторы которых состоят в этой команде
+	GetTrends(ctx context.Context, from, to time.Time, interval TrendInterval, teamName string) ([]TrendBucket, error)
+
 	// GetUserAssignmentStats возвращает статистику назначений по пользователям
 	GetUserAssignmentStats(ctx context.Context) (map[string]*UserAssignmentStats, error)
 
-	// List возвращает список PR с фильтрами
-	List(ctx context.Context, status string) ([]*PullRequest, error)
+	// GetAuthorStats возвращает число PR каждого автора (по убыванию общего числа).
+	// Непустой teamName ограничивает выборку авторами из этой команды
+	GetAuthorStats(ctx context.Context, teamName string) ([]AuthorStats, error)
+
+	// GetTeamLoadStats возвращает для каждой команды число назначений её участников на открытые PR
+	// и число активных участников, по убыванию числа назначений
+	GetTeamLoadStats(ctx context.Context) ([]TeamLoadStats, error)
+
+	// GetTeamPRSummary возвращает сводку по PR авторов команды teamName; открытые PR
+	// с числом ревьюверов меньше reviewerCount считаются неукомплектованными
+	GetTeamPRSummary(ctx context.Context, teamName string, reviewerCount int) (*TeamPRSummary, error)
+
+	// CountOpenByUsers возвращает число открытых PR, где каждый из userIDs назначен ревьювером.
+	// Пользователи без открытых ревью в результат не попадают
+	CountOpenByUsers(ctx context.Context, userIDs []string) (map[string]int, error)
+
+	// GetReviewerPairCounts возвращает, сколько раз каждая пара ревьюверов назначалась вместе
+	// на последние recentPRs PR автора authorID. Пары, ни разу не назначавшиеся вместе,
+	// в результат не попадают
+	GetReviewerPairCounts(ctx context.Context, authorID string, recentPRs int) (map[ReviewerPair]int, error)
+
+	// GetReciprocalReviewers возвращает тех из candidateIDs, чей последний (по времени создания)
+	// PR ревьюит reviewerID
+	GetReciprocalReviewers(ctx context.Context, reviewerID string, candidateIDs []string) ([]string, error)
+
+	// GetUserAssignmentStatsByUser возвращает статистику назначений одного пользователя
+	GetUserAssignmentStatsByUser(ctx context.Context, userID string) (*UserAssignmentStats, error)
+
+	// GetOrphanedReviewers возвращает назначения ревьюверов, которых нет в users
+	GetOrphanedReviewers(ctx context.Context) ([]OrphanedReviewer, error)
+
+	// GetInvariantViolations возвращает нарушения инвариантов назначения: автор-ревьювер,
+	// изменения ревьюверов смердженного PR после мерджа и назначения на несуществующие PR
+	GetInvariantViolations(ctx context.Context) ([]InvariantViolation, error)
+
+	// DeleteOrphanedReviewer удаляет назначение удалённого пользователя независимо от статуса PR
+	// (исправление целостности данных, а не изменение PR)
+	DeleteOrphanedReviewer(ctx context.Context, prID, userID string) error
+
+	// GetAssignmentCountsSince возвращает число назначений каждого пользователя начиная с since
+	GetAssignmentCountsSince(ctx context.Context, since time.Time) (map[string]int, error)
+
+	// GetOpenAssignedBefore возвращает открытые PR, самое раннее назначение ревьювера
+	// которых сделано раньше before (от самых давних), с заполненным OldestAssignedAt
+	GetOpenAssignedBefore(ctx context.Context, before time.Time) ([]SLABreach, error)
+
+	// List возвращает список PR с любым из статусов statuses (пустой список - все PR)
+	List(ctx context.Context, statuses []string, page Page) ([]*PullRequest, error)
+
+	// StreamList последовательно передаёт PR (с ревьюверами) в fn по мере чтения из БД.
+	// Внутри транзакции fn не должна обращаться к БД, пока идёт чтение
+	StreamList(ctx context.Context, statuses []string, fn func(pr *PullRequest) error) error
+
+	// GetFreezeUntil возвращает окончание заморозки автоназначения (nil, если она не задана)
+	GetFreezeUntil(ctx context.Context) (*time.Time, error)
+
+	// SetFreezeUntil задаёт окончание заморозки автоназначения (nil снимает заморозку)
+	SetFreezeUntil(ctx context.Context, until *time.Time) error
 }
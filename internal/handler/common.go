@@ -1,13 +1,24 @@
 package handler
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"go.uber.org/zap"
 	"reviewservice/internal/domain"
 )
 
+// contentTypeNDJSON - MIME-тип для потоковой выдачи (newline-delimited JSON)
+const contentTypeNDJSON = "application/x-ndjson"
+
 // ErrorResponse представляет структуру ошибки API
 type ErrorResponse struct {
 	Error ErrorDetail `json:"error"`
@@ -51,23 +62,120 @@ func writeError(w http.ResponseWriter, logger *zap.Logger, statusCode int, err e
 
 // handleDomainError обрабатывает доменные ошибки и возвращает соответствующий HTTP статус
 func handleDomainError(w http.ResponseWriter, logger *zap.Logger, err error) {
+	// Некорректный ввод, обнаруженный сервисом, отдаётся так же, как ошибки валидации в хендлерах
+	if errors.Is(err, domain.ErrInvalidInput) {
+		writeError(w, logger, http.StatusBadRequest, err, domain.CodeNotFound)
+		return
+	}
+
+	// Истёкший дедлайн запроса (см. timeoutMiddleware) - таймаут, а не ошибка сервиса
+	if errors.Is(err, context.DeadlineExceeded) {
+		logger.Warn("request deadline exceeded", zap.Error(err))
+		err = domain.ErrTimeout
+	}
+
+	// Потеря соединения с БД - временная недоступность, а не ошибка сервиса.
+	// Исходная ошибка может содержать фрагменты DSN, поэтому клиенту уходит общий текст
+	if isConnectionError(err) {
+		logger.Error("database connection error", zap.Error(err))
+		err = domain.ErrUnavailable
+	}
+
 	code := domain.MapErrorToCode(err)
 
 	switch code {
 	case domain.CodeTeamExists:
 		writeError(w, logger, http.StatusBadRequest, err, code)
-	case domain.CodePRExists, domain.CodePRMerged, domain.CodeNotAssigned, domain.CodeNoCandidate:
+	case domain.CodePRExists, domain.CodePRMerged, domain.CodeNotAssigned, domain.CodeAlreadyAssigned,
+		domain.CodeNoCandidate, domain.CodeTargetIneligible, domain.CodeAuthorReviewer:
 		writeError(w, logger, http.StatusConflict, err, code)
 	case domain.CodeNotFound:
 		writeError(w, logger, http.StatusNotFound, err, code)
+	case domain.CodeUnprocessable:
+		// Запрос корректен синтаксически, но нарушает бизнес-правило
+		writeError(w, logger, http.StatusUnprocessableEntity, err, code)
+	case domain.CodeUnavailable:
+		writeError(w, logger, http.StatusServiceUnavailable, err, code)
+	case domain.CodeTimeout:
+		writeError(w, logger, http.StatusGatewayTimeout, err, code)
 	default:
 		// Для неизвестных ошибок возвращаем 500 Internal Server Error
 		writeError(w, logger, http.StatusInternalServerError, err, code)
 	}
 }
 
+// isConnectionError определяет ошибки уровня соединения с БД: разорванное или закрытое
+// соединение и сетевые ошибки (отказ в подключении, таймаут, обрыв), которые pgx
+// оборачивает вокруг *net.OpError
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
 // decodeJSON декодирует JSON из request body
 func decodeJSON(r *http.Request, v interface{}) error {
 	defer r.Body.Close()
 	return json.NewDecoder(r.Body).Decode(v)
 }
+
+// flexibleBool - логический флаг запроса, который кроме true/false принимает 1/0
+// и строки "true"/"false": часть клиентов кодирует флаги числами. null, как и для
+// обычного bool, оставляет значение без изменений; прочие значения - ошибка декодирования
+type flexibleBool bool
+
+// UnmarshalJSON реализует json.Unmarshaler
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "null":
+	case "true", "1", `"true"`:
+		*b = true
+	case "false", "0", `"false"`:
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean value %s", data)
+	}
+	return nil
+}
+
+// acceptsNDJSON проверяет, запросил ли клиент потоковый ответ в формате NDJSON
+func acceptsNDJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), contentTypeNDJSON)
+}
+
+// PaginationConfig задаёт размер страницы по умолчанию и максимально допустимый
+type PaginationConfig struct {
+	DefaultPageSize int
+	MaxPageSize     int
+}
+
+// parsePagination разбирает параметры limit/offset запроса. Без limit используется
+// размер по умолчанию, слишком большой limit урезается до максимума.
+// Нечисловые и отрицательные значения (а также limit=0) считаются ошибкой
+func parsePagination(r *http.Request, cfg PaginationConfig) (domain.Page, error) {
+	page := domain.Page{Limit: cfg.DefaultPageSize}
+	query := r.URL.Query()
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return domain.Page{}, domain.ErrInvalidInput
+		}
+		page.Limit = limit
+	}
+	if cfg.MaxPageSize > 0 && page.Limit > cfg.MaxPageSize {
+		page.Limit = cfg.MaxPageSize
+	}
+
+	if raw := query.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return domain.Page{}, domain.ErrInvalidInput
+		}
+		page.Offset = offset
+	}
+
+	return page, nil
+}
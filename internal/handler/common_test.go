@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"reviewservice/internal/domain"
+	"reviewservice/internal/testutil"
+)
+
+// TestParsePagination tests limit/offset defaults, clamping and validation
+func TestParsePagination(t *testing.T) {
+	cfg := PaginationConfig{DefaultPageSize: 20, MaxPageSize: 100}
+
+	tests := []struct {
+		name     string
+		query    string
+		wantPage domain.Page
+		wantErr  bool
+	}{
+		{
+			name:     "defaults when no params given",
+			query:    "",
+			wantPage: domain.Page{Limit: 20, Offset: 0},
+		},
+		{
+			name:     "explicit limit and offset",
+			query:    "?limit=10&offset=30",
+			wantPage: domain.Page{Limit: 10, Offset: 30},
+		},
+		{
+			name:     "limit is clamped to max",
+			query:    "?limit=1000",
+			wantPage: domain.Page{Limit: 100, Offset: 0},
+		},
+		{
+			name:    "negative limit is rejected",
+			query:   "?limit=-5",
+			wantErr: true,
+		},
+		{
+			name:    "zero limit is rejected",
+			query:   "?limit=0",
+			wantErr: true,
+		},
+		{
+			name:    "negative offset is rejected",
+			query:   "?offset=-1",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric limit is rejected",
+			query:   "?limit=abc",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/pullRequest/list"+tt.query, nil)
+
+			page, err := parsePagination(req, cfg)
+
+			if tt.wantErr {
+				testutil.AssertErrorIs(t, err, domain.ErrInvalidInput)
+				return
+			}
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, page, tt.wantPage, "Page")
+		})
+	}
+}
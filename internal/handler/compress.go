@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// compressMinSize - ответы меньше этого размера (в байтах) отдаются без сжатия
+const compressMinSize = 1024
+
+// compressMiddleware сжимает JSON ответы gzip, если клиент поддерживает его (Accept-Encoding).
+// В отличие от middleware.Compress из chi, решение о сжатии откладывается до накопления
+// minSize байт: короткие ответы уходят как есть. Остальные типы контента (в том числе
+// потоковый NDJSON) не сжимаются и пишутся сразу, без буферизации
+func compressMiddleware(minSize int) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			if !acceptsGzip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressResponseWriter{ResponseWriter: w, minSize: minSize, status: http.StatusOK}
+			defer cw.close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// acceptsGzip проверяет, принимает ли клиент ответ в gzip (без явного q=0)
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		if strings.TrimSpace(coding) != "gzip" {
+			continue
+		}
+
+		q, found := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !found {
+			return true
+		}
+		weight, err := strconv.ParseFloat(q, 64)
+		return err == nil && weight > 0
+	}
+	return false
+}
+
+// compressResponseWriter буферизует начало ответа, пока не станет ясно,
+// стоит ли его сжимать, а затем пишет либо через gzip, либо напрямую
+type compressResponseWriter struct {
+	http.ResponseWriter
+
+	minSize int
+	status  int
+	buf     []byte
+
+	gz          *gzip.Writer
+	passthrough bool
+}
+
+// WriteHeader запоминает статус: заголовки отправляются, когда решение о сжатии принято
+func (cw *compressResponseWriter) WriteHeader(code int) {
+	if cw.gz != nil || cw.passthrough {
+		return
+	}
+	cw.status = code
+}
+
+// Write копит данные до minSize байт, после чего начинает сжатие
+func (cw *compressResponseWriter) Write(p []byte) (int, error) {
+	switch {
+	case cw.gz != nil:
+		return cw.gz.Write(p)
+	case cw.passthrough:
+		return cw.ResponseWriter.Write(p)
+	case !cw.compressible():
+		if err := cw.startPassthrough(); err != nil {
+			return 0, err
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) >= cw.minSize {
+		if err := cw.startGzip(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush отправляет накопленные данные клиенту. Явный Flush до порога означает,
+// что обработчику важна задержка, поэтому ответ уходит без сжатия
+func (cw *compressResponseWriter) Flush() {
+	switch {
+	case cw.gz != nil:
+		_ = cw.gz.Flush()
+	case !cw.passthrough:
+		_ = cw.startPassthrough()
+	}
+
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap даёт http.ResponseController доступ к исходному ResponseWriter
+func (cw *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// compressible проверяет, что тип ответа - JSON (NDJSON и прочее не сжимается)
+func (cw *compressResponseWriter) compressible() bool {
+	contentType, _, _ := strings.Cut(cw.Header().Get("Content-Type"), ";")
+	return strings.TrimSpace(contentType) == "application/json"
+}
+
+// startGzip отправляет заголовки сжатого ответа и сжимает накопленный буфер
+func (cw *compressResponseWriter) startGzip() error {
+	cw.Header().Set("Content-Encoding", "gzip")
+	cw.Header().Del("Content-Length")
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	cw.gz = gzip.NewWriter(cw.ResponseWriter)
+	_, err := cw.gz.Write(cw.buf)
+	cw.buf = nil
+	return err
+}
+
+// startPassthrough отправляет заголовки и накопленный буфер без сжатия
+func (cw *compressResponseWriter) startPassthrough() error {
+	cw.passthrough = true
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	_, err := cw.ResponseWriter.Write(cw.buf)
+	cw.buf = nil
+	return err
+}
+
+// close завершает ответ: дописывает gzip-поток либо отдаёт короткий ответ как есть
+func (cw *compressResponseWriter) close() {
+	switch {
+	case cw.gz != nil:
+		_ = cw.gz.Close()
+	case !cw.passthrough:
+		_ = cw.startPassthrough()
+	}
+}
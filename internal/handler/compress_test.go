@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"reviewservice/internal/testutil"
+)
+
+// TestCompressMiddleware tests gzip negotiation, the size threshold and NDJSON passthrough
+func TestCompressMiddleware(t *testing.T) {
+	large := map[string]interface{}{"items": strings.Split(strings.Repeat("pull-request,", 200), ",")}
+	small := map[string]interface{}{"status": "ok"}
+
+	jsonHandler := func(data interface{}) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, data)
+		})
+	}
+
+	serve := func(h http.Handler, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		compressMiddleware(compressMinSize)(h).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("large JSON is gzipped", func(t *testing.T) {
+		rec := serve(jsonHandler(large), "gzip, deflate")
+
+		testutil.AssertEqual(t, rec.Code, http.StatusOK, "status")
+		testutil.AssertEqual(t, rec.Header().Get("Content-Encoding"), "gzip", "Content-Encoding")
+
+		gz, err := gzip.NewReader(rec.Body)
+		testutil.AssertNoError(t, err)
+		body, err := io.ReadAll(gz)
+		testutil.AssertNoError(t, err)
+
+		want, err := json.Marshal(large)
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, string(body), string(want)+"\n", "decompressed body")
+	})
+
+	t.Run("small JSON is not compressed", func(t *testing.T) {
+		rec := serve(jsonHandler(small), "gzip")
+
+		testutil.AssertEqual(t, rec.Header().Get("Content-Encoding"), "", "Content-Encoding")
+		testutil.AssertEqual(t, rec.Body.String(), "{\"status\":\"ok\"}\n", "body")
+	})
+
+	t.Run("no gzip without Accept-Encoding", func(t *testing.T) {
+		rec := serve(jsonHandler(large), "")
+		testutil.AssertEqual(t, rec.Header().Get("Content-Encoding"), "", "Content-Encoding")
+
+		rec = serve(jsonHandler(large), "gzip;q=0")
+		testutil.AssertEqual(t, rec.Header().Get("Content-Encoding"), "", "Content-Encoding with q=0")
+	})
+
+	t.Run("NDJSON stream is passed through and flushed", func(t *testing.T) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentTypeNDJSON)
+			w.WriteHeader(http.StatusOK)
+			for i := 0; i < 100; i++ {
+				_, _ = w.Write([]byte(strings.Repeat("x", 50) + "\n"))
+				w.(http.Flusher).Flush()
+			}
+		})
+
+		rec := serve(h, "gzip")
+
+		testutil.AssertEqual(t, rec.Header().Get("Content-Encoding"), "", "Content-Encoding")
+		testutil.AssertTrue(t, rec.Flushed, "stream must be flushed")
+		testutil.AssertLen(t, strings.Split(strings.TrimSpace(rec.Body.String()), "\n"), 100, "all lines written")
+	})
+}
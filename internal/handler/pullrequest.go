@@ -1,7 +1,14 @@
 package handler
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"reviewservice/internal/domain"
@@ -10,24 +17,34 @@ import (
 
 // PullRequestHandler обрабатывает HTTP запросы для работы с Pull Request'ами
 type PullRequestHandler struct {
-	prService *service.PullRequestService
-	logger    *zap.Logger
+	prService  *service.PullRequestService
+	pagination PaginationConfig
+	logger     *zap.Logger
 }
 
 // NewPullRequestHandler создаёт новый экземпляр PullRequestHandler
-func NewPullRequestHandler(prService *service.PullRequestService, logger *zap.Logger) *PullRequestHandler {
+func NewPullRequestHandler(
+	prService *service.PullRequestService,
+	pagination PaginationConfig,
+	logger *zap.Logger,
+) *PullRequestHandler {
 	return &PullRequestHandler{
-		prService: prService,
-		logger:    logger,
+		prService:  prService,
+		pagination: pagination,
+		logger:     logger,
 	}
 }
 
-// CreatePullRequest обрабатывает POST /pullRequest/create
+// CreatePullRequest обрабатывает POST /pullRequest/create[?dry_run=true]
 func (h *PullRequestHandler) CreatePullRequest(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		PullRequestID   string `json:"pull_request_id"`
-		PullRequestName string `json:"pull_request_name"`
-		AuthorID        string `json:"author_id"`
+		PullRequestID   string   `json:"pull_request_id"`
+		PullRequestName string   `json:"pull_request_name"`
+		AuthorID        string   `json:"author_id"`
+		Paths           []string `json:"paths,omitempty"`
+		CandidatePool   []string `json:"candidate_pool,omitempty"`
+		// Size - размер PR (small, medium, large), определяет число ревьюверов
+		Size domain.PRSize `json:"size,omitempty"`
 	}
 
 	if err := decodeJSON(r, &req); err != nil {
@@ -41,16 +58,40 @@ func (h *PullRequestHandler) CreatePullRequest(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	pr, err := h.prService.CreatePullRequest(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	// dry_run=true: только показать, кто был бы назначен, ничего не сохраняя
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+			return
+		}
+		dryRun = parsed
+	}
+
+	if dryRun {
+		pr, err := h.prService.PreviewPullRequest(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID, req.Paths, req.CandidatePool, req.Size)
+		if err != nil {
+			handleDomainError(w, h.logger, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"pr": pullRequestView(r, pr), "dry_run": true})
+		return
+	}
+
+	pr, err := h.prService.CreatePullRequest(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID, req.Paths, req.CandidatePool, req.Size)
 	if err != nil {
 		handleDomainError(w, h.logger, err)
 		return
 	}
 
 	response := map[string]interface{}{
-		"pr": pr,
+		"pr": pullRequestView(r, pr),
 	}
 
+	// Location указывает на созданный ресурс (REST-конвенция для 201)
+	w.Header().Set("Location", "/pullRequest/get?pull_request_id="+url.QueryEscape(pr.PullRequestID))
 	writeJSON(w, http.StatusCreated, response)
 }
 
@@ -78,17 +119,62 @@ func (h *PullRequestHandler) MergePullRequest(w http.ResponseWriter, r *http.Req
 	}
 
 	response := map[string]interface{}{
-		"pr": pr,
+		"pr": pullRequestView(r, pr),
 	}
 
 	writeJSON(w, http.StatusOK, response)
 }
 
+// GetPullRequest обрабатывает GET /pullRequest/get
+func (h *PullRequestHandler) GetPullRequest(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	pr, err := h.prService.GetPullRequest(r.Context(), prID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pullRequestView(r, pr),
+	})
+}
+
+// DeletePullRequest обрабатывает DELETE /pullRequest/delete
+func (h *PullRequestHandler) DeletePullRequest(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+	}
+
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	if req.PullRequestID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	if err := h.prService.DeletePullRequest(r.Context(), req.PullRequestID); err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"pull_request_id": req.PullRequestID})
+}
+
 // ReassignReviewer обрабатывает POST /pullRequest/reassign
 func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		PullRequestID string `json:"pull_request_id"`
 		OldUserID     string `json:"old_user_id"`
+		// NewUserID - выбранная замена; пусто - случайный выбор среди подходящих кандидатов
+		NewUserID string `json:"new_user_id,omitempty"`
 	}
 
 	if err := decodeJSON(r, &req); err != nil {
@@ -99,7 +185,8 @@ func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Req
 
 	h.logger.Debug("reassign request",
 		zap.String("pr_id", req.PullRequestID),
-		zap.String("old_user_id", req.OldUserID))
+		zap.String("old_user_id", req.OldUserID),
+		zap.String("new_user_id", req.NewUserID))
 
 	// Валидация
 	if req.PullRequestID == "" || req.OldUserID == "" {
@@ -110,40 +197,512 @@ func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	pr, newReviewerID, err := h.prService.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID)
+	result, err := h.prService.ReassignReviewerTo(r.Context(), req.PullRequestID, req.OldUserID, req.NewUserID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr":               pullRequestView(r, result.PR),
+		"replaced_by":      result.ReplacedBy,
+		"before_reviewers": result.BeforeReviewers,
+		"after_reviewers":  result.AfterReviewers,
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// ForceAssignReviewer обрабатывает POST /admin/forceAssignReviewer
+func (h *PullRequestHandler) ForceAssignReviewer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+	}
+
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	// Валидация
+	if req.PullRequestID == "" || req.UserID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	pr, err := h.prService.ForceAssignReviewer(r.Context(), req.PullRequestID, req.UserID)
 	if err != nil {
 		handleDomainError(w, h.logger, err)
 		return
 	}
 
 	response := map[string]interface{}{
-		"pr":          pr,
-		"replaced_by": newReviewerID,
+		"pr":     pullRequestView(r, pr),
+		"forced": true,
 	}
 
 	writeJSON(w, http.StatusOK, response)
 }
 
+// AddReviewer обрабатывает POST /pullRequest/addReviewer
+func (h *PullRequestHandler) AddReviewer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+	}
+
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	// Валидация
+	if req.PullRequestID == "" || req.UserID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	pr, err := h.prService.AddReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pullRequestView(r, pr),
+	})
+}
+
+// RemoveReviewer обрабатывает POST /pullRequest/removeReviewer
+func (h *PullRequestHandler) RemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+	}
+
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	// Валидация
+	if req.PullRequestID == "" || req.UserID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	pr, err := h.prService.RemoveReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pullRequestView(r, pr),
+	})
+}
+
+// SetPrimaryReviewer обрабатывает POST /pullRequest/setPrimary
+func (h *PullRequestHandler) SetPrimaryReviewer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+	}
+
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	// Валидация
+	if req.PullRequestID == "" || req.UserID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	pr, err := h.prService.SetPrimaryReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr": pullRequestView(r, pr),
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// UndoAssignment обрабатывает POST /pullRequest/undoAssignment
+func (h *PullRequestHandler) UndoAssignment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+	}
+
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	// Валидация
+	if req.PullRequestID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	result, err := h.prService.UndoAssignment(r.Context(), req.PullRequestID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr":     pullRequestView(r, result.PR),
+		"undone": result.Undone,
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// ResetReviewers обрабатывает POST /pullRequest/resetReviewers
+func (h *PullRequestHandler) ResetReviewers(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+	}
+
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	// Валидация
+	if req.PullRequestID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	pr, err := h.prService.ResetReviewers(r.Context(), req.PullRequestID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr": pullRequestView(r, pr),
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// CheckIntegrity обрабатывает GET /admin/integrity
+func (h *PullRequestHandler) CheckIntegrity(w http.ResponseWriter, r *http.Request) {
+	orphans, err := h.prService.CheckIntegrity(r.Context())
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"orphaned_reviewers": orphans,
+		"count":              len(orphans),
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetSelectionDecisions обрабатывает GET /admin/selectionDecisions
+func (h *PullRequestHandler) GetSelectionDecisions(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pr_id")
+	if prID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	decisions, err := h.prService.GetSelectionDecisions(r.Context(), prID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": prID,
+		"decisions":       decisions,
+	})
+}
+
+// SelfCheck обрабатывает GET /admin/selfCheck
+func (h *PullRequestHandler) SelfCheck(w http.ResponseWriter, r *http.Request) {
+	violations, err := h.prService.SelfCheck(r.Context())
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"violations": violations,
+		"count":      len(violations),
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// SetFreeze обрабатывает POST /admin/setFreeze. freeze_until в формате RFC 3339;
+// null или отсутствие поля снимает заморозку
+func (h *PullRequestHandler) SetFreeze(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FreezeUntil *time.Time `json:"freeze_until"`
+	}
+
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	until, err := h.prService.SetFreeze(r.Context(), req.FreezeUntil)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"freeze_until": until,
+		"frozen":       until != nil,
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// RepairReviewers обрабатывает POST /admin/repairReviewers
+func (h *PullRequestHandler) RepairReviewers(w http.ResponseWriter, r *http.Request) {
+	repaired, err := h.prService.RepairReviewers(r.Context())
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"repaired": repaired,
+		"count":    len(repaired),
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// ReconcileReviewerCounts обрабатывает POST /admin/reconcileReviewers
+func (h *PullRequestHandler) ReconcileReviewerCounts(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName string `json:"team_name"`
+	}
+
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	// Валидация
+	if req.TeamName == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	result, err := h.prService.ReconcileReviewerCounts(r.Context(), req.TeamName)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// RebalanceTeam обрабатывает POST /admin/rebalanceTeam?team_name=...[&max_gap=N]
+func (h *PullRequestHandler) RebalanceTeam(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	maxGap := service.DefaultRebalanceMaxGap
+	if value := r.URL.Query().Get("max_gap"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+			return
+		}
+		maxGap = parsed
+	}
+
+	result, err := h.prService.RebalanceTeam(r.Context(), teamName, maxGap)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
 // ListPullRequests обрабатывает GET /pullRequest/list
 func (h *PullRequestHandler) ListPullRequests(w http.ResponseWriter, r *http.Request) {
-	status := r.URL.Query().Get("status") // Опционально: OPEN, MERGED или пусто (все)
+	// Опционально: OPEN, MERGED, несколько статусов через запятую или пусто (все)
+	statuses, err := parseStatuses(r.URL.Query().Get("status"))
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err, domain.CodeNotFound)
+		return
+	}
 
-	// Валидация статуса если указан
-	if status != "" && status != string(domain.PRStatusOpen) && status != string(domain.PRStatusMerged) {
-		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+	// Потоковая выдача отдаёт весь список и не ограничивается пагинацией
+	if acceptsNDJSON(r) {
+		h.streamPullRequests(w, r, statuses)
 		return
 	}
 
-	prs, err := h.prService.ListPullRequests(r.Context(), status)
+	page, err := parsePagination(r, h.pagination)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err, domain.CodeNotFound)
+		return
+	}
+
+	prs, err := h.prService.ListPullRequests(r.Context(), statuses, page)
 	if err != nil {
 		handleDomainError(w, h.logger, err)
 		return
 	}
 
 	response := map[string]interface{}{
-		"pull_requests": prs,
+		"pull_requests": pullRequestsView(r, prs),
 		"total":         len(prs),
+		"limit":         page.Limit,
+		"offset":        page.Offset,
 	}
 
 	writeJSON(w, http.StatusOK, response)
 }
+
+// streamPullRequests отдаёт список PR в формате NDJSON (по одному JSON-объекту на строку)
+func (h *PullRequestHandler) streamPullRequests(w http.ResponseWriter, r *http.Request, statuses []string) {
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	started := false
+
+	err := h.prService.StreamPullRequests(r.Context(), statuses, func(pr *domain.PullRequest) error {
+		if !started {
+			w.Header().Set("Content-Type", contentTypeNDJSON)
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		if err := encoder.Encode(pullRequestView(r, pr)); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+
+	if err != nil {
+		// После начала стрима статус уже отправлен - остаётся только залогировать
+		if started {
+			h.logger.Error("failed to stream pull requests", zap.Error(err))
+			return
+		}
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	if !started {
+		w.Header().Set("Content-Type", contentTypeNDJSON)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// parseStatuses разбирает фильтр статусов: один статус или несколько через запятую.
+// Пустая строка означает все статусы, повторы отбрасываются. Неизвестный или пустой
+// элемент списка - ErrInvalidInput
+func parseStatuses(raw string) ([]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	statuses := make([]string, 0, len(parts))
+	for _, part := range parts {
+		status := domain.PRStatus(strings.TrimSpace(part))
+		if !status.IsValid() {
+			return nil, fmt.Errorf("unknown status %q: %w", status, domain.ErrInvalidInput)
+		}
+		if !slices.Contains(statuses, string(status)) {
+			statuses = append(statuses, string(status))
+		}
+	}
+
+	return statuses, nil
+}
+
+// ExplainAssignment обрабатывает GET /pullRequest/explainAssignment
+func (h *PullRequestHandler) ExplainAssignment(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	explanation, err := h.prService.ExplainAssignment(r.Context(), prID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, explanation)
+}
+
+// GetEligibleReviewers обрабатывает GET /pullRequest/eligibleReviewers
+func (h *PullRequestHandler) GetEligibleReviewers(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	reviewers, err := h.prService.EligibleReviewers(r.Context(), prID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": prID,
+		"reviewers":       reviewers,
+	})
+}
+
+// GetSLABreaches обрабатывает GET /pullRequest/slaBreaches
+func (h *PullRequestHandler) GetSLABreaches(w http.ResponseWriter, r *http.Request) {
+	breaches, err := h.prService.SLABreaches(r.Context())
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"breaches": breaches})
+}
+
+// GetReviewerHistory обрабатывает GET /pullRequest/reviewerHistory
+func (h *PullRequestHandler) GetReviewerHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	history, err := h.prService.GetReviewerHistory(r.Context(), prID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": prID,
+		"history":         history,
+	})
+}
@@ -0,0 +1,616 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+	"reviewservice/internal/service"
+	"reviewservice/internal/testutil"
+)
+
+// newTestPullRequestHandler creates a PR handler backed by mock repositories
+func newTestPullRequestHandler(prRepo *testutil.MockPRRepository, userRepo *testutil.MockUserRepository) *PullRequestHandler {
+	logger := zap.NewNop()
+	prService := service.NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), nil, service.ReviewerPolicy{}, service.AssignmentConfig{}, logger)
+	return NewPullRequestHandler(prService, PaginationConfig{DefaultPageSize: 50, MaxPageSize: 500}, logger)
+}
+
+// TestPullRequestHandler_ListPullRequests_NDJSON tests streaming PR list as NDJSON
+func TestPullRequestHandler_ListPullRequests_NDJSON(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+
+	prRepo.PRs["pr-1"] = &domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusOpen}
+	prRepo.PRs["pr-2"] = &domain.PullRequest{PullRequestID: "pr-2", Status: domain.PRStatusMerged}
+	prRepo.PRs["pr-3"] = &domain.PullRequest{PullRequestID: "pr-3", Status: domain.PRStatusOpen}
+
+	h := newTestPullRequestHandler(prRepo, userRepo)
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/list", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+
+	h.ListPullRequests(rec, req)
+
+	testutil.AssertEqual(t, rec.Code, http.StatusOK, "Status code")
+	testutil.AssertEqual(t, rec.Header().Get("Content-Type"), "application/x-ndjson", "Content-Type")
+
+	lines := 0
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var pr domain.PullRequest
+		if err := json.Unmarshal(scanner.Bytes(), &pr); err != nil {
+			t.Fatalf("line %d is not a valid PR object: %v", lines+1, err)
+		}
+		lines++
+	}
+
+	testutil.AssertEqual(t, lines, 3, "One line per PR")
+}
+
+// TestPullRequestHandler_ListPullRequests_Statuses tests comma-separated status filters,
+// the single-status form and rejection of an unknown member
+func TestPullRequestHandler_ListPullRequests_Statuses(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+	prRepo.PRs["pr-1"] = &domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusOpen}
+	prRepo.PRs["pr-2"] = &domain.PullRequest{PullRequestID: "pr-2", Status: domain.PRStatusMerged}
+	prRepo.PRs["pr-3"] = &domain.PullRequest{PullRequestID: "pr-3", Status: domain.PRStatusOpen}
+	var gotStatuses []string
+	prRepo.ListFunc = func(ctx context.Context, statuses []string, page domain.Page) ([]*domain.PullRequest, error) {
+		gotStatuses = statuses
+		result := make([]*domain.PullRequest, 0)
+		for _, pr := range prRepo.PRs {
+			for _, status := range statuses {
+				if string(pr.Status) == status {
+					result = append(result, pr)
+				}
+			}
+		}
+		return result, nil
+	}
+
+	h := newTestPullRequestHandler(prRepo, userRepo)
+
+	tests := []struct {
+		name         string
+		query        string
+		wantCode     int
+		wantStatuses []string
+		wantCount    int
+	}{
+		{name: "single status", query: "OPEN", wantCode: http.StatusOK, wantStatuses: []string{"OPEN"}, wantCount: 2},
+		{name: "multiple statuses", query: "OPEN,MERGED", wantCode: http.StatusOK, wantStatuses: []string{"OPEN", "MERGED"}, wantCount: 3},
+		{name: "spaces and repeats", query: "MERGED,%20MERGED", wantCode: http.StatusOK, wantStatuses: []string{"MERGED"}, wantCount: 1},
+		{name: "unknown member", query: "OPEN,CLOSED", wantCode: http.StatusBadRequest},
+		{name: "empty member", query: "OPEN,", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStatuses = nil
+			rec := httptest.NewRecorder()
+			h.ListPullRequests(rec, httptest.NewRequest(http.MethodGet, "/pullRequest/list?status="+tt.query, nil))
+
+			testutil.AssertEqual(t, rec.Code, tt.wantCode, "Status code")
+			if tt.wantCode != http.StatusOK {
+				testutil.AssertNil(t, gotStatuses, "Repository not queried")
+				return
+			}
+
+			var resp struct {
+				PullRequests []domain.PullRequest `json:"pull_requests"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			testutil.AssertEqual(t, gotStatuses, tt.wantStatuses, "Statuses passed to the repository")
+			testutil.AssertLen(t, resp.PullRequests, tt.wantCount)
+		})
+	}
+}
+
+// TestPullRequestHandler_ReassignReviewer_Diff tests that reassign response includes the reviewer diff
+func TestPullRequestHandler_ReassignReviewer_Diff(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+
+	prRepo.PRs["pr-1"] = &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+	userRepo.Users["u1"] = &domain.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	userRepo.Users["u2"] = &domain.User{UserID: "u2", TeamName: "backend", IsActive: true}
+	userRepo.Users["u3"] = &domain.User{UserID: "u3", TeamName: "backend", IsActive: true}
+	userRepo.Users["u4"] = &domain.User{UserID: "u4", TeamName: "backend", IsActive: true}
+
+	h := newTestPullRequestHandler(prRepo, userRepo)
+
+	body := strings.NewReader(`{"pull_request_id":"pr-1","old_user_id":"u2"}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", body)
+	rec := httptest.NewRecorder()
+
+	h.ReassignReviewer(rec, req)
+
+	testutil.AssertEqual(t, rec.Code, http.StatusOK, "Status code")
+
+	var resp struct {
+		ReplacedBy      string   `json:"replaced_by"`
+		BeforeReviewers []string `json:"before_reviewers"`
+		AfterReviewers  []string `json:"after_reviewers"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	testutil.AssertEqual(t, resp.ReplacedBy, "u4", "Replaced by")
+	testutil.AssertEqual(t, resp.BeforeReviewers, []string{"u2", "u3"}, "Before reviewers")
+	testutil.AssertEqual(t, resp.AfterReviewers, []string{"u4", "u3"}, "After reviewers")
+}
+
+// TestPullRequestHandler_ReassignReviewer_Target tests that new_user_id in the body
+// is used as the replacement and that an ineligible target is rejected with 409
+func TestPullRequestHandler_ReassignReviewer_Target(t *testing.T) {
+	tests := []struct {
+		name       string
+		newUserID  string
+		wantStatus int
+		wantCode   domain.ErrorCode
+	}{
+		{name: "chosen target", newUserID: "u5", wantStatus: http.StatusOK},
+		{name: "author as target", newUserID: "u1", wantStatus: http.StatusConflict, wantCode: domain.CodeAuthorReviewer},
+		{name: "current reviewer as target", newUserID: "u3", wantStatus: http.StatusConflict, wantCode: domain.CodeAlreadyAssigned},
+		{name: "inactive target", newUserID: "idle", wantStatus: http.StatusConflict, wantCode: domain.CodeTargetIneligible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := testutil.NewMockPRRepository()
+			userRepo := testutil.NewMockUserRepository()
+			prRepo.PRs["pr-1"] = &domain.PullRequest{
+				PullRequestID:     "pr-1",
+				AuthorID:          "u1",
+				Status:            domain.PRStatusOpen,
+				AssignedReviewers: []string{"u2", "u3"},
+			}
+			for _, id := range []string{"u1", "u2", "u3", "u4", "u5"} {
+				userRepo.Users[id] = &domain.User{UserID: id, TeamName: "backend", IsActive: true}
+			}
+			userRepo.Users["idle"] = &domain.User{UserID: "idle", TeamName: "backend", IsActive: false}
+			h := newTestPullRequestHandler(prRepo, userRepo)
+
+			body := strings.NewReader(fmt.Sprintf(`{"pull_request_id":"pr-1","old_user_id":"u2","new_user_id":%q}`, tt.newUserID))
+			req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", body)
+			rec := httptest.NewRecorder()
+
+			h.ReassignReviewer(rec, req)
+
+			testutil.AssertEqual(t, rec.Code, tt.wantStatus, "Status code")
+			if tt.wantCode != "" {
+				var resp ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				testutil.AssertEqual(t, resp.Error.Code, tt.wantCode, "Error code")
+				testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"u2", "u3"}, "Reviewers unchanged")
+				return
+			}
+
+			var resp struct {
+				ReplacedBy string `json:"replaced_by"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			testutil.AssertEqual(t, resp.ReplacedBy, tt.newUserID, "Replaced by the chosen target")
+			testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"u5", "u3"}, "Stored reviewers")
+		})
+	}
+}
+
+// TestPullRequestHandler_ListPullRequests_Pagination tests that list responses honor limit/offset
+func TestPullRequestHandler_ListPullRequests_Pagination(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+	for _, id := range []string{"pr-1", "pr-2", "pr-3"} {
+		prRepo.PRs[id] = &domain.PullRequest{PullRequestID: id, Status: domain.PRStatusOpen}
+	}
+
+	h := newTestPullRequestHandler(prRepo, userRepo)
+
+	t.Run("limit restricts page size", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ListPullRequests(rec, httptest.NewRequest(http.MethodGet, "/pullRequest/list?limit=2", nil))
+
+		testutil.AssertEqual(t, rec.Code, http.StatusOK, "Status code")
+
+		var resp struct {
+			PullRequests []domain.PullRequest `json:"pull_requests"`
+			Limit        int                  `json:"limit"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		testutil.AssertLen(t, resp.PullRequests, 2)
+		testutil.AssertEqual(t, resp.Limit, 2, "Limit")
+	})
+
+	t.Run("negative offset is rejected", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ListPullRequests(rec, httptest.NewRequest(http.MethodGet, "/pullRequest/list?offset=-1", nil))
+
+		testutil.AssertEqual(t, rec.Code, http.StatusBadRequest, "Status code")
+	})
+}
+
+// TestPullRequestHandler_CreatePullRequest_InactiveAuthor tests that a well-formed request
+// with an inactive author is rejected with 422 rather than 400
+func TestPullRequestHandler_CreatePullRequest_InactiveAuthor(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["u1"] = &domain.User{UserID: "u1", TeamName: "backend", IsActive: false}
+	userRepo.Users["u2"] = &domain.User{UserID: "u2", TeamName: "backend", IsActive: true}
+
+	h := newTestPullRequestHandler(prRepo, userRepo)
+
+	body := strings.NewReader(`{"pull_request_id":"pr-1","pull_request_name":"Feature","author_id":"u1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", body)
+	rec := httptest.NewRecorder()
+
+	h.CreatePullRequest(rec, req)
+
+	testutil.AssertEqual(t, rec.Code, http.StatusUnprocessableEntity, "Status code")
+
+	var resp ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	testutil.AssertEqual(t, resp.Error.Code, domain.CodeUnprocessable, "Error code")
+	testutil.AssertTrue(t, prRepo.PRs["pr-1"] == nil, "PR is not created")
+
+	// Malformed JSON is still a syntax error
+	req = httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(`{"pull_request_id":`))
+	rec = httptest.NewRecorder()
+
+	h.CreatePullRequest(rec, req)
+
+	testutil.AssertEqual(t, rec.Code, http.StatusBadRequest, "Malformed request status code")
+}
+
+// TestPullRequestHandler_GetPullRequest tests fetching a single PR with reviewers and
+// timestamps, 404 for a missing PR and 400 without the query parameter
+func TestPullRequestHandler_GetPullRequest(t *testing.T) {
+	createdAt := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	mergedAt := createdAt.Add(3 * time.Hour)
+	prRepo := testutil.NewMockPRRepository()
+	prRepo.PRs["pr-1"] = &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		PullRequestName:   "Feature",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusMerged,
+		AssignedReviewers: []string{"u2", "u3"},
+		CreatedAt:         &createdAt,
+		MergedAt:          &mergedAt,
+	}
+	h := newTestPullRequestHandler(prRepo, testutil.NewMockUserRepository())
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+	}{
+		{name: "existing PR", query: "?pull_request_id=pr-1", wantStatus: http.StatusOK},
+		{name: "missing PR", query: "?pull_request_id=missing", wantStatus: http.StatusNotFound},
+		{name: "no query parameter", query: "", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/pullRequest/get"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			h.GetPullRequest(rec, req)
+
+			testutil.AssertEqual(t, rec.Code, tt.wantStatus, "Status code")
+			if tt.wantStatus != http.StatusOK {
+				var resp ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				testutil.AssertEqual(t, resp.Error.Code, domain.CodeNotFound, "Error code")
+				return
+			}
+
+			var resp struct {
+				PR domain.PullRequest `json:"pr"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			testutil.AssertEqual(t, resp.PR.AssignedReviewers, []string{"u2", "u3"}, "Reviewers")
+			testutil.AssertTrue(t, resp.PR.CreatedAt != nil && resp.PR.CreatedAt.Equal(createdAt), "createdAt")
+			testutil.AssertTrue(t, resp.PR.MergedAt != nil && resp.PR.MergedAt.Equal(mergedAt), "mergedAt")
+		})
+	}
+}
+
+// TestPullRequestHandler_DeletePullRequest tests deleting an existing PR and
+// the 404 error envelope for a missing one
+func TestPullRequestHandler_DeletePullRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		prID       string
+		wantStatus int
+		wantCode   domain.ErrorCode
+	}{
+		{name: "deletes existing PR", prID: "pr-1", wantStatus: http.StatusOK},
+		{name: "missing PR", prID: "missing", wantStatus: http.StatusNotFound, wantCode: domain.CodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := testutil.NewMockPRRepository()
+			prRepo.PRs["pr-1"] = &domain.PullRequest{
+				PullRequestID:     "pr-1",
+				Status:            domain.PRStatusOpen,
+				AssignedReviewers: []string{"u2"},
+			}
+			h := newTestPullRequestHandler(prRepo, testutil.NewMockUserRepository())
+
+			body := strings.NewReader(fmt.Sprintf(`{"pull_request_id":%q}`, tt.prID))
+			req := httptest.NewRequest(http.MethodDelete, "/pullRequest/delete", body)
+			rec := httptest.NewRecorder()
+
+			h.DeletePullRequest(rec, req)
+
+			testutil.AssertEqual(t, rec.Code, tt.wantStatus, "Status code")
+			if tt.wantCode != "" {
+				var resp ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				testutil.AssertEqual(t, resp.Error.Code, tt.wantCode, "Error code")
+				testutil.AssertTrue(t, prRepo.PRs["pr-1"] != nil, "Other PRs are kept")
+				return
+			}
+			testutil.AssertTrue(t, prRepo.PRs["pr-1"] == nil, "PR deleted")
+		})
+	}
+}
+
+// TestPullRequestHandler_RemoveReviewer tests status codes of manual reviewer removal
+func TestPullRequestHandler_RemoveReviewer(t *testing.T) {
+	tests := []struct {
+		name       string
+		prStatus   domain.PRStatus
+		userID     string
+		wantStatus int
+		wantCode   domain.ErrorCode
+	}{
+		{name: "removes reviewer", prStatus: domain.PRStatusOpen, userID: "u3", wantStatus: http.StatusOK},
+		{name: "merged PR", prStatus: domain.PRStatusMerged, userID: "u3", wantStatus: http.StatusConflict, wantCode: domain.CodePRMerged},
+		{name: "unassigned user", prStatus: domain.PRStatusOpen, userID: "u4", wantStatus: http.StatusConflict, wantCode: domain.CodeNotAssigned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := testutil.NewMockPRRepository()
+			prRepo.PRs["pr-1"] = &domain.PullRequest{
+				PullRequestID:     "pr-1",
+				AuthorID:          "u1",
+				Status:            tt.prStatus,
+				AssignedReviewers: []string{"u2", "u3"},
+				PrimaryReviewer:   "u2",
+			}
+			h := newTestPullRequestHandler(prRepo, testutil.NewMockUserRepository())
+
+			body := strings.NewReader(fmt.Sprintf(`{"pull_request_id":"pr-1","user_id":%q}`, tt.userID))
+			req := httptest.NewRequest(http.MethodPost, "/pullRequest/removeReviewer", body)
+			rec := httptest.NewRecorder()
+
+			h.RemoveReviewer(rec, req)
+
+			testutil.AssertEqual(t, rec.Code, tt.wantStatus, "Status code")
+			if tt.wantCode != "" {
+				var resp ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				testutil.AssertEqual(t, resp.Error.Code, tt.wantCode, "Error code")
+				testutil.AssertLen(t, prRepo.PRs["pr-1"].AssignedReviewers, 2, "Reviewers unchanged")
+				return
+			}
+			testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"u2"}, "Reviewer removed")
+		})
+	}
+}
+
+// TestPullRequestHandler_AddReviewer tests that an ineligible chosen reviewer is rejected with
+// TARGET_INELIGIBLE rather than the auto-selection NO_CANDIDATE code
+func TestPullRequestHandler_AddReviewer(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		wantStatus int
+		wantCode   domain.ErrorCode
+	}{
+		{name: "adds reviewer", userID: "u3", wantStatus: http.StatusOK},
+		{name: "author", userID: "u1", wantStatus: http.StatusConflict, wantCode: domain.CodeAuthorReviewer},
+		{name: "inactive user", userID: "idle", wantStatus: http.StatusConflict, wantCode: domain.CodeTargetIneligible},
+		{name: "conflicted user", userID: "rival", wantStatus: http.StatusConflict, wantCode: domain.CodeTargetIneligible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := testutil.NewMockPRRepository()
+			userRepo := testutil.NewMockUserRepository()
+			prRepo.PRs["pr-1"] = &domain.PullRequest{
+				PullRequestID:     "pr-1",
+				AuthorID:          "u1",
+				Status:            domain.PRStatusOpen,
+				AssignedReviewers: []string{"u2"},
+			}
+			userRepo.AddTeamMembers("backend", "u1", "u2", "u3", "rival")
+			userRepo.Users["idle"] = &domain.User{UserID: "idle", TeamName: "backend", IsActive: false}
+			userRepo.Conflicts = []domain.ReviewConflict{{UserA: "u1", UserB: "rival"}}
+			h := newTestPullRequestHandler(prRepo, userRepo)
+
+			body := strings.NewReader(fmt.Sprintf(`{"pull_request_id":"pr-1","user_id":%q}`, tt.userID))
+			req := httptest.NewRequest(http.MethodPost, "/pullRequest/addReviewer", body)
+			rec := httptest.NewRecorder()
+
+			h.AddReviewer(rec, req)
+
+			testutil.AssertEqual(t, rec.Code, tt.wantStatus, "Status code")
+			if tt.wantCode != "" {
+				var resp ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				testutil.AssertEqual(t, resp.Error.Code, tt.wantCode, "Error code")
+				testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"u2"}, "Reviewers unchanged")
+				return
+			}
+			testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"u2", "u3"}, "Reviewer added")
+		})
+	}
+}
+
+// TestPullRequestHandler_CreatePullRequest_DryRun tests that dry_run returns the proposed
+// reviewers with a dry_run flag and persists nothing
+func TestPullRequestHandler_CreatePullRequest_DryRun(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	prRepo.CreateFunc = func(ctx context.Context, pr *domain.PullRequest) error {
+		t.Errorf("Create must not be called in dry run")
+		return nil
+	}
+	prRepo.AssignReviewersFunc = func(ctx context.Context, prID string, reviewerIDs []string) error {
+		t.Errorf("AssignReviewers must not be called in dry run")
+		return nil
+	}
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["u1"] = &domain.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	userRepo.Users["u2"] = &domain.User{UserID: "u2", TeamName: "backend", IsActive: true}
+	userRepo.Users["u3"] = &domain.User{UserID: "u3", TeamName: "backend", IsActive: true}
+
+	h := newTestPullRequestHandler(prRepo, userRepo)
+
+	body := strings.NewReader(`{"pull_request_id":"pr-1","pull_request_name":"Feature","author_id":"u1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create?dry_run=true", body)
+	rec := httptest.NewRecorder()
+
+	h.CreatePullRequest(rec, req)
+
+	testutil.AssertEqual(t, rec.Code, http.StatusOK, "Status code")
+
+	var resp struct {
+		PR     domain.PullRequest `json:"pr"`
+		DryRun bool               `json:"dry_run"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	testutil.AssertTrue(t, resp.DryRun, "dry_run flag")
+	testutil.AssertEqual(t, resp.PR.AssignedReviewers, []string{"u2", "u3"}, "Proposed reviewers")
+	testutil.AssertEqual(t, resp.PR.PrimaryReviewer, "u2", "Proposed primary reviewer")
+	testutil.AssertLen(t, prRepo.PRs, 0, "Nothing persisted")
+
+	// An unparsable flag is rejected
+	req = httptest.NewRequest(http.MethodPost, "/pullRequest/create?dry_run=maybe",
+		strings.NewReader(`{"pull_request_id":"pr-1","pull_request_name":"Feature","author_id":"u1"}`))
+	rec = httptest.NewRecorder()
+
+	h.CreatePullRequest(rec, req)
+
+	testutil.AssertEqual(t, rec.Code, http.StatusBadRequest, "Invalid dry_run status code")
+}
+
+// TestPullRequestHandler_CreatePullRequest_Location tests that a created PR is answered with a Location header
+func TestPullRequestHandler_CreatePullRequest_Location(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["u1"] = &domain.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	userRepo.Users["u2"] = &domain.User{UserID: "u2", TeamName: "backend", IsActive: true}
+
+	h := newTestPullRequestHandler(prRepo, userRepo)
+
+	body := strings.NewReader(`{"pull_request_id":"pr 1","pull_request_name":"Feature","author_id":"u1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", body)
+	rec := httptest.NewRecorder()
+
+	h.CreatePullRequest(rec, req)
+
+	testutil.AssertEqual(t, rec.Code, http.StatusCreated, "Status code")
+	testutil.AssertEqual(t, rec.Header().Get("Location"), "/pullRequest/get?pull_request_id=pr+1", "Location header")
+
+	// A dry run creates nothing, so there is no Location
+	req = httptest.NewRequest(http.MethodPost, "/pullRequest/create?dry_run=true",
+		strings.NewReader(`{"pull_request_id":"pr-2","pull_request_name":"Feature","author_id":"u1"}`))
+	rec = httptest.NewRecorder()
+
+	h.CreatePullRequest(rec, req)
+
+	testutil.AssertEqual(t, rec.Code, http.StatusOK, "Dry run status code")
+	testutil.AssertEqual(t, rec.Header().Get("Location"), "", "No Location on dry run")
+}
+
+// TestPullRequestHandler_ConnectionError tests that database connectivity errors map to 503
+// with a generic message, while domain errors keep their status codes
+func TestPullRequestHandler_ConnectionError(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: connection refused (postgres://app:s3cret@db:5432/reviews)")}
+
+	tests := []struct {
+		name       string
+		mergeErr   error
+		wantStatus int
+		wantCode   domain.ErrorCode
+	}{
+		{"network error", fmt.Errorf("failed to merge: %w", refused), http.StatusServiceUnavailable, domain.CodeUnavailable},
+		{"bad connection", fmt.Errorf("failed to merge: %w", driver.ErrBadConn), http.StatusServiceUnavailable, domain.CodeUnavailable},
+		{"domain error", domain.ErrNotFound, http.StatusNotFound, domain.CodeNotFound},
+		{"no reviewers", domain.ErrNoReviewers, http.StatusUnprocessableEntity, domain.CodeUnprocessable},
+		{"author PR limit", fmt.Errorf("author u1 has 3 open pull requests (limit 3): %w", domain.ErrAuthorPRLimit), http.StatusUnprocessableEntity, domain.CodeUnprocessable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := testutil.NewMockPRRepository()
+			prRepo.MergeFunc = func(ctx context.Context, prID string) (*domain.PullRequest, error) {
+				return nil, tt.mergeErr
+			}
+			h := newTestPullRequestHandler(prRepo, testutil.NewMockUserRepository())
+
+			req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", strings.NewReader(`{"pull_request_id":"pr-1"}`))
+			rec := httptest.NewRecorder()
+
+			h.MergePullRequest(rec, req)
+
+			testutil.AssertEqual(t, rec.Code, tt.wantStatus, "Status code")
+			body := rec.Body.String()
+			testutil.AssertFalse(t, strings.Contains(body, "s3cret") || strings.Contains(body, "postgres://"), "Body leaks connection details: %s", body)
+
+			var resp ErrorResponse
+			if err := json.Unmarshal([]byte(body), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			testutil.AssertEqual(t, resp.Error.Code, tt.wantCode, "Error code")
+			if tt.wantStatus == http.StatusServiceUnavailable {
+				testutil.AssertEqual(t, resp.Error.Message, domain.ErrUnavailable.Error(), "Generic message")
+			}
+		})
+	}
+}
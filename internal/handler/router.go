@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -16,12 +17,15 @@ func serveOpenAPISpec(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, "openapi.yml")
 }
 
-// Router создаёт и настраивает HTTP роутер
+// Router создаёт и настраивает HTTP роутер. logSampleRate - доля логируемых
+// успешных запросов (см. loggerMiddleware)
 func Router(
 	teamHandler *TeamHandler,
 	userHandler *UserHandler,
 	prHandler *PullRequestHandler,
 	statsHandler *StatsHandler,
+	requestTimeout time.Duration,
+	logSampleRate float64,
 	logger *zap.Logger,
 ) http.Handler {
 	r := chi.NewRouter()
@@ -29,9 +33,10 @@ func Router(
 	// Middleware
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(loggerMiddleware(logger))
+	r.Use(loggerMiddleware(logger, logSampleRate))
+	r.Use(compressMiddleware(compressMinSize))
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(timeoutMiddleware(requestTimeout, logger))
 
 	// Health check
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -50,26 +55,65 @@ func Router(
 	// Team endpoints
 	r.Post("/team/add", teamHandler.CreateTeam)
 	r.Get("/team/get", teamHandler.GetTeam)
+	r.Get("/team/health", teamHandler.GetTeamHealth)
 	r.Post("/team/deactivate", teamHandler.BulkDeactivateTeam)
+	r.Post("/team/activate", teamHandler.BulkActivateTeam)
+	r.Post("/team/handoff", teamHandler.HandoffTeam)
+	r.Patch("/team", teamHandler.PatchTeam)
 
 	// User endpoints
 	r.Post("/users/setIsActive", userHandler.SetIsActive)
 	r.Get("/users/getReview", userHandler.GetReview)
+	r.Get("/users/primaryReviews", userHandler.GetPrimaryReviews)
+	r.Get("/users/workload", userHandler.GetWorkload)
 
 	// Pull Request endpoints
 	r.Post("/pullRequest/create", prHandler.CreatePullRequest)
+	r.Get("/pullRequest/get", prHandler.GetPullRequest)
 	r.Post("/pullRequest/merge", prHandler.MergePullRequest)
+	r.Delete("/pullRequest/delete", prHandler.DeletePullRequest)
 	r.Post("/pullRequest/reassign", prHandler.ReassignReviewer)
+	r.Post("/pullRequest/addReviewer", prHandler.AddReviewer)
+	r.Post("/pullRequest/removeReviewer", prHandler.RemoveReviewer)
+	r.Post("/pullRequest/setPrimary", prHandler.SetPrimaryReviewer)
+	r.Post("/pullRequest/resetReviewers", prHandler.ResetReviewers)
+	r.Post("/pullRequest/undoAssignment", prHandler.UndoAssignment)
 	r.Get("/pullRequest/list", prHandler.ListPullRequests)
+	r.Get("/pullRequest/explainAssignment", prHandler.ExplainAssignment)
+	r.Get("/pullRequest/reviewerHistory", prHandler.GetReviewerHistory)
+	r.Get("/pullRequest/eligibleReviewers", prHandler.GetEligibleReviewers)
+	r.Get("/pullRequest/slaBreaches", prHandler.GetSLABreaches)
 
 	// Stats endpoints
 	r.Get("/stats", statsHandler.GetStats)
+	r.Get("/stats/trends", statsHandler.GetTrends)
+	r.Get("/stats/authors", statsHandler.GetAuthorStats)
+	r.Get("/stats/capacity", statsHandler.GetTeamCapacity)
+	r.Get("/stats/byTeam", statsHandler.GetTeamLoadStats)
+
+	// Admin endpoints
+	r.Post("/admin/forceAssignReviewer", prHandler.ForceAssignReviewer)
+	r.Get("/admin/integrity", prHandler.CheckIntegrity)
+	r.Get("/admin/selfCheck", prHandler.SelfCheck)
+	r.Get("/admin/selectionDecisions", prHandler.GetSelectionDecisions)
+	r.Post("/admin/repairReviewers", prHandler.RepairReviewers)
+	r.Post("/admin/reconcileReviewers", prHandler.ReconcileReviewerCounts)
+	r.Post("/admin/rebalanceTeam", prHandler.RebalanceTeam)
+	r.Post("/admin/setFreeze", prHandler.SetFreeze)
+	r.Post("/admin/import", teamHandler.ImportTeams)
+	r.Get("/admin/reviewConflicts", userHandler.ListReviewConflicts)
+	r.Post("/admin/reviewConflicts/add", userHandler.AddReviewConflict)
+	r.Post("/admin/reviewConflicts/remove", userHandler.RemoveReviewConflict)
 
 	return r
 }
 
-// loggerMiddleware добавляет структурированное логирование HTTP запросов
-func loggerMiddleware(logger *zap.Logger) func(next http.Handler) http.Handler {
+// loggerMiddleware добавляет структурированное логирование HTTP запросов. Ответы не 2xx
+// логируются всегда, а успешные - с долей sampleRate, чтобы при высокой нагрузке
+// не засорять хранилище логов
+func loggerMiddleware(logger *zap.Logger, sampleRate float64) func(next http.Handler) http.Handler {
+	sampleSuccess := newSuccessSampler(sampleRate)
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
@@ -77,12 +121,18 @@ func loggerMiddleware(logger *zap.Logger) func(next http.Handler) http.Handler {
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 
 			defer func() {
+				// Status 0 - обработчик ничего не записал, клиент получит 200
+				status := ww.Status()
+				if status < http.StatusMultipleChoices && !sampleSuccess() {
+					return
+				}
+
 				logger.Info("http request",
 					zap.String("method", r.Method),
 					zap.String("path", r.URL.Path),
 					zap.String("remote_addr", r.RemoteAddr),
 					zap.String("user_agent", r.UserAgent()),
-					zap.Int("status", ww.Status()),
+					zap.Int("status", status),
 					zap.Int("bytes", ww.BytesWritten()),
 					zap.Duration("duration", time.Since(start)),
 					zap.String("request_id", middleware.GetReqID(r.Context())),
@@ -93,3 +143,17 @@ func loggerMiddleware(logger *zap.Logger) func(next http.Handler) http.Handler {
 		})
 	}
 }
+
+// newSuccessSampler возвращает функцию, решающую, логировать ли очередной успешный запрос.
+// Счётчик распределяет логируемые запросы равномерно: из n запросов логируется floor(n*rate)
+func newSuccessSampler(rate float64) func() bool {
+	if rate >= 1 {
+		return func() bool { return true }
+	}
+
+	var count atomic.Uint64
+	return func() bool {
+		n := count.Add(1)
+		return uint64(float64(n)*rate) > uint64(float64(n-1)*rate)
+	}
+}
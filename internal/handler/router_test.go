@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"reviewservice/internal/testutil"
+)
+
+// TestLoggerMiddleware_Sampling tests that error responses are always logged
+// while successful ones are sampled at the configured rate
+func TestLoggerMiddleware_Sampling(t *testing.T) {
+	tests := []struct {
+		name          string
+		rate          float64
+		wantSuccesses int
+	}{
+		{name: "all successes logged by default", rate: 1, wantSuccesses: 20},
+		{name: "every tenth success logged", rate: 0.1, wantSuccesses: 2},
+		{name: "successes disabled", rate: 0, wantSuccesses: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			h := loggerMiddleware(zap.New(core), tt.rate)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/fail" {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			for i := 0; i < 20; i++ {
+				h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+				h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+			}
+
+			successes := logs.FilterField(zap.Int("status", http.StatusOK)).Len()
+			failures := logs.FilterField(zap.Int("status", http.StatusInternalServerError)).Len()
+			testutil.AssertEqual(t, successes, tt.wantSuccesses, "Logged successes")
+			testutil.AssertEqual(t, failures, 20, "Logged failures")
+		})
+	}
+}
@@ -2,8 +2,11 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"go.uber.org/zap"
+	"reviewservice/internal/domain"
 	"reviewservice/internal/service"
 )
 
@@ -21,9 +24,20 @@ func NewStatsHandler(statsService *service.StatsService, logger *zap.Logger) *St
 	}
 }
 
-// GetStats обрабатывает GET /stats
+// GetStats обрабатывает GET /stats[?partial=true]
 func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
-	stats, err := h.statsService.GetStats(r.Context())
+	// partial=true: вернуть доступные разделы, даже если часть статистики получить не удалось
+	partial := false
+	if value := r.URL.Query().Get("partial"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+			return
+		}
+		partial = parsed
+	}
+
+	stats, err := h.statsService.GetStats(r.Context(), partial)
 	if err != nil {
 		handleDomainError(w, h.logger, err)
 		return
@@ -31,3 +45,68 @@ func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, stats)
 }
+
+// GetAuthorStats обрабатывает GET /stats/authors[?team_name=...]
+func (h *StatsHandler) GetAuthorStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.statsService.GetAuthorStats(r.Context(), r.URL.Query().Get("team_name"))
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"authors": stats})
+}
+
+// GetTeamLoadStats обрабатывает GET /stats/byTeam
+func (h *StatsHandler) GetTeamLoadStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.statsService.GetTeamLoadStats(r.Context())
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"teams": stats})
+}
+
+// GetTeamCapacity обрабатывает GET /stats/capacity?team_name=...
+func (h *StatsHandler) GetTeamCapacity(w http.ResponseWriter, r *http.Request) {
+	capacity, err := h.statsService.GetTeamCapacity(r.Context(), r.URL.Query().Get("team_name"))
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, capacity)
+}
+
+// GetTrends обрабатывает GET /stats/trends?from=YYYY-MM-DD&to=YYYY-MM-DD&interval=day|week[&team_name=...]
+func (h *StatsHandler) GetTrends(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	from, errFrom := time.Parse(time.DateOnly, query.Get("from"))
+	to, errTo := time.Parse(time.DateOnly, query.Get("to"))
+	if errFrom != nil || errTo != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	interval := domain.TrendInterval(query.Get("interval"))
+	if interval == "" {
+		interval = domain.TrendIntervalDay
+	}
+
+	buckets, err := h.statsService.GetTrends(r.Context(), from, to, interval, query.Get("team_name"))
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"from":     from.Format(time.DateOnly),
+		"to":       to.Format(time.DateOnly),
+		"interval": interval,
+		"buckets":  buckets,
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
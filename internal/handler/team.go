@@ -12,6 +12,7 @@ import (
 type TeamHandler struct {
 	teamService  *service.TeamService
 	statsService *service.StatsService
+	prService    *service.PullRequestService
 	logger       *zap.Logger
 }
 
@@ -19,11 +20,13 @@ type TeamHandler struct {
 func NewTeamHandler(
 	teamService *service.TeamService,
 	statsService *service.StatsService,
+	prService *service.PullRequestService,
 	logger *zap.Logger,
 ) *TeamHandler {
 	return &TeamHandler{
 		teamService:  teamService,
 		statsService: statsService,
+		prService:    prService,
 		logger:       logger,
 	}
 }
@@ -55,6 +58,57 @@ func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, response)
 }
 
+// maxImportBodyBytes - максимальный размер тела запроса импорта команд
+const maxImportBodyBytes = 5 << 20
+
+// ImportTeams обрабатывает POST /admin/import
+func (h *TeamHandler) ImportTeams(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBodyBytes)
+
+	var req struct {
+		Teams []domain.Team `json:"teams"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	results, err := h.teamService.ImportTeams(r.Context(), req.Teams)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"teams": results})
+}
+
+// PatchTeam обрабатывает PATCH /team
+func (h *TeamHandler) PatchTeam(w http.ResponseWriter, r *http.Request) {
+	var req domain.TeamPatch
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	// Валидация
+	if req.TeamName == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	team, err := h.teamService.PatchTeam(r.Context(), &req)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"team": team,
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
 // GetTeam обрабатывает GET /team/get
 func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	teamName := r.URL.Query().Get("team_name")
@@ -72,6 +126,23 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, team)
 }
 
+// GetTeamHealth обрабатывает GET /team/health?team_name=...
+func (h *TeamHandler) GetTeamHealth(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	health, err := h.prService.TeamHealth(r.Context(), teamName)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, health)
+}
+
 // BulkDeactivateTeam обрабатывает POST /team/deactivate
 func (h *TeamHandler) BulkDeactivateTeam(w http.ResponseWriter, r *http.Request) {
 	var req struct {
@@ -96,3 +167,55 @@ func (h *TeamHandler) BulkDeactivateTeam(w http.ResponseWriter, r *http.Request)
 
 	writeJSON(w, http.StatusOK, result)
 }
+
+// BulkActivateTeam обрабатывает POST /team/activate
+func (h *TeamHandler) BulkActivateTeam(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName string `json:"team_name"`
+		TopUp    bool   `json:"top_up"`
+	}
+
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	if req.TeamName == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	result, err := h.prService.BulkActivateTeam(r.Context(), req.TeamName, req.TopUp)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// HandoffTeam обрабатывает POST /team/handoff
+func (h *TeamHandler) HandoffTeam(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FromTeam string `json:"from_team"`
+		ToTeam   string `json:"to_team"`
+	}
+
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	if req.FromTeam == "" || req.ToTeam == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	result, err := h.prService.HandoffTeam(r.Context(), req.FromTeam, req.ToTeam)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+)
+
+// timeoutMiddleware ограничивает время обработки запроса дедлайном контекста.
+// В отличие от middleware.Timeout из chi, по истечении дедлайна клиент получает
+// 504 в формате ErrorResponse с кодом TIMEOUT, а медленный маршрут логируется.
+// Обработчик не прерывается принудительно: он должен сам реагировать на отмену контекста
+// (запросы к БД её учитывают). Если обработчик уже начал ответ, ответ не подменяется
+func timeoutMiddleware(timeout time.Duration, logger *zap.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			r = r.WithContext(ctx)
+			next.ServeHTTP(ww, r)
+
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return
+			}
+
+			logger.Warn("request timed out",
+				zap.String("method", r.Method),
+				zap.String("route", routePattern(r)),
+				zap.Duration("timeout", timeout),
+				zap.String("request_id", middleware.GetReqID(ctx)))
+
+			if ww.Status() == 0 {
+				writeError(ww, logger, http.StatusGatewayTimeout, domain.ErrTimeout, domain.CodeTimeout)
+			}
+		})
+	}
+}
+
+// routePattern возвращает шаблон маршрута chi (или путь, если маршрут не сопоставлен)
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return r.URL.Path
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+	"reviewservice/internal/testutil"
+)
+
+// TestTimeoutMiddleware tests that requests exceeding the deadline get a JSON 504
+// with the TIMEOUT code, while fast responses pass through untouched
+func TestTimeoutMiddleware(t *testing.T) {
+	serve := func(h http.HandlerFunc) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+		rec := httptest.NewRecorder()
+		timeoutMiddleware(10*time.Millisecond, zap.NewNop())(h).ServeHTTP(rec, req)
+		return rec
+	}
+
+	assertTimeout := func(t *testing.T, rec *httptest.ResponseRecorder) {
+		t.Helper()
+		testutil.AssertEqual(t, rec.Code, http.StatusGatewayTimeout, "status")
+		testutil.AssertEqual(t, rec.Header().Get("Content-Type"), "application/json", "Content-Type")
+
+		var resp ErrorResponse
+		testutil.AssertNoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		testutil.AssertEqual(t, resp.Error.Code, domain.CodeTimeout, "error code")
+	}
+
+	t.Run("slow handler that writes nothing", func(t *testing.T) {
+		rec := serve(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		})
+
+		assertTimeout(t, rec)
+	})
+
+	t.Run("handler failing with deadline exceeded", func(t *testing.T) {
+		rec := serve(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+			handleDomainError(w, zap.NewNop(), fmt.Errorf("failed to get PR: %w", r.Context().Err()))
+		})
+
+		assertTimeout(t, rec)
+		testutil.AssertFalse(t, strings.Contains(rec.Body.String(), "failed to get PR"), "internal error text hidden")
+	})
+
+	t.Run("fast handler", func(t *testing.T) {
+		rec := serve(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+		})
+
+		testutil.AssertEqual(t, rec.Code, http.StatusOK, "status")
+	})
+}
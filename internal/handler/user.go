@@ -12,6 +12,7 @@ import (
 type UserHandler struct {
 	userService *service.UserService
 	prService   *service.PullRequestService
+	pagination  PaginationConfig
 	logger      *zap.Logger
 }
 
@@ -19,11 +20,13 @@ type UserHandler struct {
 func NewUserHandler(
 	userService *service.UserService,
 	prService *service.PullRequestService,
+	pagination PaginationConfig,
 	logger *zap.Logger,
 ) *UserHandler {
 	return &UserHandler{
 		userService: userService,
 		prService:   prService,
+		pagination:  pagination,
 		logger:      logger,
 	}
 }
@@ -31,8 +34,8 @@ func NewUserHandler(
 // SetIsActive обрабатывает POST /users/setIsActive
 func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		UserID   string `json:"user_id"`
-		IsActive bool   `json:"is_active"`
+		UserID   string       `json:"user_id"`
+		IsActive flexibleBool `json:"is_active"`
 	}
 
 	if err := decodeJSON(r, &req); err != nil {
@@ -46,7 +49,7 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.userService.SetIsActive(r.Context(), req.UserID, req.IsActive)
+	user, err := h.userService.SetIsActive(r.Context(), req.UserID, bool(req.IsActive))
 	if err != nil {
 		handleDomainError(w, h.logger, err)
 		return
@@ -67,7 +70,17 @@ func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	reviews, err := h.prService.GetUserReviews(r.Context(), userID)
+	// Без limit эндпоинт, как и до пагинации, отдаёт весь список - но не больше MaxPageSize
+	pagination := h.pagination
+	pagination.DefaultPageSize = pagination.MaxPageSize
+
+	page, err := parsePagination(r, pagination)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err, domain.CodeNotFound)
+		return
+	}
+
+	reviews, err := h.prService.GetUserReviews(r.Context(), userID, page)
 	if err != nil {
 		handleDomainError(w, h.logger, err)
 		return
@@ -75,3 +88,105 @@ func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, reviews)
 }
+
+// GetPrimaryReviews обрабатывает GET /users/primaryReviews
+func (h *UserHandler) GetPrimaryReviews(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	prs, err := h.prService.GetPrimaryReviews(r.Context(), userID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"user_id":       userID,
+		"pull_requests": prs,
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetWorkload обрабатывает GET /users/workload
+func (h *UserHandler) GetWorkload(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeError(w, h.logger, http.StatusBadRequest, domain.ErrInvalidInput, domain.CodeNotFound)
+		return
+	}
+
+	workload, err := h.userService.GetWorkload(r.Context(), userID)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, workload)
+}
+
+// reviewConflictRequest - тело запросов добавления и снятия конфликта интересов
+type reviewConflictRequest struct {
+	UserA string `json:"user_a"`
+	UserB string `json:"user_b"`
+}
+
+// decodeReviewConflict разбирает и проверяет тело запроса конфликта интересов
+func decodeReviewConflict(r *http.Request) (reviewConflictRequest, error) {
+	var req reviewConflictRequest
+	if err := decodeJSON(r, &req); err != nil {
+		return req, domain.ErrInvalidInput
+	}
+	if req.UserA == "" || req.UserB == "" {
+		return req, domain.ErrInvalidInput
+	}
+	return req, nil
+}
+
+// ListReviewConflicts обрабатывает GET /admin/reviewConflicts
+func (h *UserHandler) ListReviewConflicts(w http.ResponseWriter, r *http.Request) {
+	conflicts, err := h.userService.ListReviewConflicts(r.Context())
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"conflicts": conflicts})
+}
+
+// AddReviewConflict обрабатывает POST /admin/reviewConflicts/add
+func (h *UserHandler) AddReviewConflict(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeReviewConflict(r)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err, domain.CodeNotFound)
+		return
+	}
+
+	conflict, err := h.userService.AddReviewConflict(r.Context(), req.UserA, req.UserB)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"conflict": conflict})
+}
+
+// RemoveReviewConflict обрабатывает POST /admin/reviewConflicts/remove
+func (h *UserHandler) RemoveReviewConflict(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeReviewConflict(r)
+	if err != nil {
+		writeError(w, h.logger, http.StatusBadRequest, err, domain.CodeNotFound)
+		return
+	}
+
+	conflict, err := h.userService.RemoveReviewConflict(r.Context(), req.UserA, req.UserB)
+	if err != nil {
+		handleDomainError(w, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"conflict": conflict})
+}
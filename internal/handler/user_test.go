@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+	"reviewservice/internal/service"
+	"reviewservice/internal/testutil"
+)
+
+// TestUserHandler_SetIsActive_FlexibleBool tests that is_active accepts booleans,
+// 1/0 and "true"/"false" strings and rejects other values with 400
+func TestUserHandler_SetIsActive_FlexibleBool(t *testing.T) {
+	tests := []struct {
+		name       string
+		isActive   string
+		initial    bool
+		wantStatus int
+		wantActive bool
+	}{
+		{name: "boolean true", isActive: `true`, wantStatus: http.StatusOK, wantActive: true},
+		{name: "boolean false", isActive: `false`, initial: true, wantStatus: http.StatusOK, wantActive: false},
+		{name: "number 1", isActive: `1`, wantStatus: http.StatusOK, wantActive: true},
+		{name: "number 0", isActive: `0`, initial: true, wantStatus: http.StatusOK, wantActive: false},
+		{name: "string true", isActive: `"true"`, wantStatus: http.StatusOK, wantActive: true},
+		{name: "string false", isActive: `"false"`, initial: true, wantStatus: http.StatusOK, wantActive: false},
+		{name: "number 2 rejected", isActive: `2`, initial: true, wantStatus: http.StatusBadRequest, wantActive: true},
+		{name: "string yes rejected", isActive: `"yes"`, initial: true, wantStatus: http.StatusBadRequest, wantActive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			prRepo := testutil.NewMockPRRepository()
+			userRepo := testutil.NewMockUserRepository()
+			userRepo.Users["u1"] = &domain.User{UserID: "u1", TeamName: "backend", IsActive: tt.initial}
+			userService := service.NewUserService(userRepo, prRepo, service.AssignmentConfig{}, logger)
+			prService := service.NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), nil, service.ReviewerPolicy{}, service.AssignmentConfig{}, logger)
+			h := NewUserHandler(userService, prService, PaginationConfig{DefaultPageSize: 50, MaxPageSize: 500}, logger)
+
+			body := strings.NewReader(fmt.Sprintf(`{"user_id":"u1","is_active":%s}`, tt.isActive))
+			req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", body)
+			rec := httptest.NewRecorder()
+
+			h.SetIsActive(rec, req)
+
+			testutil.AssertEqual(t, rec.Code, tt.wantStatus, "Status code")
+			testutil.AssertEqual(t, userRepo.Users["u1"].IsActive, tt.wantActive, "Stored is_active")
+		})
+	}
+}
+
+// TestUserHandler_GetReview_Limit tests that without limit the whole list is returned up to
+// MaxPageSize instead of DefaultPageSize, and that an explicit limit still applies
+func TestUserHandler_GetReview_Limit(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantCount int
+	}{
+		{name: "no limit returns more than default page", query: "", wantCount: 20},
+		{name: "explicit limit applies", query: "&limit=3", wantCount: 3},
+		{name: "limit capped at max", query: "&limit=100", wantCount: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			logger := zap.NewNop()
+			prRepo := testutil.NewMockPRRepository()
+			userRepo := testutil.NewMockUserRepository()
+			userRepo.AddTeamMembers("backend", "author", "u1")
+			for i := 0; i < 30; i++ {
+				prRepo.AddOpenPR(fmt.Sprintf("pr-%02d", i), "author", "u1")
+			}
+			userService := service.NewUserService(userRepo, prRepo, service.AssignmentConfig{}, logger)
+			prService := service.NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), nil, service.ReviewerPolicy{}, service.AssignmentConfig{}, logger)
+			h := NewUserHandler(userService, prService, PaginationConfig{DefaultPageSize: 5, MaxPageSize: 20}, logger)
+
+			req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			// Act
+			h.GetReview(rec, req)
+
+			// Assert
+			var resp domain.UserPullRequests
+			testutil.AssertJSONResponse(t, rec.Result(), http.StatusOK, &resp)
+			testutil.AssertLen(t, resp.PullRequests, tt.wantCount, "Returned PRs")
+			testutil.AssertEqual(t, resp.Total, 30, "Total")
+		})
+	}
+}
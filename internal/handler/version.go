@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"reviewservice/internal/domain"
+)
+
+// apiVersion1 - первая версия API: набор полей PR до появления дополнительных полей ответа
+const apiVersion1 = "1"
+
+// apiVersion извлекает запрошенную версию API из параметра version заголовка Accept
+// (например, "application/json;version=1"). Без параметра используется последняя версия
+func apiVersion(r *http.Request) string {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if version, ok := params["version"]; ok {
+			return version
+		}
+	}
+	return ""
+}
+
+// pullRequestV1 - представление PR для клиентов версии 1: только исходный набор полей,
+// чтобы старые клиенты не получали незнакомые ключи
+type pullRequestV1 struct {
+	PullRequestID     string          `json:"pull_request_id"`
+	PullRequestName   string          `json:"pull_request_name"`
+	AuthorID          string          `json:"author_id"`
+	Status            domain.PRStatus `json:"status"`
+	AssignedReviewers []string        `json:"assigned_reviewers"`
+	CreatedAt         *time.Time      `json:"createdAt,omitempty"`
+	MergedAt          *time.Time      `json:"mergedAt,omitempty"`
+}
+
+// pullRequestView возвращает представление PR для версии API, запрошенной клиентом
+func pullRequestView(r *http.Request, pr *domain.PullRequest) interface{} {
+	if pr == nil || apiVersion(r) != apiVersion1 {
+		return pr
+	}
+
+	return pullRequestV1{
+		PullRequestID:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorID:          pr.AuthorID,
+		Status:            pr.Status,
+		AssignedReviewers: pr.AssignedReviewers,
+		CreatedAt:         pr.CreatedAt,
+		MergedAt:          pr.MergedAt,
+	}
+}
+
+// pullRequestsView применяет pullRequestView к списку PR
+func pullRequestsView(r *http.Request, prs []*domain.PullRequest) interface{} {
+	if apiVersion(r) != apiVersion1 {
+		return prs
+	}
+
+	views := make([]interface{}, len(prs))
+	for i, pr := range prs {
+		views[i] = pullRequestView(r, pr)
+	}
+	return views
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"reviewservice/internal/domain"
+	"reviewservice/internal/testutil"
+)
+
+// TestAPIVersion tests parsing of the version parameter from the Accept header
+func TestAPIVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "no header", accept: "", want: ""},
+		{name: "plain json", accept: "application/json", want: ""},
+		{name: "version 1", accept: "application/json;version=1", want: "1"},
+		{name: "version with spaces", accept: "application/json; version=1", want: "1"},
+		{name: "version in later entry", accept: "text/html, application/json;version=1", want: "1"},
+		{name: "malformed entry is skipped", accept: ";;;, application/json;version=1", want: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			testutil.AssertEqual(t, apiVersion(req), tt.want, "API version")
+		})
+	}
+}
+
+// TestPullRequestHandler_Versioning tests that a v1 request omits fields added after v1
+// while the default (latest) response includes them
+func TestPullRequestHandler_Versioning(t *testing.T) {
+	newFields := []string{"primary_reviewer", "warnings"}
+
+	tests := []struct {
+		name       string
+		accept     string
+		wantFields bool
+	}{
+		{name: "default is latest", accept: "", wantFields: true},
+		{name: "explicit v1", accept: "application/json;version=1", wantFields: false},
+		{name: "unknown version falls back to latest", accept: "application/json;version=99", wantFields: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := testutil.NewMockPRRepository()
+			userRepo := testutil.NewMockUserRepository()
+			userRepo.Users["u1"] = &domain.User{UserID: "u1", TeamName: "backend", IsActive: true}
+			userRepo.Users["u2"] = &domain.User{UserID: "u2", TeamName: "backend", IsActive: true}
+
+			h := newTestPullRequestHandler(prRepo, userRepo)
+
+			// Only one candidate for two slots, so the response carries a warning
+			body := strings.NewReader(`{"pull_request_id":"pr-1","pull_request_name":"Feature","author_id":"u1"}`)
+			req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", body)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			h.CreatePullRequest(rec, req)
+
+			testutil.AssertEqual(t, rec.Code, http.StatusCreated, "Status code")
+
+			var resp struct {
+				PR map[string]json.RawMessage `json:"pr"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			for _, field := range []string{"pull_request_id", "pull_request_name", "author_id", "status", "assigned_reviewers"} {
+				_, ok := resp.PR[field]
+				testutil.AssertTrue(t, ok, "v1 field "+field+" present")
+			}
+			for _, field := range newFields {
+				_, ok := resp.PR[field]
+				testutil.AssertEqual(t, ok, tt.wantFields, "field "+field+" presence")
+			}
+		})
+	}
+}
+
+// TestPullRequestHandler_ListPullRequests_Versioning tests that list items follow the requested version
+func TestPullRequestHandler_ListPullRequests_Versioning(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	prRepo.PRs["pr-1"] = &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2"},
+		PrimaryReviewer:   "u2",
+	}
+
+	h := newTestPullRequestHandler(prRepo, testutil.NewMockUserRepository())
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/list", nil)
+	req.Header.Set("Accept", "application/json;version=1")
+	rec := httptest.NewRecorder()
+
+	h.ListPullRequests(rec, req)
+
+	testutil.AssertEqual(t, rec.Code, http.StatusOK, "Status code")
+
+	var resp struct {
+		PullRequests []map[string]json.RawMessage `json:"pull_requests"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	testutil.AssertLen(t, resp.PullRequests, 1, "PR count")
+	_, ok := resp.PullRequests[0]["primary_reviewer"]
+	testutil.AssertTrue(t, !ok, "v1 list item omits primary_reviewer")
+}
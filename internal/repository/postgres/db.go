@@ -3,9 +3,11 @@ package postgres
 import (
 	"database/sql"
 	"fmt"
+	"strconv"
 	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 )
 
 // Config содержит параметры подключения к PostgreSQL
@@ -14,15 +16,28 @@ type Config struct {
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
+
+	// StatementTimeout серверный statement_timeout каждого соединения: PostgreSQL сам
+	// прерывает запросы дольше этого значения, даже если контекст приложения не отменён
+	// (0 - используется значение сервера)
+	StatementTimeout time.Duration
 }
 
 // NewDB создаёт новое подключение к PostgreSQL
 func NewDB(cfg Config) (*sql.DB, error) {
-	db, err := sql.Open("pgx", cfg.DSN)
+	connConfig, err := pgx.ParseConfig(cfg.DSN)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		return nil, fmt.Errorf("failed to parse database DSN: %w", err)
+	}
+
+	// statement_timeout передаётся параметром запуска, поэтому действует на каждое
+	// соединение пула, включая переоткрытые после ConnMaxLifetime
+	if cfg.StatementTimeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
 	}
 
+	db := stdlib.OpenDB(*connConfig)
+
 	// Настройка пула подключений
 	db.SetMaxOpenConns(cfg.MaxOpenConns)
 	db.SetMaxIdleConns(cfg.MaxIdleConns)
@@ -30,6 +45,7 @@ func NewDB(cfg Config) (*sql.DB, error) {
 
 	// Проверка подключения
 	if err := db.Ping(); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
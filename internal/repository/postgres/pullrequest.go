@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -34,7 +35,7 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *domain.PullReque
 		createdAt = *pr.CreatedAt
 	}
 
-	_, err := r.db.ExecContext(ctx, query, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, createdAt)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, createdAt)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -63,7 +64,7 @@ func (r *PullRequestRepository) Get(ctx context.Context, prID string) (*domain.P
 	var createdAt time.Time
 	var mergedAt sql.NullTime
 
-	err := r.db.QueryRowContext(ctx, query, prID).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, prID).Scan(
 		&pr.PullRequestID,
 		&pr.PullRequestName,
 		&pr.AuthorID,
@@ -85,11 +86,9 @@ func (r *PullRequestRepository) Get(ctx context.Context, prID string) (*domain.P
 	}
 
 	// Получаем ревьюверов
-	reviewers, err := r.GetReviewers(ctx, prID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get reviewers: %w", err)
+	if err := r.loadReviewers(ctx, &pr); err != nil {
+		return nil, err
 	}
-	pr.AssignedReviewers = reviewers
 
 	return &pr, nil
 }
@@ -102,7 +101,7 @@ func (r *PullRequestRepository) Update(ctx context.Context, pr *domain.PullReque
 		WHERE pull_request_id = $1
 	`
 
-	result, err := r.db.ExecContext(ctx, query, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.MergedAt)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.MergedAt)
 	if err != nil {
 		return fmt.Errorf("failed to update pull request: %w", err)
 	}
@@ -119,6 +118,28 @@ func (r *PullRequestRepository) Update(ctx context.Context, pr *domain.PullReque
 	return nil
 }
 
+// Delete удаляет PR. Строки pr_reviewers, история ревьюверов и аудит назначений
+// удаляются каскадно по внешним ключам
+func (r *PullRequestRepository) Delete(ctx context.Context, prID string) error {
+	query := `DELETE FROM pull_requests WHERE pull_request_id = $1`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, prID)
+	if err != nil {
+		return fmt.Errorf("failed to delete PR: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
 // Merge помечает PR как смердженный (идемпотентная операция)
 func (r *PullRequestRepository) Merge(ctx context.Context, prID string) (*domain.PullRequest, error) {
 	// Получаем текущее состояние PR
@@ -140,7 +161,7 @@ func (r *PullRequestRepository) Merge(ctx context.Context, prID string) (*domain
 		WHERE pull_request_id = $1
 	`
 
-	_, err = r.db.ExecContext(ctx, query, prID, domain.PRStatusMerged, mergedAt)
+	_, err = conn(ctx, r.db).ExecContext(ctx, query, prID, domain.PRStatusMerged, mergedAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to merge pull request: %w", err)
 	}
@@ -152,16 +173,19 @@ func (r *PullRequestRepository) Merge(ctx context.Context, prID string) (*domain
 }
 
 // GetByReviewer получает PR'ы, где пользователь назначен ревьювером
-func (r *PullRequestRepository) GetByReviewer(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+func (r *PullRequestRepository) GetByReviewer(ctx context.Context, userID string, page domain.Page) ([]domain.PullRequestShort, error) {
 	query := `
-		SELECT DISTINCT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at
+		SELECT DISTINCT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at
 		FROM pull_requests p
 		INNER JOIN pr_reviewers pr ON p.pull_request_id = pr.pull_request_id
 		WHERE pr.user_id = $1
-		ORDER BY p.created_at DESC
+		ORDER BY p.created_at DESC, p.pull_request_id
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	args := []interface{}{userID}
+	query, args = appendPage(query, args, page)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pull requests by reviewer: %w", err)
 	}
@@ -169,10 +193,42 @@ func (r *PullRequestRepository) GetByReviewer(ctx context.Context, userID string
 
 	prs := make([]domain.PullRequestShort, 0)
 	for rows.Next() {
-		var pr domain.PullRequestShort
-		var createdAt time.Time
-		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &createdAt); err != nil {
-			return nil, fmt.Errorf("failed to scan pull request: %w", err)
+		pr, err := scanPullRequestShort(rows)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pull requests: %w", err)
+	}
+
+	return prs, nil
+}
+
+// GetByPrimaryReviewer получает PR'ы, где пользователь отмечен основным ревьювером
+// (is_primary): сначала открытые, затем от новых к старым
+func (r *PullRequestRepository) GetByPrimaryReviewer(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+	query := `
+		SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at
+		FROM pull_requests p
+		INNER JOIN pr_reviewers pr ON p.pull_request_id = pr.pull_request_id
+		WHERE pr.user_id = $1 AND pr.is_primary
+		ORDER BY p.status = $2, p.created_at DESC, p.pull_request_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID, domain.PRStatusMerged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull requests by primary reviewer: %w", err)
+	}
+	defer rows.Close()
+
+	prs := make([]domain.PullRequestShort, 0)
+	for rows.Next() {
+		pr, err := scanPullRequestShort(rows)
+		if err != nil {
+			return nil, err
 		}
 		prs = append(prs, pr)
 	}
@@ -184,6 +240,47 @@ func (r *PullRequestRepository) GetByReviewer(ctx context.Context, userID string
 	return prs, nil
 }
 
+// scanPullRequestShort читает строку pull_request_id, pull_request_name, author_id, status,
+// created_at, merged_at в краткое представление PR
+func scanPullRequestShort(rows *sql.Rows) (domain.PullRequestShort, error) {
+	var pr domain.PullRequestShort
+	var createdAt time.Time
+	var mergedAt sql.NullTime
+	if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &createdAt, &mergedAt); err != nil {
+		return domain.PullRequestShort{}, fmt.Errorf("failed to scan pull request: %w", err)
+	}
+
+	pr.CreatedAt = &createdAt
+	if mergedAt.Valid {
+		pr.MergedAt = &mergedAt.Time
+	}
+	return pr, nil
+}
+
+// CountByReviewer возвращает число PR, где пользователь назначен ревьювером
+func (r *PullRequestRepository) CountByReviewer(ctx context.Context, userID string) (int, error) {
+	query := `SELECT COUNT(*) FROM pr_reviewers WHERE user_id = $1`
+
+	var total int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count pull requests by reviewer: %w", err)
+	}
+
+	return total, nil
+}
+
+// CountOpenByAuthor возвращает число открытых PR автора
+func (r *PullRequestRepository) CountOpenByAuthor(ctx context.Context, authorID string) (int, error) {
+	query := `SELECT COUNT(*) FROM pull_requests WHERE author_id = $1 AND status = $2`
+
+	var total int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, authorID, domain.PRStatusOpen).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count open pull requests by author: %w", err)
+	}
+
+	return total, nil
+}
+
 // GetOpenByReviewer получает открытые PR'ы пользователя
 func (r *PullRequestRepository) GetOpenByReviewer(ctx context.Context, userID string) ([]string, error) {
 	query := `
@@ -193,7 +290,7 @@ func (r *PullRequestRepository) GetOpenByReviewer(ctx context.Context, userID st
 		WHERE pr.user_id = $1 AND p.status = $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, domain.PRStatusOpen)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID, domain.PRStatusOpen)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get open pull requests: %w", err)
 	}
@@ -215,12 +312,60 @@ func (r *PullRequestRepository) GetOpenByReviewer(ctx context.Context, userID st
 	return prIDs, nil
 }
 
+// GetOpenByAuthorTeam получает открытые PR, авторы которых состоят в команде teamName,
+// от новых к старым
+func (r *PullRequestRepository) GetOpenByAuthorTeam(ctx context.Context, teamName string) ([]*domain.PullRequest, error) {
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at
+		FROM pull_requests pr
+		JOIN users u ON u.user_id = pr.author_id
+		WHERE u.team_name = $1 AND pr.status = $2
+		ORDER BY pr.created_at DESC, pr.pull_request_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, teamName, domain.PRStatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team open pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	prs := make([]*domain.PullRequest, 0)
+	for rows.Next() {
+		var pr domain.PullRequest
+		var createdAt time.Time
+		var mergedAt sql.NullTime
+
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &createdAt, &mergedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pull request: %w", err)
+		}
+
+		pr.CreatedAt = &createdAt
+		if mergedAt.Valid {
+			pr.MergedAt = &mergedAt.Time
+		}
+
+		prs = append(prs, &pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pull requests: %w", err)
+	}
+	rows.Close()
+
+	// Ревьюверы читаются после закрытия курсора: внутри транзакции соединение одно
+	if err := r.loadReviewersBatch(ctx, prs); err != nil {
+		return nil, err
+	}
+
+	return prs, nil
+}
+
 // Exists проверяет существование PR
 func (r *PullRequestRepository) Exists(ctx context.Context, prID string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1)`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, prID).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, prID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check PR existence: %w", err)
 	}
@@ -228,147 +373,443 @@ func (r *PullRequestRepository) Exists(ctx context.Context, prID string) (bool,
 	return exists, nil
 }
 
+// lockOpenPR блокирует строку PR до конца транзакции q и возвращает ErrPRMerged, если PR
+// смерджен. Блокировка не даёт параллельному merge проскочить между проверкой и изменением
+func lockOpenPR(ctx context.Context, q querier, prID string) error {
+	var status domain.PRStatus
+	query := `SELECT status FROM pull_requests WHERE pull_request_id = $1 FOR UPDATE`
+	if err := q.QueryRowContext(ctx, query, prID).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to lock PR: %w", err)
+	}
+	if status == domain.PRStatusMerged {
+		return domain.ErrPRMerged
+	}
+	return nil
+}
+
 // AssignReviewers назначает ревьюверов на PR
 func (r *PullRequestRepository) AssignReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
 	if len(reviewerIDs) == 0 {
 		return nil
 	}
 
-	// Используем транзакцию для атомарности
-	tx, err := r.db.BeginTx(ctx, nil)
+	// Используем транзакцию для атомарности (или транзакцию вызывающего из ctx)
+	return withinTransaction(ctx, r.db, func(ctx context.Context, tx *sql.Tx) error {
+		if err := lockOpenPR(ctx, tx, prID); err != nil {
+			return err
+		}
+
+		query := `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)`
+
+		for _, reviewerID := range reviewerIDs {
+			_, err := tx.ExecContext(ctx, query, prID, reviewerID)
+			if err != nil {
+				var pgErr *pgconn.PgError
+				if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+					// Ревьювер уже назначен, пропускаем
+					continue
+				}
+				return fmt.Errorf("failed to assign reviewer %s: %w", reviewerID, err)
+			}
+		}
+
+		// Первый назначенный ревьювер становится основным, если основного ещё нет
+		primaryQuery := `
+			UPDATE pr_reviewers SET is_primary = TRUE
+			WHERE pull_request_id = $1 AND user_id = $2
+			  AND NOT EXISTS (SELECT 1 FROM pr_reviewers WHERE pull_request_id = $1 AND is_primary)
+		`
+		if _, err := tx.ExecContext(ctx, primaryQuery, prID, reviewerIDs[0]); err != nil {
+			return fmt.Errorf("failed to set primary reviewer: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ReplaceReviewers снимает всех ревьюверов PR и назначает reviewerIDs в одной транзакции
+// (или в транзакции вызывающего из ctx); первый из reviewerIDs становится основным
+func (r *PullRequestRepository) ReplaceReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
+	return withinTransaction(ctx, r.db, func(ctx context.Context, tx *sql.Tx) error {
+		if err := lockOpenPR(ctx, tx, prID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM pr_reviewers WHERE pull_request_id = $1`, prID); err != nil {
+			return fmt.Errorf("failed to clear reviewers: %w", err)
+		}
+		return r.AssignReviewers(ctx, prID, reviewerIDs)
+	})
+}
+
+// RemoveReviewer удаляет ревьювера из PR
+func (r *PullRequestRepository) RemoveReviewer(ctx context.Context, prID string, reviewerID string) error {
+	return withinTransaction(ctx, r.db, func(ctx context.Context, tx *sql.Tx) error {
+		if err := lockOpenPR(ctx, tx, prID); err != nil {
+			return err
+		}
+		return removeReviewer(ctx, tx, prID, reviewerID)
+	})
+}
+
+// DeleteOrphanedReviewer удаляет назначение удалённого пользователя, в том числе из смердженного PR
+func (r *PullRequestRepository) DeleteOrphanedReviewer(ctx context.Context, prID, userID string) error {
+	return withinTransaction(ctx, r.db, func(ctx context.Context, tx *sql.Tx) error {
+		return removeReviewer(ctx, tx, prID, userID)
+	})
+}
+
+// removeReviewer удаляет ревьювера из PR в транзакции tx, передавая признак основного
+// самому раннему из оставшихся ревьюверов
+func removeReviewer(ctx context.Context, tx *sql.Tx, prID, reviewerID string) error {
+	query := `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2 RETURNING is_primary`
+
+	var wasPrimary bool
+	err := tx.QueryRowContext(ctx, query, prID, reviewerID).Scan(&wasPrimary)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrNotAssigned
+		}
+		return fmt.Errorf("failed to remove reviewer: %w", err)
+	}
+
+	// Основным становится самый ранний из оставшихся ревьюверов
+	if wasPrimary {
+		promoteQuery := `
+			UPDATE pr_reviewers SET is_primary = TRUE
+			WHERE (pull_request_id, user_id) = (
+				SELECT pull_request_id, user_id FROM pr_reviewers
+				WHERE pull_request_id = $1
+				ORDER BY assigned_at, user_id
+				LIMIT 1
+			)
+		`
+		if _, err := tx.ExecContext(ctx, promoteQuery, prID); err != nil {
+			return fmt.Errorf("failed to promote primary reviewer: %w", err)
+		}
 	}
-	defer tx.Rollback()
 
-	query := `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)`
+	return nil
+}
+
+// AddReviewer добавляет ревьювера в PR; уже назначенный ревьювер - ErrAlreadyAssigned
+func (r *PullRequestRepository) AddReviewer(ctx context.Context, prID string, reviewerID string) error {
+	// Ревьювер PR без основного ревьювера сам становится основным
+	query := `
+		INSERT INTO pr_reviewers (pull_request_id, user_id, is_primary)
+		VALUES ($1, $2, NOT EXISTS (SELECT 1 FROM pr_reviewers WHERE pull_request_id = $1 AND is_primary))
+	`
+
+	return withinTransaction(ctx, r.db, func(ctx context.Context, tx *sql.Tx) error {
+		if err := lockOpenPR(ctx, tx, prID); err != nil {
+			return err
+		}
 
-	for _, reviewerID := range reviewerIDs {
 		_, err := tx.ExecContext(ctx, query, prID, reviewerID)
 		if err != nil {
 			var pgErr *pgconn.PgError
 			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-				// Ревьювер уже назначен, пропускаем
-				continue
+				return domain.ErrAlreadyAssigned
 			}
-			return fmt.Errorf("failed to assign reviewer %s: %w", reviewerID, err)
+			return fmt.Errorf("failed to add reviewer: %w", err)
 		}
-	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return nil
+	})
+}
+
+// SetPrimaryReviewer делает назначенного ревьювера основным, снимая признак с прежнего
+func (r *PullRequestRepository) SetPrimaryReviewer(ctx context.Context, prID, reviewerID string) error {
+	return withinTransaction(ctx, r.db, func(ctx context.Context, tx *sql.Tx) error {
+		if err := lockOpenPR(ctx, tx, prID); err != nil {
+			return err
+		}
+
+		var assigned bool
+		checkQuery := `SELECT EXISTS(SELECT 1 FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2)`
+		if err := tx.QueryRowContext(ctx, checkQuery, prID, reviewerID).Scan(&assigned); err != nil {
+			return fmt.Errorf("failed to check reviewer: %w", err)
+		}
+		if !assigned {
+			return domain.ErrNotAssigned
+		}
+
+		// Снимаем признак до установки нового, чтобы не нарушить уникальный индекс
+		clearQuery := `UPDATE pr_reviewers SET is_primary = FALSE WHERE pull_request_id = $1 AND is_primary`
+		if _, err := tx.ExecContext(ctx, clearQuery, prID); err != nil {
+			return fmt.Errorf("failed to clear primary reviewer: %w", err)
+		}
+
+		setQuery := `UPDATE pr_reviewers SET is_primary = TRUE WHERE pull_request_id = $1 AND user_id = $2`
+		if _, err := tx.ExecContext(ctx, setQuery, prID, reviewerID); err != nil {
+			return fmt.Errorf("failed to set primary reviewer: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// RecordAssignmentAudit добавляет запись в журнал изменений назначений
+func (r *PullRequestRepository) RecordAssignmentAudit(ctx context.Context, entry *domain.AssignmentAuditEntry) error {
+	query := `
+		INSERT INTO assignment_audit (pull_request_id, user_id, action, forced)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, entry.PullRequestID, entry.UserID, entry.Action, entry.Forced).
+		Scan(&entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record assignment audit: %w", err)
 	}
 
 	return nil
 }
 
-// RemoveReviewer удаляет ревьювера из PR
-func (r *PullRequestRepository) RemoveReviewer(ctx context.Context, prID string, reviewerID string) error {
-	query := `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`
+// RecordReviewerHistory добавляет события в историю ревьюверов PR
+func (r *PullRequestRepository) RecordReviewerHistory(ctx context.Context, entries []domain.ReviewerHistoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO reviewer_history (pull_request_id, user_id, action, replaced_by, selection_trace)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5::jsonb)
+	`
+
+	return withinTransaction(ctx, r.db, func(ctx context.Context, tx *sql.Tx) error {
+		for _, entry := range entries {
+			trace := sql.NullString{String: string(entry.SelectionTrace), Valid: len(entry.SelectionTrace) > 0}
+			if _, err := tx.ExecContext(ctx, query, entry.PullRequestID, entry.UserID, entry.Action, entry.ReplacedBy, trace); err != nil {
+				return fmt.Errorf("failed to record reviewer history: %w", err)
+			}
+		}
+		return nil
+	})
+}
 
-	result, err := r.db.ExecContext(ctx, query, prID, reviewerID)
+// GetReviewerHistory возвращает историю ревьюверов PR в порядке событий
+func (r *PullRequestRepository) GetReviewerHistory(ctx context.Context, prID string) ([]domain.ReviewerHistoryEntry, error) {
+	query := `
+		SELECT user_id, action, COALESCE(replaced_by, ''), created_at, selection_trace
+		FROM reviewer_history
+		WHERE pull_request_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, prID)
 	if err != nil {
-		return fmt.Errorf("failed to remove reviewer: %w", err)
+		return nil, fmt.Errorf("failed to get reviewer history: %w", err)
 	}
+	defer rows.Close()
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+	history := make([]domain.ReviewerHistoryEntry, 0)
+	for rows.Next() {
+		entry := domain.ReviewerHistoryEntry{PullRequestID: prID}
+		var trace []byte
+		if err := rows.Scan(&entry.UserID, &entry.Action, &entry.ReplacedBy, &entry.CreatedAt, &trace); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer history: %w", err)
+		}
+		if len(trace) > 0 {
+			entry.SelectionTrace = trace
+		}
+		history = append(history, entry)
 	}
 
-	if rowsAffected == 0 {
-		return domain.ErrNotAssigned
+	return history, rows.Err()
+}
+
+// RecordSelectionDecision сохраняет решение автоотбора ревьюверов
+func (r *PullRequestRepository) RecordSelectionDecision(ctx context.Context, decision *domain.SelectionDecision) error {
+	query := `
+		INSERT INTO selection_decisions (pull_request_id, pool_size, reviewers, strategy)
+		VALUES ($1, $2, $3::text[], $4)
+		RETURNING created_at
+	`
+
+	reviewers := decision.Reviewers
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, decision.PullRequestID, decision.PoolSize, reviewers, decision.Strategy).
+		Scan(&decision.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record selection decision: %w", err)
 	}
 
 	return nil
 }
 
-// AddReviewer добавляет ревьювера в PR
-func (r *PullRequestRepository) AddReviewer(ctx context.Context, prID string, reviewerID string) error {
-	query := `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)`
+// GetSelectionDecisions возвращает решения автоотбора по PR в порядке записи
+func (r *PullRequestRepository) GetSelectionDecisions(ctx context.Context, prID string) ([]domain.SelectionDecision, error) {
+	query := `
+		SELECT pool_size, array_to_json(reviewers), strategy, created_at
+		FROM selection_decisions
+		WHERE pull_request_id = $1
+		ORDER BY created_at, id
+	`
 
-	_, err := r.db.ExecContext(ctx, query, prID, reviewerID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, prID)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			// Ревьювер уже назначен
-			return nil
+		return nil, fmt.Errorf("failed to get selection decisions: %w", err)
+	}
+	defer rows.Close()
+
+	decisions := make([]domain.SelectionDecision, 0)
+	for rows.Next() {
+		decision := domain.SelectionDecision{PullRequestID: prID}
+		var reviewers []byte
+		if err := rows.Scan(&decision.PoolSize, &reviewers, &decision.Strategy, &decision.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan selection decision: %w", err)
+		}
+		if err := json.Unmarshal(reviewers, &decision.Reviewers); err != nil {
+			return nil, fmt.Errorf("failed to decode selection decision reviewers: %w", err)
 		}
-		return fmt.Errorf("failed to add reviewer: %w", err)
+		decisions = append(decisions, decision)
 	}
 
-	return nil
+	return decisions, rows.Err()
 }
 
 // GetReviewers получает список ревьюверов PR
 func (r *PullRequestRepository) GetReviewers(ctx context.Context, prID string) ([]string, error) {
+	reviewers, _, err := r.getReviewers(ctx, prID)
+	return reviewers, err
+}
+
+// loadReviewers заполняет ревьюверов PR и его основного ревьювера
+func (r *PullRequestRepository) loadReviewers(ctx context.Context, pr *domain.PullRequest) error {
+	reviewers, primary, err := r.getReviewers(ctx, pr.PullRequestID)
+	if err != nil {
+		return fmt.Errorf("failed to get reviewers for PR %s: %w", pr.PullRequestID, err)
+	}
+
+	pr.AssignedReviewers = reviewers
+	pr.PrimaryReviewer = primary
+	return nil
+}
+
+// getReviewers возвращает ревьюверов PR в порядке назначения и основного ревьювера
+func (r *PullRequestRepository) getReviewers(ctx context.Context, prID string) ([]string, string, error) {
 	query := `
-		SELECT user_id
+		SELECT user_id, is_primary
 		FROM pr_reviewers
 		WHERE pull_request_id = $1
 		ORDER BY assigned_at
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, prID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, prID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviewers: %w", err)
+		return nil, "", fmt.Errorf("failed to get reviewers: %w", err)
 	}
 	defer rows.Close()
 
 	reviewers := make([]string, 0)
+	primary := ""
 	for rows.Next() {
 		var reviewerID string
-		if err := rows.Scan(&reviewerID); err != nil {
-			return nil, fmt.Errorf("failed to scan reviewer: %w", err)
+		var isPrimary bool
+		if err := rows.Scan(&reviewerID, &isPrimary); err != nil {
+			return nil, "", fmt.Errorf("failed to scan reviewer: %w", err)
 		}
 		reviewers = append(reviewers, reviewerID)
+		if isPrimary {
+			primary = reviewerID
+		}
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating reviewers: %w", err)
+		return nil, "", fmt.Errorf("error iterating reviewers: %w", err)
 	}
 
-	return reviewers, nil
+	return reviewers, primary, nil
 }
 
-// ReassignReviewer переназначает ревьювера (атомарная операция)
-func (r *PullRequestRepository) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+// loadReviewersBatch заполняет ревьюверов и основного ревьювера списка PR одним запросом.
+// Порядок ревьюверов внутри PR - по времени назначения, как в getReviewers
+func (r *PullRequestRepository) loadReviewersBatch(ctx context.Context, prs []*domain.PullRequest) error {
+	if len(prs) == 0 {
+		return nil
 	}
-	defer tx.Rollback()
 
-	// Удаляем старого ревьювера
-	deleteQuery := `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`
-	result, err := tx.ExecContext(ctx, deleteQuery, prID, oldReviewerID)
-	if err != nil {
-		return fmt.Errorf("failed to remove old reviewer: %w", err)
+	byID := make(map[string]*domain.PullRequest, len(prs))
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		pr.AssignedReviewers = make([]string, 0)
+		byID[pr.PullRequestID] = pr
+		ids = append(ids, pr.PullRequestID)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	query := `
+		SELECT pull_request_id, user_id, is_primary
+		FROM pr_reviewers
+		WHERE pull_request_id = ANY($1::text[])
+		ORDER BY pull_request_id, assigned_at
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, ids)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return fmt.Errorf("failed to get reviewers: %w", err)
 	}
+	defer rows.Close()
 
-	if rowsAffected == 0 {
-		return domain.ErrNotAssigned
-	}
+	for rows.Next() {
+		var prID, reviewerID string
+		var isPrimary bool
+		if err := rows.Scan(&prID, &reviewerID, &isPrimary); err != nil {
+			return fmt.Errorf("failed to scan reviewer: %w", err)
+		}
 
-	// Добавляем нового ревьювера
-	insertQuery := `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)`
-	_, err = tx.ExecContext(ctx, insertQuery, prID, newReviewerID)
-	if err != nil {
-		return fmt.Errorf("failed to add new reviewer: %w", err)
+		pr := byID[prID]
+		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+		if isPrimary {
+			pr.PrimaryReviewer = reviewerID
+		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating reviewers: %w", err)
 	}
 
 	return nil
 }
 
+// ReassignReviewer переназначает ревьювера (атомарная операция)
+func (r *PullRequestRepository) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
+	return withinTransaction(ctx, r.db, func(ctx context.Context, tx *sql.Tx) error {
+		if err := lockOpenPR(ctx, tx, prID); err != nil {
+			return err
+		}
+
+		// Удаляем старого ревьювера
+		deleteQuery := `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2 RETURNING is_primary`
+		var wasPrimary bool
+		if err := tx.QueryRowContext(ctx, deleteQuery, prID, oldReviewerID).Scan(&wasPrimary); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrNotAssigned
+			}
+			return fmt.Errorf("failed to remove old reviewer: %w", err)
+		}
+
+		// Добавляем нового ревьювера; признак основного переходит к нему.
+		// Под блокировкой PR нарушение уникальности означает, что замену уже назначил
+		// параллельный запрос, прочитавший состав ревьюверов до нас
+		insertQuery := `INSERT INTO pr_reviewers (pull_request_id, user_id, is_primary) VALUES ($1, $2, $3)`
+		if _, err := tx.ExecContext(ctx, insertQuery, prID, newReviewerID, wasPrimary); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return domain.ErrAlreadyAssigned
+			}
+			return fmt.Errorf("failed to add new reviewer: %w", err)
+		}
+
+		return nil
+	})
+}
+
 // GetPRStats возвращает общую статистику по PR
 func (r *PullRequestRepository) GetPRStats(ctx context.Context) (map[string]int, error) {
 	query := `
@@ -386,7 +827,7 @@ func (r *PullRequestRepository) GetPRStats(ctx context.Context) (map[string]int,
 	`
 
 	var total, open, merged, avgReviewersX100 int
-	err := r.db.QueryRowContext(ctx, query, domain.PRStatusOpen, domain.PRStatusMerged).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, domain.PRStatusOpen, domain.PRStatusMerged).Scan(
 		&total, &open, &merged, &avgReviewersX100,
 	)
 	if err != nil {
@@ -414,7 +855,7 @@ func (r *PullRequestRepository) GetUserAssignmentStats(ctx context.Context) (map
 		GROUP BY pr.user_id
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, domain.PRStatusOpen, domain.PRStatusMerged)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, domain.PRStatusOpen, domain.PRStatusMerged)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user assignment stats: %w", err)
 	}
@@ -444,22 +885,494 @@ func (r *PullRequestRepository) GetUserAssignmentStats(ctx context.Context) (map
 	return stats, nil
 }
 
-// List возвращает список PR с фильтрацией по статусу
-func (r *PullRequestRepository) List(ctx context.Context, status string) ([]*domain.PullRequest, error) {
+// CountOpenByUsers возвращает число открытых PR, где пользователи из userIDs назначены ревьюверами.
+// В отличие от GetUserAssignmentStats учитывает только текущую нагрузку, а не всю историю
+func (r *PullRequestRepository) CountOpenByUsers(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int)
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+		SELECT rv.user_id, COUNT(*)
+		FROM pr_reviewers rv
+		JOIN pull_requests pr ON pr.pull_request_id = rv.pull_request_id
+		WHERE pr.status = $1 AND rv.user_id = ANY($2::text[])
+		GROUP BY rv.user_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, domain.PRStatusOpen, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open reviews: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan open review count: %w", err)
+		}
+		counts[userID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open review counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetTeamPRSummary считает открытые, неукомплектованные и смердженные PR авторов команды
+// и среднее время до мерджа одним запросом
+func (r *PullRequestRepository) GetTeamPRSummary(ctx context.Context, teamName string, reviewerCount int) (*domain.TeamPRSummary, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE pr.status = $2),
+			COUNT(*) FILTER (WHERE pr.status = $2 AND COALESCE(rv.reviewers, 0) < $4),
+			COUNT(*) FILTER (WHERE pr.status = $3),
+			COALESCE(AVG(EXTRACT(EPOCH FROM pr.merged_at - pr.created_at))
+				FILTER (WHERE pr.status = $3 AND pr.merged_at IS NOT NULL), 0)
+		FROM pull_requests pr
+		JOIN users u ON u.user_id = pr.author_id
+		LEFT JOIN (
+			SELECT pull_request_id, COUNT(*) AS reviewers
+			FROM pr_reviewers
+			GROUP BY pull_request_id
+		) rv ON rv.pull_request_id = pr.pull_request_id
+		WHERE u.team_name = $1
+	`
+
+	var summary domain.TeamPRSummary
+	var avgSeconds float64
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, teamName, domain.PRStatusOpen, domain.PRStatusMerged, reviewerCount).
+		Scan(&summary.OpenPRs, &summary.UnderstaffedPRs, &summary.MergedPRs, &avgSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team PR summary: %w", err)
+	}
+	summary.AvgTimeToMerge = time.Duration(avgSeconds * float64(time.Second))
+
+	return &summary, nil
+}
+
+// GetReviewerPairCounts возвращает частоту совместных назначений пар ревьюверов на последних
+// recentPRs PR автора (по времени создания). Учитывается текущий состав ревьюверов PR
+func (r *PullRequestRepository) GetReviewerPairCounts(ctx context.Context, authorID string, recentPRs int) (map[domain.ReviewerPair]int, error) {
+	query := `
+		WITH recent AS (
+			SELECT pull_request_id
+			FROM pull_requests
+			WHERE author_id = $1
+			ORDER BY created_at DESC, pull_request_id DESC
+			LIMIT $2
+		)
+		SELECT a.user_id, b.user_id, COUNT(*)
+		FROM recent
+		JOIN pr_reviewers a ON a.pull_request_id = recent.pull_request_id
+		JOIN pr_reviewers b ON b.pull_request_id = recent.pull_request_id AND a.user_id < b.user_id
+		GROUP BY a.user_id, b.user_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, authorID, recentPRs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reviewer pairs: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.ReviewerPair]int)
+	for rows.Next() {
+		var first, second string
+		var count int
+		if err := rows.Scan(&first, &second, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer pair count: %w", err)
+		}
+		counts[domain.NewReviewerPair(first, second)] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reviewer pair counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetReciprocalReviewers возвращает кандидатов, на последний PR которых (по времени создания)
+// назначен reviewerID. Учитывается текущий состав ревьюверов PR
+func (r *PullRequestRepository) GetReciprocalReviewers(ctx context.Context, reviewerID string, candidateIDs []string) ([]string, error) {
+	reciprocal := []string{}
+	if len(candidateIDs) == 0 {
+		return reciprocal, nil
+	}
+
+	query := `
+		WITH latest AS (
+			SELECT DISTINCT ON (author_id) author_id, pull_request_id
+			FROM pull_requests
+			WHERE author_id = ANY($2::text[])
+			ORDER BY author_id, created_at DESC, pull_request_id DESC
+		)
+		SELECT latest.author_id
+		FROM latest
+		JOIN pr_reviewers rv ON rv.pull_request_id = latest.pull_request_id AND rv.user_id = $1
+		ORDER BY latest.author_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, reviewerID, candidateIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reciprocal reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan reciprocal reviewer: %w", err)
+		}
+		reciprocal = append(reciprocal, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reciprocal reviewers: %w", err)
+	}
+
+	return reciprocal, nil
+}
+
+// GetAuthorStats возвращает число PR каждого автора по статусам вместе с его username,
+// от авторов с наибольшим числом PR. Непустой teamName оставляет только авторов этой команды
+func (r *PullRequestRepository) GetAuthorStats(ctx context.Context, teamName string) ([]domain.AuthorStats, error) {
+	query := `
+		SELECT
+			pr.author_id,
+			u.username,
+			COUNT(*) AS total_prs,
+			COUNT(*) FILTER (WHERE pr.status = $1) AS open_prs,
+			COUNT(*) FILTER (WHERE pr.status = $2) AS merged_prs
+		FROM pull_requests pr
+		JOIN users u ON u.user_id = pr.author_id
+		WHERE $3::text = '' OR u.team_name = $3::text
+		GROUP BY pr.author_id, u.username
+		ORDER BY total_prs DESC, pr.author_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, domain.PRStatusOpen, domain.PRStatusMerged, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get author stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]domain.AuthorStats, 0)
+	for rows.Next() {
+		var s domain.AuthorStats
+		if err := rows.Scan(&s.AuthorID, &s.Username, &s.TotalPRs, &s.OpenPRs, &s.MergedPRs); err != nil {
+			return nil, fmt.Errorf("failed to scan author stats: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating author stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetTeamLoadStats возвращает по каждой команде число назначений её участников на открытые PR
+// и число активных участников. Команды без назначений возвращаются с нулём
+func (r *PullRequestRepository) GetTeamLoadStats(ctx context.Context) ([]domain.TeamLoadStats, error) {
+	query := `
+		SELECT
+			t.team_name,
+			COUNT(p.pull_request_id) AS open_assignments,
+			COUNT(DISTINCT u.user_id) FILTER (WHERE u.is_active) AS active_reviewers
+		FROM teams t
+		LEFT JOIN users u ON u.team_name = t.team_name
+		LEFT JOIN pr_reviewers rv ON rv.user_id = u.user_id
+		LEFT JOIN pull_requests p ON p.pull_request_id = rv.pull_request_id AND p.status = $1
+		GROUP BY t.team_name
+		ORDER BY open_assignments DESC, t.team_name
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, domain.PRStatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team load stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]domain.TeamLoadStats, 0)
+	for rows.Next() {
+		var s domain.TeamLoadStats
+		if err := rows.Scan(&s.TeamName, &s.OpenAssignments, &s.ActiveReviewers); err != nil {
+			return nil, fmt.Errorf("failed to scan team load stats: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating team load stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetUserAssignmentStatsByUser возвращает статистику назначений одного пользователя.
+// Для пользователя без назначений возвращаются нули
+func (r *PullRequestRepository) GetUserAssignmentStatsByUser(ctx context.Context, userID string) (*domain.UserAssignmentStats, error) {
+	query := `
+		SELECT
+			COUNT(*) as total_assignments,
+			COUNT(*) FILTER (WHERE p.status = $2) as open_prs,
+			COUNT(*) FILTER (WHERE p.status = $3) as merged_prs
+		FROM pr_reviewers pr
+		INNER JOIN pull_requests p ON pr.pull_request_id = p.pull_request_id
+		WHERE pr.user_id = $1
+	`
+
+	stats := &domain.UserAssignmentStats{UserID: userID}
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID, domain.PRStatusOpen, domain.PRStatusMerged).Scan(
+		&stats.TotalAssignments, &stats.OpenPRs, &stats.MergedPRs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user assignment stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetTrends возвращает непрерывный ряд интервалов (day/week) с числом созданных и смердженных PR.
+// Интервалы строятся через generate_series, поэтому дни без активности тоже попадают в ответ.
+// Непустой teamName оставляет только PR авторов, состоящих в команде сейчас
+func (r *PullRequestRepository) GetTrends(
+	ctx context.Context,
+	from, to time.Time,
+	interval domain.TrendInterval,
+	teamName string,
+) ([]domain.TrendBucket, error) {
+	query := `
+		WITH buckets AS (
+			SELECT generate_series(
+				date_trunc($3::text, $1::timestamp),
+				date_trunc($3::text, $2::timestamp),
+				('1 ' || $3::text)::interval
+			) AS bucket
+		),
+		scoped AS (
+			SELECT pr.created_at, pr.merged_at
+			FROM pull_requests pr
+			JOIN users u ON u.user_id = pr.author_id
+			WHERE $4::text = '' OR u.team_name = $4::text
+		),
+		created AS (
+			SELECT date_trunc($3::text, created_at) AS bucket, COUNT(*) AS cnt
+			FROM scoped
+			GROUP BY 1
+		),
+		merged AS (
+			SELECT date_trunc($3::text, merged_at) AS bucket, COUNT(*) AS cnt
+			FROM scoped
+			WHERE merged_at IS NOT NULL
+			GROUP BY 1
+		)
+		SELECT b.bucket, COALESCE(c.cnt, 0), COALESCE(m.cnt, 0)
+		FROM buckets b
+		LEFT JOIN created c ON c.bucket = b.bucket
+		LEFT JOIN merged m ON m.bucket = b.bucket
+		ORDER BY b.bucket
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, from, to, string(interval), teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trends: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := make([]domain.TrendBucket, 0)
+	for rows.Next() {
+		var bucket domain.TrendBucket
+		var date time.Time
+		if err := rows.Scan(&date, &bucket.Created, &bucket.Merged); err != nil {
+			return nil, fmt.Errorf("failed to scan trend bucket: %w", err)
+		}
+		bucket.Date = date.Format(time.DateOnly)
+		buckets = append(buckets, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating trend buckets: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// GetOrphanedReviewers возвращает записи pr_reviewers, чей user_id отсутствует в users
+func (r *PullRequestRepository) GetOrphanedReviewers(ctx context.Context) ([]domain.OrphanedReviewer, error) {
+	query := `
+		SELECT prr.pull_request_id, prr.user_id, pr.status
+		FROM pr_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		LEFT JOIN users u ON u.user_id = prr.user_id
+		WHERE u.user_id IS NULL
+		ORDER BY prr.pull_request_id, prr.user_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orphaned reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	orphans := []domain.OrphanedReviewer{}
+	for rows.Next() {
+		var orphan domain.OrphanedReviewer
+		if err := rows.Scan(&orphan.PullRequestID, &orphan.UserID, &orphan.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan orphaned reviewer: %w", err)
+		}
+		orphans = append(orphans, orphan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return orphans, nil
+}
+
+// GetInvariantViolations ищет нарушения инвариантов, которые не гарантируются схемой:
+// автор среди ревьюверов своего PR, назначения и события истории ревьюверов позже мерджа
+// и записи pr_reviewers без PR (возможны при снятом внешнем ключе или ручной правке данных)
+func (r *PullRequestRepository) GetInvariantViolations(ctx context.Context) ([]domain.InvariantViolation, error) {
+	query := `
+		SELECT $1::text, prr.pull_request_id, prr.user_id
+		FROM pr_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		WHERE prr.user_id = pr.author_id
+		UNION
+		SELECT $2::text, prr.pull_request_id, prr.user_id
+		FROM pr_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		WHERE pr.status = $4 AND prr.assigned_at > pr.merged_at
+		UNION
+		SELECT $2::text, rh.pull_request_id, rh.user_id
+		FROM reviewer_history rh
+		JOIN pull_requests pr ON pr.pull_request_id = rh.pull_request_id
+		WHERE pr.status = $4 AND rh.created_at > pr.merged_at
+		UNION
+		SELECT $3::text, prr.pull_request_id, prr.user_id
+		FROM pr_reviewers prr
+		LEFT JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		WHERE pr.pull_request_id IS NULL
+		ORDER BY 2, 3, 1
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query,
+		domain.ViolationAuthorIsReviewer, domain.ViolationChangedAfterMerge, domain.ViolationMissingPullRequest,
+		domain.PRStatusMerged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invariant violations: %w", err)
+	}
+	defer rows.Close()
+
+	violations := []domain.InvariantViolation{}
+	for rows.Next() {
+		var violation domain.InvariantViolation
+		if err := rows.Scan(&violation.Kind, &violation.PullRequestID, &violation.UserID); err != nil {
+			return nil, fmt.Errorf("failed to scan invariant violation: %w", err)
+		}
+		violations = append(violations, violation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return violations, nil
+}
+
+// GetAssignmentCountsSince возвращает число назначений каждого пользователя начиная с since.
+// Пользователи без назначений в результат не попадают
+func (r *PullRequestRepository) GetAssignmentCountsSince(ctx context.Context, since time.Time) (map[string]int, error) {
+	query := `
+		SELECT user_id, COUNT(*)
+		FROM pr_reviewers
+		WHERE assigned_at >= $1
+		GROUP BY user_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan assignment count: %w", err)
+		}
+		counts[userID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating assignment counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetOpenAssignedBefore возвращает открытые PR, самое раннее назначение ревьювера которых
+// сделано раньше before. PR без ревьюверов в результат не попадают
+func (r *PullRequestRepository) GetOpenAssignedBefore(ctx context.Context, before time.Time) ([]domain.SLABreach, error) {
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, MIN(rv.assigned_at) AS oldest
+		FROM pull_requests pr
+		JOIN pr_reviewers rv ON rv.pull_request_id = pr.pull_request_id
+		WHERE pr.status = $2
+		GROUP BY pr.pull_request_id, pr.pull_request_name, pr.author_id
+		HAVING MIN(rv.assigned_at) < $1
+		ORDER BY oldest, pr.pull_request_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, before, domain.PRStatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open assignments: %w", err)
+	}
+	defer rows.Close()
+
+	breaches := []domain.SLABreach{}
+	for rows.Next() {
+		var breach domain.SLABreach
+		if err := rows.Scan(&breach.PullRequestID, &breach.PullRequestName, &breach.AuthorID, &breach.OldestAssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan open assignment: %w", err)
+		}
+		breaches = append(breaches, breach)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open assignments: %w", err)
+	}
+
+	return breaches, nil
+}
+
+// List возвращает список PR с фильтрацией по статусам (пустой statuses - без фильтра)
+func (r *PullRequestRepository) List(ctx context.Context, statuses []string, page domain.Page) ([]*domain.PullRequest, error) {
 	query := `
 		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
 		FROM pull_requests
 	`
 
 	args := []interface{}{}
-	if status != "" {
-		query += " WHERE status = $1"
-		args = append(args, status)
+	if len(statuses) > 0 {
+		query += " WHERE status = ANY($1::text[])"
+		args = append(args, statuses)
 	}
 
-	query += " ORDER BY created_at DESC"
+	query += " ORDER BY created_at DESC, pull_request_id"
+	query, args = appendPage(query, args, page)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pull requests: %w", err)
 	}
@@ -480,19 +1393,136 @@ func (r *PullRequestRepository) List(ctx context.Context, status string) ([]*dom
 			pr.MergedAt = &mergedAt.Time
 		}
 
-		// Получаем ревьюверов для каждого PR
-		reviewers, err := r.GetReviewers(ctx, pr.PullRequestID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get reviewers for PR %s: %w", pr.PullRequestID, err)
-		}
-		pr.AssignedReviewers = reviewers
-
 		prs = append(prs, &pr)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating pull requests: %w", err)
 	}
+	rows.Close()
+
+	// Ревьюверы всей страницы - одним запросом, а не по запросу на PR
+	if err := r.loadReviewersBatch(ctx, prs); err != nil {
+		return nil, err
+	}
 
 	return prs, nil
 }
+
+// StreamList последовательно передаёт PR в fn по мере сканирования строк,
+// не накапливая весь список в памяти. Ошибка fn прерывает чтение.
+// Ревьюверы читаются тем же запросом, так что чтение занимает одно соединение и работает
+// внутри транзакции из ctx. Пока вызывается fn, курсор открыт: в транзакции fn не должна
+// обращаться к БД (соединение транзакции занято чтением)
+func (r *PullRequestRepository) StreamList(ctx context.Context, statuses []string, fn func(pr *domain.PullRequest) error) error {
+	query := `
+		SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at,
+		       r.user_id, COALESCE(r.is_primary, FALSE)
+		FROM pull_requests p
+		LEFT JOIN pr_reviewers r ON r.pull_request_id = p.pull_request_id
+	`
+
+	args := []interface{}{}
+	if len(statuses) > 0 {
+		query += " WHERE p.status = ANY($1::text[])"
+		args = append(args, statuses)
+	}
+
+	// Строки одного PR идут подряд, ревьюверы - в порядке назначения
+	query += " ORDER BY p.created_at DESC, p.pull_request_id, r.assigned_at"
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to stream pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	var current *domain.PullRequest
+	for rows.Next() {
+		var pr domain.PullRequest
+		var createdAt time.Time
+		var mergedAt sql.NullTime
+		var reviewerID sql.NullString
+		var isPrimary bool
+
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &createdAt, &mergedAt,
+			&reviewerID, &isPrimary); err != nil {
+			return fmt.Errorf("failed to scan pull request: %w", err)
+		}
+
+		// Новый PR: предыдущий собран целиком и передаётся в fn
+		if current == nil || current.PullRequestID != pr.PullRequestID {
+			if current != nil {
+				if err := fn(current); err != nil {
+					return err
+				}
+			}
+
+			pr.CreatedAt = &createdAt
+			if mergedAt.Valid {
+				pr.MergedAt = &mergedAt.Time
+			}
+			pr.AssignedReviewers = make([]string, 0)
+			current = &pr
+		}
+
+		if reviewerID.Valid {
+			current.AssignedReviewers = append(current.AssignedReviewers, reviewerID.String)
+			if isPrimary {
+				current.PrimaryReviewer = reviewerID.String
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating pull requests: %w", err)
+	}
+
+	if current == nil {
+		return nil
+	}
+	return fn(current)
+}
+
+// GetFreezeUntil возвращает окончание заморозки автоназначения из service_settings
+func (r *PullRequestRepository) GetFreezeUntil(ctx context.Context) (*time.Time, error) {
+	query := `SELECT freeze_until FROM service_settings WHERE id = 1`
+
+	var until sql.NullTime
+	err := conn(ctx, r.db).QueryRowContext(ctx, query).Scan(&until)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get freeze window: %w", err)
+	}
+
+	if !until.Valid {
+		return nil, nil
+	}
+	return &until.Time, nil
+}
+
+// SetFreezeUntil сохраняет окончание заморозки автоназначения (NULL снимает заморозку)
+func (r *PullRequestRepository) SetFreezeUntil(ctx context.Context, until *time.Time) error {
+	query := `
+		INSERT INTO service_settings (id, freeze_until) VALUES (1, $1)
+		ON CONFLICT (id) DO UPDATE SET freeze_until = EXCLUDED.freeze_until
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, until); err != nil {
+		return fmt.Errorf("failed to set freeze window: %w", err)
+	}
+
+	return nil
+}
+
+// appendPage дописывает к запросу LIMIT/OFFSET для окна page (при Limit 0 - только OFFSET)
+func appendPage(query string, args []interface{}, page domain.Page) (string, []interface{}) {
+	if page.Limit > 0 {
+		args = append(args, page.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if page.Offset > 0 {
+		args = append(args, page.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+	return query, args
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"reviewservice/internal/domain"
+)
+
+// queryCounter counts statements sent to PostgreSQL
+type queryCounter struct {
+	queries atomic.Int64
+}
+
+func (c *queryCounter) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	c.queries.Add(1)
+	return ctx
+}
+
+func (c *queryCounter) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+}
+
+// benchDSN returns the test database DSN (same defaults as the integration tests)
+func benchDSN() string {
+	get := func(key, fallback string) string {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+		return fallback
+	}
+
+	return fmt.Sprintf("postgres://%s:%s@%s:5432/%s?sslmode=disable",
+		get("TEST_DB_USER", "reviewservice"), get("TEST_DB_PASSWORD", "password"),
+		get("TEST_DB_HOST", "localhost"), get("TEST_DB_NAME", "reviewservice_test"))
+}
+
+// BenchmarkPullRequestRepository_List lists a page of 100 PRs with two reviewers each and
+// reports queries per List call: reviewers are loaded in one batch, so it stays at 2
+// regardless of the page size instead of 1 + page size
+func BenchmarkPullRequestRepository_List(b *testing.B) {
+	if testing.Short() {
+		b.Skip("skipping database benchmark in short mode")
+	}
+
+	dsn := benchDSN()
+	m, err := migrate.New("file://../../../migrations", dsn)
+	if err != nil {
+		b.Skipf("test database unavailable: %v", err)
+	}
+	m.Down()
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		b.Fatalf("failed to run migrations: %v", err)
+	}
+	m.Close()
+
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		b.Fatalf("failed to parse DSN: %v", err)
+	}
+	counter := &queryCounter{}
+	connConfig.Tracer = counter
+	db := stdlib.OpenDB(*connConfig)
+	defer func() {
+		db.Exec("TRUNCATE teams, users, pull_requests, pr_reviewers CASCADE")
+		db.Close()
+	}()
+
+	ctx := context.Background()
+	seed := []string{
+		"INSERT INTO teams (team_name) VALUES ('backend')",
+		"INSERT INTO users (user_id, username, team_name) SELECT 'u' || i, 'user' || i, 'backend' FROM generate_series(1, 3) i",
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
+			SELECT 'pr-' || i, 'PR ' || i, 'u1', 'OPEN' FROM generate_series(1, 100) i`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id)
+			SELECT 'pr-' || i, u FROM generate_series(1, 100) i, unnest(ARRAY['u2', 'u3']) u`,
+	}
+	for _, query := range seed {
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			b.Fatalf("failed to seed: %v", err)
+		}
+	}
+
+	repo := NewPullRequestRepository(db)
+	page := domain.Page{Limit: 100}
+
+	b.ResetTimer()
+	counter.queries.Store(0)
+	for i := 0; i < b.N; i++ {
+		prs, err := repo.List(ctx, nil, page)
+		if err != nil {
+			b.Fatalf("List failed: %v", err)
+		}
+		if len(prs) != 100 || len(prs[0].AssignedReviewers) != 2 {
+			b.Fatalf("unexpected result: %d PRs", len(prs))
+		}
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(counter.queries.Load())/float64(b.N), "queries/op")
+}
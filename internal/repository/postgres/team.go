@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -22,14 +23,17 @@ func NewTeamRepository(db *sql.DB) *TeamRepository {
 
 // Create создаёт новую команду
 func (r *TeamRepository) Create(ctx context.Context, team *domain.Team) error {
-	query := `INSERT INTO teams (team_name) VALUES ($1)`
+	query := `INSERT INTO teams (team_name, backup_team_name) VALUES ($1, NULLIF($2, ''))`
 
-	_, err := r.db.ExecContext(ctx, query, team.TeamName)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, team.TeamName, team.BackupTeamName)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
 			return domain.ErrTeamExists
 		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return fmt.Errorf("backup team %s not found: %w", team.BackupTeamName, domain.ErrNotFound)
+		}
 		return fmt.Errorf("failed to create team: %w", err)
 	}
 
@@ -38,26 +42,28 @@ func (r *TeamRepository) Create(ctx context.Context, team *domain.Team) error {
 
 // Get получает команду по имени вместе с участниками
 func (r *TeamRepository) Get(ctx context.Context, teamName string) (*domain.Team, error) {
-	// Проверяем существование команды
-	var exists bool
-	checkQuery := `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)`
-	err := r.db.QueryRowContext(ctx, checkQuery, teamName).Scan(&exists)
+	// Проверяем существование команды и читаем её настройки
+	var reviewerCount int
+	var backupTeamName string
+	settingsQuery := `SELECT COALESCE(reviewer_count, 0), COALESCE(backup_team_name, '') FROM teams WHERE team_name = $1`
+	err := conn(ctx, r.db).QueryRowContext(ctx, settingsQuery, teamName).Scan(&reviewerCount, &backupTeamName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check team existence: %w", err)
-	}
-	if !exists {
-		return nil, domain.ErrNotFound
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
 
 	// Получаем участников команды
 	query := `
-		SELECT user_id, username, is_active
+		SELECT user_id, username, is_active, COALESCE(review_group, ''), seniority, COALESCE(max_daily_assignments, 0),
+		       available_from, available_to
 		FROM users
 		WHERE team_name = $1
 		ORDER BY username
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, teamName)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, teamName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team members: %w", err)
 	}
@@ -66,7 +72,8 @@ func (r *TeamRepository) Get(ctx context.Context, teamName string) (*domain.Team
 	members := make([]domain.TeamMember, 0)
 	for rows.Next() {
 		var member domain.TeamMember
-		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.ReviewGroup, &member.Seniority, &member.MaxDailyAssignments,
+			&member.AvailableFrom, &member.AvailableTo); err != nil {
 			return nil, fmt.Errorf("failed to scan team member: %w", err)
 		}
 		members = append(members, member)
@@ -76,9 +83,19 @@ func (r *TeamRepository) Get(ctx context.Context, teamName string) (*domain.Team
 		return nil, fmt.Errorf("error iterating team members: %w", err)
 	}
 
+	rows.Close()
+
+	calendars, err := r.GetCalendars(ctx, []string{teamName})
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.Team{
-		TeamName: teamName,
-		Members:  members,
+		TeamName:       teamName,
+		Members:        members,
+		ReviewerCount:  reviewerCount,
+		BackupTeamName: backupTeamName,
+		Calendar:       calendars[teamName],
 	}, nil
 }
 
@@ -87,10 +104,179 @@ func (r *TeamRepository) Exists(ctx context.Context, teamName string) (bool, err
 	query := `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, teamName).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, teamName).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check team existence: %w", err)
 	}
 
 	return exists, nil
 }
+
+// GetReviewerCount возвращает число ревьюверов на PR команды (0 - не задано)
+func (r *TeamRepository) GetReviewerCount(ctx context.Context, teamName string) (int, error) {
+	query := `SELECT COALESCE(reviewer_count, 0) FROM teams WHERE team_name = $1`
+
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, teamName).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to get team reviewer count: %w", err)
+	}
+
+	return count, nil
+}
+
+// SetReviewerCount задаёт число ревьюверов на PR команды
+func (r *TeamRepository) SetReviewerCount(ctx context.Context, teamName string, count int) error {
+	query := `UPDATE teams SET reviewer_count = $2 WHERE team_name = $1`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, teamName, count)
+	if err != nil {
+		return fmt.Errorf("failed to set team reviewer count: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetBackupTeam возвращает резервную команду (пусто - не задана)
+func (r *TeamRepository) GetBackupTeam(ctx context.Context, teamName string) (string, error) {
+	query := `SELECT COALESCE(backup_team_name, '') FROM teams WHERE team_name = $1`
+
+	var backupTeamName string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, teamName).Scan(&backupTeamName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrNotFound
+		}
+		return "", fmt.Errorf("failed to get backup team: %w", err)
+	}
+
+	return backupTeamName, nil
+}
+
+// SetBackupTeam задаёт резервную команду (пустая строка снимает её)
+func (r *TeamRepository) SetBackupTeam(ctx context.Context, teamName, backupTeamName string) error {
+	query := `UPDATE teams SET backup_team_name = NULLIF($2, '') WHERE team_name = $1`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, teamName, backupTeamName)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return fmt.Errorf("backup team %s not found: %w", backupTeamName, domain.ErrNotFound)
+		}
+		return fmt.Errorf("failed to set backup team: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetCalendars возвращает рабочие календари команд teamNames; команды без
+// календаря в результат не попадают
+func (r *TeamRepository) GetCalendars(ctx context.Context, teamNames []string) (map[string]*domain.TeamCalendar, error) {
+	calendars := make(map[string]*domain.TeamCalendar)
+	if len(teamNames) == 0 {
+		return calendars, nil
+	}
+
+	query := `
+		SELECT t.team_name,
+		       COALESCE(array_to_json(t.working_days), '[]'),
+		       COALESCE((SELECT json_agg(to_char(h.holiday, 'YYYY-MM-DD') ORDER BY h.holiday)
+		                 FROM team_holidays h WHERE h.team_name = t.team_name), '[]')
+		FROM teams t
+		WHERE t.team_name = ANY($1::text[])
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, teamNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team calendars: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamName string
+		var workingDays, holidays []byte
+		if err := rows.Scan(&teamName, &workingDays, &holidays); err != nil {
+			return nil, fmt.Errorf("failed to scan team calendar: %w", err)
+		}
+
+		var calendar domain.TeamCalendar
+		if err := json.Unmarshal(workingDays, &calendar.WorkingDays); err != nil {
+			return nil, fmt.Errorf("failed to decode working days: %w", err)
+		}
+		if err := json.Unmarshal(holidays, &calendar.Holidays); err != nil {
+			return nil, fmt.Errorf("failed to decode holidays: %w", err)
+		}
+
+		if !calendar.IsEmpty() {
+			calendars[teamName] = &calendar
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating team calendars: %w", err)
+	}
+
+	return calendars, nil
+}
+
+// SetCalendar заменяет рабочий календарь команды (пустой календарь снимает его)
+func (r *TeamRepository) SetCalendar(ctx context.Context, teamName string, calendar *domain.TeamCalendar) error {
+	workingDays := make([]int, 0, len(calendar.WorkingDays))
+	workingDays = append(workingDays, calendar.WorkingDays...)
+	holidays := make([]string, 0, len(calendar.Holidays))
+	holidays = append(holidays, calendar.Holidays...)
+
+	return withinTransaction(ctx, r.db, func(ctx context.Context, tx *sql.Tx) error {
+		query := `UPDATE teams SET working_days = NULLIF($2::int[], '{}') WHERE team_name = $1`
+
+		result, err := tx.ExecContext(ctx, query, teamName, workingDays)
+		if err != nil {
+			return fmt.Errorf("failed to set team working days: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrNotFound
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM team_holidays WHERE team_name = $1`, teamName); err != nil {
+			return fmt.Errorf("failed to clear team holidays: %w", err)
+		}
+
+		insertQuery := `
+			INSERT INTO team_holidays (team_name, holiday)
+			SELECT $1, day FROM unnest($2::text[]::date[]) AS day
+			ON CONFLICT DO NOTHING
+		`
+		if _, err := tx.ExecContext(ctx, insertQuery, teamName, holidays); err != nil {
+			return fmt.Errorf("failed to set team holidays: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -6,6 +6,30 @@ import (
 	"fmt"
 )
 
+// txKey - ключ контекста, под которым хранится текущая транзакция
+type txKey struct{}
+
+// querier - общие методы *sql.DB и *sql.Tx, которыми пользуются репозитории
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// txFromContext возвращает транзакцию из контекста (nil, если её нет)
+func txFromContext(ctx context.Context) *sql.Tx {
+	tx, _ := ctx.Value(txKey{}).(*sql.Tx)
+	return tx
+}
+
+// conn возвращает транзакцию из контекста, а если её нет - пул соединений db
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx
+	}
+	return db
+}
+
 // TxManager управляет транзакциями базы данных
 type TxManager struct {
 	db *sql.DB
@@ -16,9 +40,20 @@ func NewTxManager(db *sql.DB) *TxManager {
 	return &TxManager{db: db}
 }
 
-// WithinTransaction выполняет функцию внутри транзакции
-func (tm *TxManager) WithinTransaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
-	tx, err := tm.db.BeginTx(ctx, nil)
+// WithinTransaction выполняет функцию внутри транзакции. Транзакция кладётся в
+// переданный fn контекст, поэтому вызовы репозиториев с этим контекстом выполняются
+// в ней же. Если в ctx уже есть транзакция, fn присоединяется к ней
+func (tm *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	return withinTransaction(ctx, tm.db, fn)
+}
+
+// withinTransaction выполняет fn в транзакции из ctx или в новой транзакции на db
+func withinTransaction(ctx context.Context, db *sql.DB, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	if tx := txFromContext(ctx); tx != nil {
+		return fn(ctx, tx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -30,7 +65,7 @@ func (tm *TxManager) WithinTransaction(ctx context.Context, fn func(tx *sql.Tx)
 		}
 	}()
 
-	if err := fn(tx); err != nil {
+	if err := fn(context.WithValue(ctx, txKey{}, tx), tx); err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return fmt.Errorf("transaction error: %v, rollback error: %w", err, rbErr)
 		}
@@ -25,11 +25,14 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 // Create создаёт нового пользователя
 func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (user_id, username, team_name, is_active)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO users (user_id, username, team_name, is_active, review_group, seniority, max_daily_assignments,
+		                   available_from, available_to)
+		VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5, ''), $6, NULLIF($7, 0), $8, $9)
 	`
 
-	_, err := r.db.ExecContext(ctx, query, user.UserID, user.Username, user.TeamName, user.IsActive)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		user.UserID, user.Username, user.TeamName, user.IsActive, user.ReviewGroup, user.Seniority, user.MaxDailyAssignments,
+		user.AvailableFrom, user.AvailableTo)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -37,7 +40,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 				return fmt.Errorf("user already exists: %w", err)
 			}
 			if pgErr.Code == "23503" { // foreign_key_violation
-				return fmt.Errorf("team not found: %w", err)
+				return fmt.Errorf("team %s not found: %w", user.TeamName, domain.ErrNotFound)
 			}
 		}
 		return fmt.Errorf("failed to create user: %w", err)
@@ -50,15 +53,18 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users
-		SET username = $2, team_name = $3, is_active = $4
+		SET username = $2, team_name = NULLIF($3, ''), is_active = $4, review_group = NULLIF($5, ''), seniority = $6,
+		    max_daily_assignments = NULLIF($7, 0), available_from = $8, available_to = $9
 		WHERE user_id = $1
 	`
 
-	result, err := r.db.ExecContext(ctx, query, user.UserID, user.Username, user.TeamName, user.IsActive)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query,
+		user.UserID, user.Username, user.TeamName, user.IsActive, user.ReviewGroup, user.Seniority, user.MaxDailyAssignments,
+		user.AvailableFrom, user.AvailableTo)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
-			return fmt.Errorf("team not found: %w", err)
+			return fmt.Errorf("team %s not found: %w", user.TeamName, domain.ErrNotFound)
 		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
@@ -78,17 +84,23 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 // Get получает пользователя по ID
 func (r *UserRepository) Get(ctx context.Context, userID string) (*domain.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, COALESCE(team_name, ''), is_active, COALESCE(review_group, ''), seniority, COALESCE(max_daily_assignments, 0),
+		       available_from, available_to
 		FROM users
 		WHERE user_id = $1
 	`
 
 	var user domain.User
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(
 		&user.UserID,
 		&user.Username,
 		&user.TeamName,
 		&user.IsActive,
+		&user.ReviewGroup,
+		&user.Seniority,
+		&user.MaxDailyAssignments,
+		&user.AvailableFrom,
+		&user.AvailableTo,
 	)
 
 	if err != nil {
@@ -104,13 +116,14 @@ func (r *UserRepository) Get(ctx context.Context, userID string) (*domain.User,
 // GetByTeam получает всех пользователей команды
 func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, COALESCE(team_name, ''), is_active, COALESCE(review_group, ''), seniority, COALESCE(max_daily_assignments, 0),
+		       available_from, available_to
 		FROM users
 		WHERE team_name = $1
 		ORDER BY username
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, teamName)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, teamName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users by team: %w", err)
 	}
@@ -119,7 +132,41 @@ func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]doma
 	users := make([]domain.User, 0)
 	for rows.Next() {
 		var user domain.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.ReviewGroup, &user.Seniority, &user.MaxDailyAssignments,
+			&user.AvailableFrom, &user.AvailableTo); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetByReviewGroup получает всех пользователей группы ревью внутри команды
+func (r *UserRepository) GetByReviewGroup(ctx context.Context, teamName, reviewGroup string) ([]domain.User, error) {
+	query := `
+		SELECT user_id, username, COALESCE(team_name, ''), is_active, COALESCE(review_group, ''), seniority, COALESCE(max_daily_assignments, 0),
+		       available_from, available_to
+		FROM users
+		WHERE team_name = $1 AND review_group = $2
+		ORDER BY username
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, teamName, reviewGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users by review group: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]domain.User, 0)
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.ReviewGroup, &user.Seniority, &user.MaxDailyAssignments,
+			&user.AvailableFrom, &user.AvailableTo); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		users = append(users, user)
@@ -135,13 +182,14 @@ func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]doma
 // GetActiveUsersExcludingTeam получает всех активных пользователей кроме указанной команды
 func (r *UserRepository) GetActiveUsersExcludingTeam(ctx context.Context, excludeTeamName string) ([]domain.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, COALESCE(team_name, ''), is_active, COALESCE(review_group, ''), seniority, COALESCE(max_daily_assignments, 0),
+		       available_from, available_to
 		FROM users
 		WHERE is_active = true AND team_name != $1
 		ORDER BY username
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, excludeTeamName)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, excludeTeamName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active users excluding team: %w", err)
 	}
@@ -150,7 +198,8 @@ func (r *UserRepository) GetActiveUsersExcludingTeam(ctx context.Context, exclud
 	users := make([]domain.User, 0)
 	for rows.Next() {
 		var user domain.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.ReviewGroup, &user.Seniority, &user.MaxDailyAssignments,
+			&user.AvailableFrom, &user.AvailableTo); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		users = append(users, user)
@@ -167,7 +216,7 @@ func (r *UserRepository) GetActiveUsersExcludingTeam(ctx context.Context, exclud
 func (r *UserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) error {
 	query := `UPDATE users SET is_active = $2 WHERE user_id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, userID, isActive)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, userID, isActive)
 	if err != nil {
 		return fmt.Errorf("failed to set user active status: %w", err)
 	}
@@ -194,7 +243,7 @@ func (r *UserRepository) BulkDeactivateByTeam(ctx context.Context, teamName stri
 		RETURNING user_id
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, teamName)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, teamName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to bulk deactivate users: %w", err)
 	}
@@ -215,3 +264,132 @@ func (r *UserRepository) BulkDeactivateByTeam(ctx context.Context, teamName stri
 
 	return deactivatedIDs, nil
 }
+
+// BulkActivateByTeam массово активирует пользователей команды
+// Возвращает список ID активированных пользователей
+func (r *UserRepository) BulkActivateByTeam(ctx context.Context, teamName string) ([]string, error) {
+	query := `
+		UPDATE users
+		SET is_active = true
+		WHERE team_name = $1 AND is_active = false
+		RETURNING user_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bulk activate users: %w", err)
+	}
+	defer rows.Close()
+
+	activatedIDs := make([]string, 0)
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan activated user ID: %w", err)
+		}
+		activatedIDs = append(activatedIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating activated users: %w", err)
+	}
+
+	return activatedIDs, nil
+}
+
+// AddReviewConflict сохраняет конфликт интересов; повторное добавление ничего не меняет
+func (r *UserRepository) AddReviewConflict(ctx context.Context, conflict domain.ReviewConflict) error {
+	query := `
+		INSERT INTO review_conflicts (user_a, user_b)
+		VALUES ($1, $2)
+		ON CONFLICT (user_a, user_b) DO NOTHING
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, conflict.UserA, conflict.UserB)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return fmt.Errorf("user not found: %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("failed to add review conflict: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveReviewConflict удаляет конфликт интересов
+func (r *UserRepository) RemoveReviewConflict(ctx context.Context, conflict domain.ReviewConflict) error {
+	query := `DELETE FROM review_conflicts WHERE user_a = $1 AND user_b = $2`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, conflict.UserA, conflict.UserB)
+	if err != nil {
+		return fmt.Errorf("failed to remove review conflict: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListReviewConflicts возвращает все конфликты интересов, упорядоченные по паре
+func (r *UserRepository) ListReviewConflicts(ctx context.Context) ([]domain.ReviewConflict, error) {
+	query := `SELECT user_a, user_b, created_at FROM review_conflicts ORDER BY user_a, user_b`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	conflicts := make([]domain.ReviewConflict, 0)
+	for rows.Next() {
+		var conflict domain.ReviewConflict
+		if err := rows.Scan(&conflict.UserA, &conflict.UserB, &conflict.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review conflict: %w", err)
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review conflicts: %w", err)
+	}
+
+	return conflicts, nil
+}
+
+// GetReviewConflicts возвращает user_id пользователей в конфликте интересов с userID
+func (r *UserRepository) GetReviewConflicts(ctx context.Context, userID string) ([]string, error) {
+	query := `
+		SELECT user_b FROM review_conflicts WHERE user_a = $1
+		UNION
+		SELECT user_a FROM review_conflicts WHERE user_b = $1
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	conflicts := make([]string, 0)
+	for rows.Next() {
+		var conflictID string
+		if err := rows.Scan(&conflictID); err != nil {
+			return nil, fmt.Errorf("failed to scan review conflict: %w", err)
+		}
+		conflicts = append(conflicts, conflictID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review conflicts: %w", err)
+	}
+
+	return conflicts, nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+)
+
+// BulkActivateResult содержит результаты массовой активации команды
+type BulkActivateResult struct {
+	ActivatedUsers []string `json:"activated_users"`
+	ToppedUpPRs    int      `json:"topped_up_prs"`
+	Errors         int      `json:"errors,omitempty"`
+}
+
+// BulkActivateTeam массово активирует пользователей команды (например, после инцидента).
+// При topUp открытые PR авторов команды, у которых ревьюверов меньше числа команды,
+// добираются новыми ревьюверами по обычным правилам отбора; текущие ревьюверы не снимаются.
+// Добор выполняется best-effort: ошибки отдельных PR не прерывают операцию и
+// возвращаются в результате
+func (s *PullRequestService) BulkActivateTeam(ctx context.Context, teamName string, topUp bool) (*BulkActivateResult, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"team_name", &teamName}); err != nil {
+		return nil, err
+	}
+
+	if _, err := teamMembers(ctx, s.userRepo, s.teamRepo, teamName); err != nil {
+		return nil, err
+	}
+
+	activatedIDs, err := s.userRepo.BulkActivateByTeam(ctx, teamName)
+	if err != nil {
+		s.logger.Error("failed to bulk activate team", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to bulk activate team: %w", err)
+	}
+
+	s.logger.Info("team members activated",
+		zap.String("team_name", teamName),
+		zap.Int("count", len(activatedIDs)),
+		zap.Strings("user_ids", activatedIDs))
+
+	result := &BulkActivateResult{ActivatedUsers: activatedIDs}
+	if !topUp {
+		return result, nil
+	}
+
+	openPRs, err := s.teamOpenPRs(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pr := range openPRs {
+		added, _, err := s.topUpReviewers(ctx, pr)
+		if err != nil {
+			s.logger.Error("failed to top up reviewers", zap.Error(err), zap.String("pr_id", pr.PullRequestID))
+			result.Errors++
+		}
+		if len(added) > 0 {
+			result.ToppedUpPRs++
+		}
+	}
+
+	s.logger.Info("bulk activation completed",
+		zap.String("team_name", teamName),
+		zap.Int("activated", len(activatedIDs)),
+		zap.Int("topped_up_prs", result.ToppedUpPRs),
+		zap.Int("errors", result.Errors))
+
+	return result, nil
+}
+
+// ReconcileResult содержит результаты сверки числа ревьюверов открытых PR команды
+type ReconcileResult struct {
+	TeamName     string `json:"team_name"`
+	CheckedPRs   int    `json:"checked_prs"`
+	ToppedUpPRs  int    `json:"topped_up_prs"`
+	StillShort   int    `json:"still_short_prs"`
+	OverReviewed int    `json:"over_reviewed_prs"`
+	Errors       int    `json:"errors,omitempty"`
+}
+
+// ReconcileReviewerCounts приводит открытые PR авторов команды к числу ревьюверов команды
+// после изменения её состава: PR с недобором добираются по обычным правилам отбора, а PR,
+// у которых ревьюверов больше нужного, не трогаются - действующий ревьювер никогда не снимается.
+// PR, которые не удалось добрать до нужного числа (не хватает кандидатов), учитываются в
+// StillShort. Ошибки отдельных PR не прерывают сверку
+func (s *PullRequestService) ReconcileReviewerCounts(ctx context.Context, teamName string) (*ReconcileResult, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"team_name", &teamName}); err != nil {
+		return nil, err
+	}
+
+	if _, err := teamMembers(ctx, s.userRepo, s.teamRepo, teamName); err != nil {
+		return nil, err
+	}
+
+	openPRs, err := s.teamOpenPRs(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReconcileResult{TeamName: teamName, CheckedPRs: len(openPRs)}
+	for _, pr := range openPRs {
+		before := len(pr.AssignedReviewers)
+		added, reviewerCount, err := s.topUpReviewers(ctx, pr)
+		if len(added) > 0 {
+			result.ToppedUpPRs++
+		}
+		if err != nil {
+			s.logger.Error("failed to top up reviewers", zap.Error(err), zap.String("pr_id", pr.PullRequestID))
+			result.Errors++
+			continue
+		}
+
+		switch have := before + len(added); {
+		case have < reviewerCount:
+			result.StillShort++
+		case have > reviewerCount:
+			result.OverReviewed++
+		}
+	}
+
+	s.logger.Info("reviewer counts reconciled",
+		zap.String("team_name", teamName),
+		zap.Int("checked_prs", result.CheckedPRs),
+		zap.Int("topped_up_prs", result.ToppedUpPRs),
+		zap.Int("still_short_prs", result.StillShort),
+		zap.Int("over_reviewed_prs", result.OverReviewed),
+		zap.Int("errors", result.Errors))
+
+	return result, nil
+}
+
+// teamOpenPRs возвращает открытые PR авторов команды teamName. PR собираются целиком
+// до изменений, чтобы не держать открытым курсор во время добора
+func (s *PullRequestService) teamOpenPRs(ctx context.Context, teamName string) ([]*domain.PullRequest, error) {
+	openPRs, err := s.prRepo.GetOpenByAuthorTeam(ctx, teamName)
+	if err != nil {
+		s.logger.Error("failed to get team open PRs", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get open pull requests: %w", err)
+	}
+
+	return openPRs, nil
+}
+
+// topUpReviewers добавляет в PR недостающих до числа команды ревьюверов, не трогая текущих.
+// Возвращает добавленных (в том числе при ошибке на одном из следующих) и нужное PR число ревьюверов
+func (s *PullRequestService) topUpReviewers(ctx context.Context, pr *domain.PullRequest) ([]string, int, error) {
+	author, err := s.userRepo.Get(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pool, source, err := s.reviewPool(ctx, author)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reviewerCount, err := s.poolReviewerCount(ctx, author, source)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	missing := reviewerCount - len(pr.AssignedReviewers)
+	if missing <= 0 {
+		return nil, reviewerCount, nil
+	}
+
+	excluded, err := authorExclusions(ctx, s.userRepo, pr.AuthorID)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, reviewerID := range pr.AssignedReviewers {
+		excluded[reviewerID] = ExclusionAlreadyAssigned
+	}
+	reviewers := s.selectReviewers(ctx, pool, excluded, missing, nil)
+	if source == "" {
+		reviewers, err = s.fillFromBackupTeam(ctx, author.TeamName, reviewers, excluded, missing, nil)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	added := make([]string, 0, len(reviewers))
+	for _, reviewerID := range reviewers {
+		err = s.prRepo.AddReviewer(ctx, pr.PullRequestID, reviewerID)
+		if errors.Is(err, domain.ErrAlreadyAssigned) {
+			// Ревьювер назначен параллельным запросом: слот занят, но не этой операцией
+			err = nil
+			continue
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to add reviewer: %w", err)
+			break
+		}
+		added = append(added, reviewerID)
+	}
+
+	if len(added) > 0 {
+		recordReviewerHistory(ctx, s.prRepo, s.logger, assignEntries(pr.PullRequestID, added)...)
+		s.logger.Info("reviewers topped up",
+			zap.String("pr_id", pr.PullRequestID),
+			zap.Strings("added", added))
+	}
+
+	return added, reviewerCount, err
+}
@@ -0,0 +1,665 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"reviewservice/internal/domain"
+)
+
+// defaultReviewerCount - число ревьюверов на PR, если оно не задано ни команде, ни конфигурацией
+const defaultReviewerCount = 2
+
+// recentPairPRs - сколько последних PR автора учитывается при поиске повторяющихся пар ревьюверов
+const recentPairPRs = 10
+
+// FeatureFlags содержит флаги включения отдельных правил назначения ревьюверов
+type FeatureFlags struct {
+	// DebugTrace включает детальную трассировку (причины исключения кандидатов)
+	DebugTrace bool
+
+	// RequireSenior гарантирует хотя бы одного сеньора среди ревьюверов, если он доступен
+	RequireSenior bool
+
+	// PreferAvailable отдаёт предпочтение кандидатам, находящимся сейчас в своём окне
+	// доступности (User.AvailableFrom/AvailableTo); остальные используются как запасные
+	PreferAvailable bool
+
+	// DiverseReviewGroups не назначает на PR двух ревьюверов из одной группы ревью,
+	// если есть кандидаты из других групп
+	DiverseReviewGroups bool
+
+	// ReassignAuthorTeamOnly ограничивает замену ревьювера (ручную и при деактивации)
+	// командой автора PR: команда ревьювера и другие команды не рассматриваются
+	ReassignAuthorTeamOnly bool
+
+	// AvoidRepeatPairs при выборе двух ревьюверов предпочитает пару, реже всего
+	// назначавшуюся вместе на последние recentPairPRs PR автора
+	AvoidRepeatPairs bool
+
+	// AvoidReciprocal ставит в конец очереди кандидатов, чей последний PR ревьюил автор
+	// текущего PR (взаимное ревью); они назначаются, только если других кандидатов не хватает
+	AvoidReciprocal bool
+}
+
+// AssignmentConfig содержит настройки назначения ревьюверов
+type AssignmentConfig struct {
+	// Features - флаги правил отбора
+	Features FeatureFlags
+
+	// SelectionLogLevel - собственный уровень логгера отбора ревьюверов,
+	// независимый от глобального (детали отбора пишутся на уровне Debug)
+	SelectionLogLevel zapcore.Level
+
+	// CodeOwners сопоставляет префикс пути со списком владельцев (user_id)
+	CodeOwners map[string][]string
+
+	// SizeReviewers - число ревьюверов по размеру PR; для переданного размера
+	// заменяет число команды и ReviewerPolicy.ReviewersPerPR
+	SizeReviewers map[domain.PRSize]int
+
+	// MaxDailyAssignments - дневной лимит назначений по умолчанию (0 - без лимита).
+	// Персональный лимит пользователя (User.MaxDailyAssignments) имеет приоритет
+	MaxDailyAssignments int
+
+	// MaxWeeklyAssignments - недельный лимит назначений (0 - без лимита). Действует вместе
+	// с дневным: кандидат должен укладываться в оба
+	MaxWeeklyAssignments int
+
+	// MaxPendingReviews - сколько незавершённых ревью (назначений на открытые PR) может быть
+	// у кандидата; с большим числом он пропускается при отборе (0 - без ограничения)
+	MaxPendingReviews int
+
+	// MinTeamSize - минимальное число активных участников команды автора (включая его),
+	// при котором ревьюверы назначаются автоматически (0 - без ограничения)
+	MinTeamSize int
+
+	// ExcludeUsername - шаблон имён служебных аккаунтов (ботов), которые никогда
+	// не назначаются автоматически. nil - фильтрация отключена
+	ExcludeUsername *regexp.Regexp
+
+	// FallbackOrder - порядок источников замены ревьювера при деактивации.
+	// Пустой - DefaultFallbackOrder
+	FallbackOrder []FallbackSource
+
+	// ExternalReviewerTeam - команда, из которой назначаются ревьюверы PR авторов без команды.
+	// Пустая (или без участников) - ревьюверы берутся из всех команд
+	ExternalReviewerTeam string
+
+	// IDs - нормализация идентификаторов, общая для всех сервисов
+	IDs IDNormalizer
+
+	// Selector - стратегия выбора ревьюверов среди подходящих кандидатов.
+	// nil - случайный выбор
+	Selector ReviewerSelector
+
+	// BulkReassignConcurrency - число PR, переназначаемых параллельно при массовой
+	// деактивации команды. 0 или 1 - последовательно
+	BulkReassignConcurrency int
+
+	// ReviewSLA - ожидаемое время ревью: открытые PR, ревьювер которых назначен
+	// раньше, считаются нарушившими SLA. 0 - SLA не задан
+	ReviewSLA time.Duration
+
+	// BlockMergeWithoutReviewers запрещает мердж открытого PR без назначенных ревьюверов
+	// (domain.ErrNoReviewers). Повторный мердж уже смердженного PR не блокируется
+	BlockMergeWithoutReviewers bool
+
+	// MaxOpenPRsPerAuthor - сколько открытых PR может быть у одного автора; создание
+	// следующего отклоняется с ErrAuthorPRLimit (0 - без лимита)
+	MaxOpenPRsPerAuthor int
+
+	// UndoWindow - в течение какого времени после назначения или замены ревьювера его
+	// можно отменить (UndoAssignment). 0 - отмена отключена
+	UndoWindow time.Duration
+}
+
+// ReviewerPolicy - политика числа ревьюверов, назначаемых на PR
+type ReviewerPolicy struct {
+	// ReviewersPerPR - число ревьюверов на PR по умолчанию (0 - defaultReviewerCount).
+	// Число, заданное команде (reviewer_count), имеет приоритет
+	ReviewersPerPR int
+}
+
+// reviewersPerPR возвращает число ревьюверов на PR по умолчанию
+func (p ReviewerPolicy) reviewersPerPR() int {
+	if p.ReviewersPerPR <= 0 {
+		return defaultReviewerCount
+	}
+	return p.ReviewersPerPR
+}
+
+// sizeReviewerCount возвращает число ревьюверов для PR размера size
+// (teamCount, если размер не передан или для него не задано число)
+func (c AssignmentConfig) sizeReviewerCount(size domain.PRSize, teamCount int) int {
+	if count, ok := c.SizeReviewers[size]; ok {
+		return count
+	}
+	return teamCount
+}
+
+// ParseSizeReviewers проверяет соответствие размеров PR числу ревьюверов (например,
+// из PR_SIZE_REVIEWERS): неизвестный размер или число меньше единицы - ошибка
+func ParseSizeReviewers(values map[string]int) (map[domain.PRSize]int, error) {
+	counts := make(map[domain.PRSize]int, len(values))
+	for value, count := range values {
+		size := domain.PRSize(strings.TrimSpace(value))
+		if !size.IsValid() {
+			return nil, fmt.Errorf("unknown PR size %q", value)
+		}
+		if count < 1 {
+			return nil, fmt.Errorf("reviewer count %d for size %s must be at least 1", count, size)
+		}
+		counts[size] = count
+	}
+	return counts, nil
+}
+
+// excludesUsername проверяет, исключён ли пользователь из автоназначения по шаблону имени
+func (c AssignmentConfig) excludesUsername(username string) bool {
+	return c.ExcludeUsername != nil && c.ExcludeUsername.MatchString(username)
+}
+
+// filterReassignCandidates отбирает из teamMembers кандидатов на замену ревьювера:
+// активных, не автора, не текущих ревьюверов, не excludeUserID, не служебные аккаунты
+// и не пользователей в конфликте интересов с автором (conflicts)
+func (c AssignmentConfig) filterReassignCandidates(teamMembers []domain.User, authorID string, currentReviewers, conflicts []string, excludeUserID string) []string {
+	excluded := make(map[string]bool)
+	excluded[authorID] = true
+	excluded[excludeUserID] = true
+	for _, reviewerID := range currentReviewers {
+		excluded[reviewerID] = true
+	}
+	for _, userID := range conflicts {
+		excluded[userID] = true
+	}
+
+	var candidates []string
+	for _, member := range teamMembers {
+		if member.IsActive && !excluded[member.UserID] && !c.excludesUsername(member.Username) {
+			candidates = append(candidates, member.UserID)
+		}
+	}
+
+	return candidates
+}
+
+// ExclusionReason описывает причину исключения кандидата из отбора
+type ExclusionReason string
+
+const (
+	ExclusionAuthor          ExclusionReason = "author"
+	ExclusionInactive        ExclusionReason = "inactive"
+	ExclusionAlreadyAssigned ExclusionReason = "already_assigned"
+	ExclusionUsername        ExclusionReason = "username_pattern"
+	ExclusionPrevious        ExclusionReason = "previous_reviewer"
+	ExclusionConflict        ExclusionReason = "review_conflict"
+	// ExclusionDayOff - у команды кандидата сегодня выходной по календарю
+	ExclusionDayOff ExclusionReason = "day_off"
+	// ExclusionCapped - кандидат исчерпал дневной или недельный лимит назначений
+	// либо лимит незавершённых ревью
+	ExclusionCapped ExclusionReason = "capped"
+)
+
+// CandidateExclusion представляет исключённого кандидата
+type CandidateExclusion struct {
+	UserID string          `json:"user_id"`
+	Reason ExclusionReason `json:"reason"`
+}
+
+// SelectionTrace описывает ход отбора кандидатов в ревьюверы: Considered - прошедшие все
+// фильтры, Excluded - отброшенные с причиной. По каждому кандидату хранится последнее решение,
+// поэтому повторный проход (добор слотов, резервная команда) не дублирует записи
+type SelectionTrace struct {
+	Considered []string             `json:"considered"`
+	Excluded   []CandidateExclusion `json:"excluded,omitempty"`
+}
+
+// consider записывает кандидата, прошедшего отбор (nil-безопасно)
+func (t *SelectionTrace) consider(userID string) {
+	if t == nil {
+		return
+	}
+	t.forget(userID)
+	t.Considered = append(t.Considered, userID)
+}
+
+// exclude записывает исключённого кандидата (nil-безопасно). Уже выбранный этим же
+// отбором кандидат при доборе слотов исключается как already_assigned - такое
+// исключение не отменяет его рассмотрения
+func (t *SelectionTrace) exclude(userID string, reason ExclusionReason) {
+	if t == nil {
+		return
+	}
+	if reason == ExclusionAlreadyAssigned && slices.Contains(t.Considered, userID) {
+		return
+	}
+	t.forget(userID)
+	t.Excluded = append(t.Excluded, CandidateExclusion{UserID: userID, Reason: reason})
+}
+
+// drop записывает исключение с причиной reason кандидатов из before, не попавших в after
+// (nil-безопасно)
+func (t *SelectionTrace) drop(before, after []string, reason ExclusionReason) {
+	if t == nil {
+		return
+	}
+	for _, userID := range before {
+		if !slices.Contains(after, userID) {
+			t.exclude(userID, reason)
+		}
+	}
+}
+
+// forget удаляет прежнее решение по кандидату
+func (t *SelectionTrace) forget(userID string) {
+	t.Considered = slices.DeleteFunc(t.Considered, func(id string) bool { return id == userID })
+	t.Excluded = slices.DeleteFunc(t.Excluded, func(e CandidateExclusion) bool { return e.UserID == userID })
+}
+
+// ReviewerTrace содержит трассировку отбора, при котором был назначен ревьювер.
+// Traced равен false, если при назначении трассировка не записывалась (ручное назначение,
+// отмена замены); тогда списки кандидатов пусты
+type ReviewerTrace struct {
+	ReviewerID string `json:"reviewer_id"`
+	Traced     bool   `json:"traced"`
+	SelectionTrace
+}
+
+// AssignmentExplanation объясняет, почему на PR назначены именно эти ревьюверы
+type AssignmentExplanation struct {
+	PullRequestID string          `json:"pull_request_id"`
+	AuthorID      string          `json:"author_id"`
+	TeamName      string          `json:"team_name"`
+	Reviewers     []ReviewerTrace `json:"reviewers"`
+}
+
+// EligibleReviewer - пользователь, которого можно назначить ревьювером PR
+type EligibleReviewer struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// eligibleCandidates возвращает активных участников, не попавших в excluded и не подпадающих
+// под шаблон исключаемых имён из cfg. Если trace не nil, в него записываются рассмотренные
+// и исключённые кандидаты
+func eligibleCandidates(
+	members []domain.User,
+	excluded map[string]ExclusionReason,
+	cfg AssignmentConfig,
+	trace *SelectionTrace,
+) []string {
+	candidates := make([]string, 0)
+	for _, member := range members {
+		if reason, ok := excluded[member.UserID]; ok {
+			trace.exclude(member.UserID, reason)
+			continue
+		}
+		if !member.IsActive {
+			trace.exclude(member.UserID, ExclusionInactive)
+			continue
+		}
+		if cfg.excludesUsername(member.Username) {
+			trace.exclude(member.UserID, ExclusionUsername)
+			continue
+		}
+		trace.consider(member.UserID)
+		candidates = append(candidates, member.UserID)
+	}
+
+	return candidates
+}
+
+// authorExclusions возвращает исключения, общие для любого отбора ревьюверов PR автора:
+// самого автора и пользователей в конфликте интересов с ним (review_conflicts)
+func authorExclusions(ctx context.Context, userRepo domain.UserRepository, authorID string) (map[string]ExclusionReason, error) {
+	conflicts, err := userRepo.GetReviewConflicts(ctx, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review conflicts: %w", err)
+	}
+
+	excluded := map[string]ExclusionReason{authorID: ExclusionAuthor}
+	for _, userID := range conflicts {
+		excluded[userID] = ExclusionConflict
+	}
+	return excluded, nil
+}
+
+// teamMembers возвращает всех участников команды teamName (активных и неактивных).
+// Пустой состав не означает, что команды нет: импорт (ImportTeams) создаёт команды без
+// участников, поэтому в этом случае существование команды проверяется отдельно.
+// Неизвестная команда - ErrNotFound
+func teamMembers(
+	ctx context.Context,
+	userRepo domain.UserRepository,
+	teamRepo domain.TeamRepository,
+	teamName string,
+) ([]domain.User, error) {
+	members, err := userRepo.GetByTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+	if len(members) > 0 {
+		return members, nil
+	}
+
+	exists, err := teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+	return members, nil
+}
+
+// guardNotMerged запрещает изменение смердженного PR. Вызывается каждой операцией,
+// меняющей состав ревьюверов, до выбора кандидатов; репозиторий повторяет проверку под блокировкой
+func guardNotMerged(pr *domain.PullRequest) error {
+	if pr.Status == domain.PRStatusMerged {
+		return domain.ErrPRMerged
+	}
+	return nil
+}
+
+// checkAssignmentInvariants проверяет жёсткие инварианты назначения, действующие всегда,
+// в том числе при принудительном назначении: PR не смерджен и ревьювер не является автором.
+// Правила отбора (команда, активность, лимиты) проверяются отдельно и могут быть обойдены
+func checkAssignmentInvariants(pr *domain.PullRequest, reviewerID string) error {
+	if err := guardNotMerged(pr); err != nil {
+		return err
+	}
+	if pr.AuthorID == reviewerID {
+		return domain.ErrAuthorReviewer
+	}
+	return nil
+}
+
+// ensureSenior гарантирует наличие сеньора среди selected: если ни один из выбранных
+// не сеньор, последний слот отдаётся первому сеньору из rest. Без сеньоров ничего не меняет
+func ensureSenior(selected, rest []string, members []domain.User) {
+	seniors := make(map[string]bool)
+	for _, member := range members {
+		if member.IsSenior() {
+			seniors[member.UserID] = true
+		}
+	}
+
+	for _, userID := range selected {
+		if seniors[userID] {
+			return
+		}
+	}
+
+	for _, userID := range rest {
+		if seniors[userID] {
+			selected[len(selected)-1] = userID
+			return
+		}
+	}
+}
+
+// levelOverrideCore подменяет уровень вложенного core, позволяя именованному
+// под-логгеру писать записи ниже глобального уровня (и наоборот)
+type levelOverrideCore struct {
+	zapcore.Core
+	level zapcore.LevelEnabler
+}
+
+func (c *levelOverrideCore) Enabled(lvl zapcore.Level) bool {
+	return c.level.Enabled(lvl)
+}
+
+func (c *levelOverrideCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelOverrideCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *levelOverrideCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// newSelectionLogger создаёт под-логгер "selection" со своим уровнем логирования
+func newSelectionLogger(logger *zap.Logger, level zapcore.Level) *zap.Logger {
+	return logger.Named("selection").WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelOverrideCore{Core: core, level: level}
+	}))
+}
+
+// pickActiveCandidate выбирает случайного кандидата, повторно проверяя его активность
+// непосредственно перед назначением: список кандидатов мог устареть, пока его обрабатывали.
+// Неактивные кандидаты отбрасываются, выбор повторяется среди оставшихся.
+// Возвращает пустую строку, если активных кандидатов не осталось
+func pickActiveCandidate(ctx context.Context, userRepo domain.UserRepository, candidates []string, logger *zap.Logger) (string, error) {
+	remaining := make([]string, len(candidates))
+	copy(remaining, candidates)
+
+	return pickFirstActive(ctx, userRepo, randomSelector{}.Order(ctx, remaining, nil, len(remaining)), logger)
+}
+
+// pickFirstActive возвращает первого по порядку кандидата, который всё ещё активен.
+// Удалённые и деактивированные кандидаты пропускаются; пустая строка, если активных
+// кандидатов не осталось. Прочие ошибки чтения пользователя возвращаются: иначе сбой
+// хранилища выглядел бы как отсутствие кандидатов
+func pickFirstActive(ctx context.Context, userRepo domain.UserRepository, candidates []string, logger *zap.Logger) (string, error) {
+	for _, candidateID := range candidates {
+		user, err := userRepo.Get(ctx, candidateID)
+		if errors.Is(err, domain.ErrNotFound) {
+			logger.Info("candidate no longer exists, retrying", zap.String("user_id", candidateID))
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to re-check candidate %s: %w", candidateID, err)
+		}
+		if !user.IsActive {
+			logger.Info("candidate became inactive, retrying", zap.String("user_id", candidateID))
+			continue
+		}
+
+		return candidateID, nil
+	}
+
+	return "", nil
+}
+
+// matchCodeOwners возвращает уникальных владельцев префиксов, под которые попадает
+// хотя бы один из paths. Владельцы более длинных (специфичных) префиксов идут первыми
+func matchCodeOwners(codeOwners map[string][]string, paths []string) []string {
+	if len(codeOwners) == 0 || len(paths) == 0 {
+		return nil
+	}
+
+	prefixes := make([]string, 0, len(codeOwners))
+	for prefix := range codeOwners {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		if len(prefixes[i]) != len(prefixes[j]) {
+			return len(prefixes[i]) > len(prefixes[j])
+		}
+		return prefixes[i] < prefixes[j]
+	})
+
+	seen := make(map[string]bool)
+	var owners []string
+	for _, prefix := range prefixes {
+		for _, path := range paths {
+			if !strings.HasPrefix(path, prefix) {
+				continue
+			}
+			for _, ownerID := range codeOwners[prefix] {
+				if !seen[ownerID] {
+					seen[ownerID] = true
+					owners = append(owners, ownerID)
+				}
+			}
+			break
+		}
+	}
+
+	return owners
+}
+
+// startOfDay возвращает начало суток, в которые попадает t
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek возвращает начало недели (понедельник, 00:00), в которую попадает t
+func startOfWeek(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -daysSinceMonday)
+}
+
+// dailyLimits возвращает действующие дневные лимиты участников: персональный,
+// а если он не задан - defaultLimit. Участники без лимита в результат не попадают
+func dailyLimits(members []domain.User, defaultLimit int) map[string]int {
+	limits := make(map[string]int)
+	for _, member := range members {
+		limit := member.MaxDailyAssignments
+		if limit == 0 {
+			limit = defaultLimit
+		}
+		if limit > 0 {
+			limits[member.UserID] = limit
+		}
+	}
+
+	return limits
+}
+
+// withinLimit оставляет кандидатов, у которых counts меньше их лимита в limits (кандидаты
+// без лимита проходят всегда). Если лимит исчерпан у всех, возвращает кандидатов
+// с наименьшим превышением лимита
+func withinLimit(candidates []string, limits, counts map[string]int) []string {
+	within := make([]string, 0, len(candidates))
+	for _, userID := range candidates {
+		limit, ok := limits[userID]
+		if !ok || counts[userID] < limit {
+			within = append(within, userID)
+		}
+	}
+
+	if len(within) > 0 || len(candidates) == 0 {
+		return within
+	}
+
+	minOver := counts[candidates[0]] - limits[candidates[0]]
+	for _, userID := range candidates[1:] {
+		if over := counts[userID] - limits[userID]; over < minOver {
+			minOver = over
+		}
+	}
+
+	least := make([]string, 0)
+	for _, userID := range candidates {
+		if counts[userID]-limits[userID] == minOver {
+			least = append(least, userID)
+		}
+	}
+
+	return least
+}
+
+// splitByAvailability делит кандидатов на находящихся в окне доступности в час hour
+// и остальных, сохраняя исходный порядок
+func splitByAvailability(candidates []string, members []domain.User, hour int) (available, rest []string) {
+	byID := make(map[string]domain.User, len(members))
+	for _, member := range members {
+		byID[member.UserID] = member
+	}
+
+	available = make([]string, 0, len(candidates))
+	rest = make([]string, 0)
+	for _, userID := range candidates {
+		if byID[userID].IsAvailableAt(hour) {
+			available = append(available, userID)
+		} else {
+			rest = append(rest, userID)
+		}
+	}
+
+	return available, rest
+}
+
+// diversifyByGroup переупорядочивает кандидатов так, чтобы первыми шли представители
+// разных групп ревью: по одному на группу (кандидаты без группы ни с кем не конфликтуют),
+// затем остальные. Относительный порядок внутри каждой части сохраняется
+func diversifyByGroup(candidates []string, members []domain.User) []string {
+	groups := make(map[string]string, len(members))
+	for _, member := range members {
+		groups[member.UserID] = member.ReviewGroup
+	}
+
+	seen := make(map[string]bool)
+	first := make([]string, 0, len(candidates))
+	rest := make([]string, 0)
+	for _, userID := range candidates {
+		group := groups[userID]
+		if group != "" && seen[group] {
+			rest = append(rest, userID)
+			continue
+		}
+		seen[group] = group != ""
+		first = append(first, userID)
+	}
+
+	return append(first, rest...)
+}
+
+// excludedAuthor возвращает автора PR среди исключённых кандидатов (пусто, если его нет)
+func excludedAuthor(excluded map[string]ExclusionReason) string {
+	for userID, reason := range excluded {
+		if reason == ExclusionAuthor {
+			return userID
+		}
+	}
+	return ""
+}
+
+// preferFreshPair ставит первыми двух кандидатов, реже всего назначавшихся вместе по pairCounts.
+// Пары перебираются в порядке кандидатов, поэтому при равной частоте побеждает пара, которую
+// выбрала бы стратегия; первая ни разу не встречавшаяся пара берётся сразу. allowed отсекает
+// недопустимые пары; если допустимых нет, порядок не меняется
+func preferFreshPair(candidates []string, pairCounts map[domain.ReviewerPair]int, allowed func(a, b string) bool) []string {
+	bestI, bestJ, bestCount := -1, -1, 0
+search:
+	for i := range candidates {
+		for j := i + 1; j < len(candidates); j++ {
+			if !allowed(candidates[i], candidates[j]) {
+				continue
+			}
+			count := pairCounts[domain.NewReviewerPair(candidates[i], candidates[j])]
+			if bestI < 0 || count < bestCount {
+				bestI, bestJ, bestCount = i, j, count
+			}
+			if count == 0 {
+				break search
+			}
+		}
+	}
+
+	if bestI < 0 {
+		return candidates
+	}
+
+	ordered := make([]string, 0, len(candidates))
+	ordered = append(ordered, candidates[bestI], candidates[bestJ])
+	for k, userID := range candidates {
+		if k != bestI && k != bestJ {
+			ordered = append(ordered, userID)
+		}
+	}
+	return ordered
+}
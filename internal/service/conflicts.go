@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+)
+
+// AddReviewConflict запрещает пользователям userA и userB ревьюить PR друг друга.
+// Уже назначенные ревьюверы не снимаются: конфликт учитывается при следующих отборах.
+// Повторное добавление того же конфликта (в любом порядке) ничего не меняет
+func (s *UserService) AddReviewConflict(ctx context.Context, userA, userB string) (*domain.ReviewConflict, error) {
+	conflict, err := s.reviewConflict(userA, userB)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.AddReviewConflict(ctx, conflict); err != nil {
+		s.logger.Error("failed to add review conflict", zap.Error(err),
+			zap.String("user_a", conflict.UserA), zap.String("user_b", conflict.UserB))
+		return nil, err
+	}
+
+	s.logger.Info("review conflict added", zap.String("user_a", conflict.UserA), zap.String("user_b", conflict.UserB))
+
+	return &conflict, nil
+}
+
+// RemoveReviewConflict снимает конфликт интересов между userA и userB (ErrNotFound, если его нет)
+func (s *UserService) RemoveReviewConflict(ctx context.Context, userA, userB string) (*domain.ReviewConflict, error) {
+	conflict, err := s.reviewConflict(userA, userB)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.RemoveReviewConflict(ctx, conflict); err != nil {
+		s.logger.Error("failed to remove review conflict", zap.Error(err),
+			zap.String("user_a", conflict.UserA), zap.String("user_b", conflict.UserB))
+		return nil, err
+	}
+
+	s.logger.Info("review conflict removed", zap.String("user_a", conflict.UserA), zap.String("user_b", conflict.UserB))
+
+	return &conflict, nil
+}
+
+// ListReviewConflicts возвращает все конфликты интересов
+func (s *UserService) ListReviewConflicts(ctx context.Context) ([]domain.ReviewConflict, error) {
+	conflicts, err := s.userRepo.ListReviewConflicts(ctx)
+	if err != nil {
+		s.logger.Error("failed to list review conflicts", zap.Error(err))
+		return nil, err
+	}
+
+	return conflicts, nil
+}
+
+// reviewConflict нормализует user_id пары и приводит её к каноническому порядку
+func (s *UserService) reviewConflict(userA, userB string) (domain.ReviewConflict, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"user_a", &userA}, idField{"user_b", &userB}); err != nil {
+		return domain.ReviewConflict{}, err
+	}
+	if userA == userB {
+		return domain.ReviewConflict{}, fmt.Errorf("user cannot conflict with themselves: %w", domain.ErrInvalidInput)
+	}
+
+	return domain.NewReviewConflict(userA, userB), nil
+}
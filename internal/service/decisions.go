@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+)
+
+// decisionWriteTimeout ограничивает фоновую запись одного решения отбора
+const decisionWriteTimeout = 5 * time.Second
+
+// recordSelectionDecision сохраняет решение отбора в фоне, не задерживая ответ на создание PR.
+// Журнал аналитический: ошибка записи только логируется. Запись не зависит от отмены
+// контекста запроса, который завершается раньше неё
+func (s *PullRequestService) recordSelectionDecision(ctx context.Context, decision *domain.SelectionDecision) {
+	if decision == nil {
+		return
+	}
+
+	s.decisions.Add(1)
+	go func() {
+		defer s.decisions.Done()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), decisionWriteTimeout)
+		defer cancel()
+
+		if err := s.prRepo.RecordSelectionDecision(ctx, decision); err != nil {
+			s.logger.Warn("failed to record selection decision",
+				zap.Error(err),
+				zap.String("pr_id", decision.PullRequestID))
+		}
+	}()
+}
+
+// WaitSelectionDecisions ждёт завершения фоновой записи решений отбора
+// (вызывается при остановке сервиса до закрытия соединения с БД)
+func (s *PullRequestService) WaitSelectionDecisions() {
+	s.decisions.Wait()
+}
+
+// GetSelectionDecisions возвращает решения автоотбора ревьюверов по PR в порядке записи.
+// ErrNotFound, если PR не существует
+func (s *PullRequestService) GetSelectionDecisions(ctx context.Context, prID string) ([]domain.SelectionDecision, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.prRepo.Get(ctx, prID); err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	decisions, err := s.prRepo.GetSelectionDecisions(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get selection decisions", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	return decisions, nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+)
+
+// FallbackSource - источник кандидатов на замену ревьювера при деактивации
+type FallbackSource string
+
+const (
+	// FallbackReviewerTeam - команда деактивируемого ревьювера
+	FallbackReviewerTeam FallbackSource = "reviewer_team"
+	// FallbackAuthorTeam - команда автора PR
+	FallbackAuthorTeam FallbackSource = "author_team"
+	// FallbackAny - активные пользователи любых других команд
+	FallbackAny FallbackSource = "any"
+)
+
+// DefaultFallbackOrder - порядок поиска замены по умолчанию
+var DefaultFallbackOrder = []FallbackSource{FallbackReviewerTeam, FallbackAuthorTeam, FallbackAny}
+
+// ParseFallbackOrder разбирает порядок источников замены (например, из REASSIGN_FALLBACK_ORDER).
+// Пустой список означает порядок по умолчанию; неизвестные и повторяющиеся источники - ошибка
+func ParseFallbackOrder(values []string) ([]FallbackSource, error) {
+	if len(values) == 0 {
+		return DefaultFallbackOrder, nil
+	}
+
+	order := make([]FallbackSource, 0, len(values))
+	seen := make(map[FallbackSource]bool)
+	for _, value := range values {
+		source := FallbackSource(strings.TrimSpace(value))
+		switch source {
+		case FallbackReviewerTeam, FallbackAuthorTeam, FallbackAny:
+		default:
+			return nil, fmt.Errorf("unknown fallback source %q", value)
+		}
+		if seen[source] {
+			return nil, fmt.Errorf("duplicate fallback source %q", value)
+		}
+		seen[source] = true
+		order = append(order, source)
+	}
+
+	return order, nil
+}
+
+// fallbackOrder возвращает порядок источников замены с учётом ReassignAuthorTeamOnly
+func (c AssignmentConfig) fallbackOrder() []FallbackSource {
+	if c.Features.ReassignAuthorTeamOnly {
+		return []FallbackSource{FallbackAuthorTeam}
+	}
+	return c.FallbackOrder
+}
+
+// fallbackFinder перебирает источники кандидатов на замену в заданном порядке
+// и возвращает кандидатов из первого непустого источника
+type fallbackFinder struct {
+	order    []FallbackSource
+	teams    *teamCache
+	userRepo domain.UserRepository
+	logger   *zap.Logger
+}
+
+// find ищет кандидатов на замену ревьювера из команды reviewerTeam в PR pr;
+// filter отбирает подходящих кандидатов из пользователей источника.
+// Возвращает кандидатов и источник, из которого они получены (пусто, если не найдено)
+func (f *fallbackFinder) find(
+	ctx context.Context,
+	pr *domain.PullRequest,
+	reviewerTeam string,
+	filter func(users []domain.User) []string,
+) ([]string, FallbackSource) {
+	order := f.order
+	if len(order) == 0 {
+		order = DefaultFallbackOrder
+	}
+
+	for _, source := range order {
+		users, err := f.sourceUsers(ctx, source, pr, reviewerTeam)
+		if err != nil {
+			f.logger.Error("failed to get fallback candidates",
+				zap.Error(err),
+				zap.String("pr_id", pr.PullRequestID),
+				zap.String("source", string(source)))
+			continue
+		}
+
+		if candidates := filter(users); len(candidates) > 0 {
+			return candidates, source
+		}
+
+		f.logger.Info("no candidates in fallback source, trying next",
+			zap.String("pr_id", pr.PullRequestID),
+			zap.String("source", string(source)))
+	}
+
+	return nil, ""
+}
+
+// sourceUsers возвращает пользователей источника source
+func (f *fallbackFinder) sourceUsers(
+	ctx context.Context,
+	source FallbackSource,
+	pr *domain.PullRequest,
+	reviewerTeam string,
+) ([]domain.User, error) {
+	switch source {
+	case FallbackReviewerTeam:
+		return f.teams.get(ctx, reviewerTeam)
+	case FallbackAuthorTeam:
+		author, err := f.userRepo.Get(ctx, pr.AuthorID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get author: %w", err)
+		}
+		return f.teams.get(ctx, author.TeamName)
+	case FallbackAny:
+		return f.userRepo.GetActiveUsersExcludingTeam(ctx, reviewerTeam)
+	default:
+		return nil, fmt.Errorf("unknown fallback source %q", source)
+	}
+}
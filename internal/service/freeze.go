@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+)
+
+// SetFreeze задаёт окончание заморозки автоназначения: до этого момента новые PR создаются
+// без ревьюверов. nil снимает заморозку; окончание в прошлом - ErrInvalidInput
+func (s *PullRequestService) SetFreeze(ctx context.Context, until *time.Time) (*time.Time, error) {
+	if until != nil {
+		if !until.After(s.now()) {
+			return nil, fmt.Errorf("freeze end must be in the future: %w", domain.ErrInvalidInput)
+		}
+		utc := until.UTC()
+		until = &utc
+	}
+
+	if err := s.prRepo.SetFreezeUntil(ctx, until); err != nil {
+		s.logger.Error("failed to set freeze window", zap.Error(err))
+		return nil, err
+	}
+
+	if until != nil {
+		s.logger.Info("auto-assignment frozen", zap.Time("freeze_until", *until))
+	} else {
+		s.logger.Info("auto-assignment freeze lifted")
+	}
+
+	return until, nil
+}
+
+// frozen проверяет, действует ли сейчас заморозка автоназначения
+func (s *PullRequestService) frozen(ctx context.Context) (bool, error) {
+	until, err := s.prRepo.GetFreezeUntil(ctx)
+	if err != nil {
+		s.logger.Error("failed to get freeze window", zap.Error(err))
+		return false, fmt.Errorf("failed to get freeze window: %w", err)
+	}
+
+	return until != nil && s.now().Before(*until), nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+)
+
+// HandoffResult содержит результаты передачи ревью одной команды другой
+type HandoffResult struct {
+	FromTeam string `json:"from_team"`
+	ToTeam   string `json:"to_team"`
+	Moved    int    `json:"moved"`
+	Skipped  int    `json:"skipped"`
+	Errors   int    `json:"errors,omitempty"`
+}
+
+// HandoffTeam передаёт назначения участников fromTeam на открытых PR участникам toTeam
+// (например, перед плановой миграцией команды), никого не деактивируя. Замена выбирается
+// по тем же правилам, что и при переназначении (активный, не автор, не текущий ревьювер,
+// не служебный аккаунт, без конфликта с автором), из наименее загруженных открытыми ревью.
+// Если подходящих участников toTeam нет (например, все уже назначены на PR), ревьювер
+// остаётся на месте и назначение считается пропущенным. Ошибки отдельных назначений
+// не прерывают передачу
+func (s *PullRequestService) HandoffTeam(ctx context.Context, fromTeam, toTeam string) (*HandoffResult, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"from_team", &fromTeam}, idField{"to_team", &toTeam}); err != nil {
+		return nil, err
+	}
+	if fromTeam == toTeam {
+		return nil, fmt.Errorf("cannot hand off team %s to itself: %w", fromTeam, domain.ErrInvalidInput)
+	}
+
+	fromMembers, err := teamMembers(ctx, s.userRepo, s.teamRepo, fromTeam)
+	if err != nil {
+		return nil, err
+	}
+	toMembers, err := teamMembers(ctx, s.userRepo, s.teamRepo, toTeam)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HandoffResult{FromTeam: fromTeam, ToTeam: toTeam}
+
+	// Назначения группируются по PR, чтобы замены в одном PR учитывали уже сделанные
+	var prOrder []string
+	prReviewers := make(map[string][]string)
+	for _, member := range fromMembers {
+		openPRs, err := s.prRepo.GetOpenByReviewer(ctx, member.UserID)
+		if err != nil {
+			s.logger.Error("failed to get open PRs for user", zap.Error(err), zap.String("user_id", member.UserID))
+			result.Errors++
+			continue
+		}
+		for _, prID := range openPRs {
+			if _, ok := prReviewers[prID]; !ok {
+				prOrder = append(prOrder, prID)
+			}
+			prReviewers[prID] = append(prReviewers[prID], member.UserID)
+		}
+	}
+
+	selector := leastLoadedSelector{prRepo: s.prRepo}
+	for _, prID := range prOrder {
+		for _, oldReviewerID := range prReviewers[prID] {
+			moved, err := s.handoffReviewer(ctx, selector, toMembers, prID, oldReviewerID)
+			switch {
+			case err != nil:
+				s.logger.Error("failed to hand off reviewer",
+					zap.Error(err),
+					zap.String("pr_id", prID),
+					zap.String("old_reviewer", oldReviewerID))
+				result.Errors++
+			case moved:
+				result.Moved++
+			default:
+				result.Skipped++
+			}
+		}
+	}
+
+	s.logger.Info("team handoff completed",
+		zap.String("from_team", fromTeam),
+		zap.String("to_team", toTeam),
+		zap.Int("moved", result.Moved),
+		zap.Int("skipped", result.Skipped),
+		zap.Int("errors", result.Errors))
+
+	return result, nil
+}
+
+// handoffReviewer заменяет ревьювера oldReviewerID в PR prID наименее загруженным подходящим
+// участником toMembers. Возвращает false без ошибки, если подходящих участников нет
+func (s *PullRequestService) handoffReviewer(
+	ctx context.Context,
+	selector ReviewerSelector,
+	toMembers []domain.User,
+	prID, oldReviewerID string,
+) (bool, error) {
+	// PR перечитывается: предыдущие замены могли изменить его состав
+	pr, err := s.prRepo.Get(ctx, prID)
+	if err != nil {
+		return false, err
+	}
+
+	conflicts, err := s.userRepo.GetReviewConflicts(ctx, pr.AuthorID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get review conflicts: %w", err)
+	}
+
+	candidates := s.cfg.filterReassignCandidates(toMembers, pr.AuthorID, pr.AssignedReviewers, conflicts, oldReviewerID)
+	if len(candidates) == 0 {
+		s.logger.Info("no eligible target for handoff, keeping reviewer",
+			zap.String("pr_id", prID),
+			zap.String("reviewer", oldReviewerID))
+		return false, nil
+	}
+
+	newReviewerID, err := pickFirstActive(ctx, s.userRepo, selector.Order(ctx, candidates, toMembers, len(candidates)), s.logger)
+	if err != nil {
+		return false, err
+	}
+	if newReviewerID == "" {
+		return false, nil
+	}
+
+	if err := s.prRepo.ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID); err != nil {
+		return false, fmt.Errorf("failed to reassign reviewer: %w", err)
+	}
+	recordReviewerHistory(ctx, s.prRepo, s.logger, reassignEntry(prID, oldReviewerID, newReviewerID))
+
+	s.logger.Info("reviewer handed off",
+		zap.String("pr_id", prID),
+		zap.String("old_reviewer", oldReviewerID),
+		zap.String("new_reviewer", newReviewerID))
+
+	return true, nil
+}
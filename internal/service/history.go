@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+)
+
+// recordReviewerHistory сохраняет события истории ревьюверов после успешного изменения PR.
+// История вспомогательная: ошибка записи логируется, но не откатывает уже выполненное изменение
+func recordReviewerHistory(
+	ctx context.Context,
+	prRepo domain.PullRequestRepository,
+	logger *zap.Logger,
+	entries ...domain.ReviewerHistoryEntry,
+) {
+	if len(entries) == 0 {
+		return
+	}
+
+	if err := prRepo.RecordReviewerHistory(ctx, entries); err != nil {
+		logger.Error("failed to record reviewer history",
+			zap.Error(err),
+			zap.String("pr_id", entries[0].PullRequestID))
+	}
+}
+
+// withTrace прикладывает трассировку автоотбора trace к событиям назначения и замены
+// среди entries. Причины исключения сохраняются только при DebugTrace. Трассировка
+// вспомогательная: ошибка кодирования логируется, события сохраняются без неё
+func (s *PullRequestService) withTrace(entries []domain.ReviewerHistoryEntry, trace *SelectionTrace) []domain.ReviewerHistoryEntry {
+	if trace == nil || len(entries) == 0 {
+		return entries
+	}
+
+	// Кандидаты хранятся в порядке user_id, чтобы трассировка не зависела от порядка участников команды
+	stored := SelectionTrace{Considered: append([]string{}, trace.Considered...)}
+	slices.Sort(stored.Considered)
+	if s.cfg.Features.DebugTrace {
+		stored.Excluded = slices.SortedFunc(slices.Values(trace.Excluded), func(a, b CandidateExclusion) int {
+			return strings.Compare(a.UserID, b.UserID)
+		})
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		s.logger.Warn("failed to encode selection trace", zap.Error(err), zap.String("pr_id", entries[0].PullRequestID))
+		return entries
+	}
+
+	for i := range entries {
+		if entries[i].Action == domain.ReviewerActionAssign || entries[i].Action == domain.ReviewerActionReassign {
+			entries[i].SelectionTrace = data
+		}
+	}
+	return entries
+}
+
+// assignEntries возвращает события назначения ревьюверов reviewerIDs в PR prID
+func assignEntries(prID string, reviewerIDs []string) []domain.ReviewerHistoryEntry {
+	entries := make([]domain.ReviewerHistoryEntry, 0, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		entries = append(entries, domain.ReviewerHistoryEntry{
+			PullRequestID: prID,
+			UserID:        reviewerID,
+			Action:        domain.ReviewerActionAssign,
+		})
+	}
+	return entries
+}
+
+// removeEntry возвращает событие удаления ревьювера userID из PR prID
+func removeEntry(prID, userID string) domain.ReviewerHistoryEntry {
+	return domain.ReviewerHistoryEntry{
+		PullRequestID: prID,
+		UserID:        userID,
+		Action:        domain.ReviewerActionRemove,
+	}
+}
+
+// reassignEntry возвращает событие замены ревьювера oldID на newID в PR prID
+func reassignEntry(prID, oldID, newID string) domain.ReviewerHistoryEntry {
+	return domain.ReviewerHistoryEntry{
+		PullRequestID: prID,
+		UserID:        oldID,
+		Action:        domain.ReviewerActionReassign,
+		ReplacedBy:    newID,
+	}
+}
+
+// undoEntry возвращает событие отмены: userID снят с PR prID, restoredID возвращён
+func undoEntry(prID, userID, restoredID string) domain.ReviewerHistoryEntry {
+	return domain.ReviewerHistoryEntry{
+		PullRequestID: prID,
+		UserID:        userID,
+		Action:        domain.ReviewerActionUndo,
+		ReplacedBy:    restoredID,
+	}
+}
+
+// resetEntries возвращает события сброса ревьюверов PR prID: удаление прежних ревьюверов,
+// не попавших в новый состав, и назначение новых. Оставшиеся ревьюверы в историю не попадают
+func resetEntries(prID string, previous, reviewers []string) []domain.ReviewerHistoryEntry {
+	before := make(map[string]bool, len(previous))
+	for _, reviewerID := range previous {
+		before[reviewerID] = true
+	}
+	after := make(map[string]bool, len(reviewers))
+	for _, reviewerID := range reviewers {
+		after[reviewerID] = true
+	}
+
+	var entries []domain.ReviewerHistoryEntry
+	for _, reviewerID := range previous {
+		if !after[reviewerID] {
+			entries = append(entries, removeEntry(prID, reviewerID))
+		}
+	}
+	var added []string
+	for _, reviewerID := range reviewers {
+		if !before[reviewerID] {
+			added = append(added, reviewerID)
+		}
+	}
+
+	return append(entries, assignEntries(prID, added)...)
+}
+
+// GetReviewerHistory возвращает всех, кто когда-либо был ревьювером PR (включая заменённых
+// и удалённых), в порядке событий. ErrNotFound, если PR не существует
+func (s *PullRequestService) GetReviewerHistory(ctx context.Context, prID string) ([]domain.ReviewerHistoryEntry, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.prRepo.Get(ctx, prID); err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	history, err := s.prRepo.GetReviewerHistory(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get reviewer history", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	return history, nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"reviewservice/internal/domain"
+)
+
+// maxIDLength - максимальная длина идентификатора (совпадает с VARCHAR(255) в схеме)
+const maxIDLength = 255
+
+// IDNormalizer приводит идентификаторы (user_id, team_name, pull_request_id) к
+// каноническому виду: обрезает пробелы по краям и, если включено, переводит в
+// нижний регистр. Нулевое значение только обрезает пробелы
+type IDNormalizer struct {
+	// Lowercase переводит идентификаторы в нижний регистр ("U1" и "u1" - один пользователь)
+	Lowercase bool
+}
+
+// Normalize возвращает нормализованный идентификатор или ErrInvalidInput,
+// если он пуст после обрезки пробелов или длиннее maxIDLength
+func (n IDNormalizer) Normalize(field, id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if n.Lowercase {
+		id = strings.ToLower(id)
+	}
+
+	if id == "" {
+		return "", fmt.Errorf("%s is empty: %w", field, domain.ErrInvalidInput)
+	}
+	if len(id) > maxIDLength {
+		return "", fmt.Errorf("%s exceeds %d characters: %w", field, maxIDLength, domain.ErrInvalidInput)
+	}
+
+	return id, nil
+}
+
+// idField - ссылка на нормализуемый идентификатор и имя его поля для сообщения об ошибке
+type idField struct {
+	name string
+	id   *string
+}
+
+// normalizeFields нормализует идентификаторы на месте, останавливаясь на первом некорректном
+func (n IDNormalizer) normalizeFields(fields ...idField) error {
+	for _, f := range fields {
+		normalized, err := n.Normalize(f.name, *f.id)
+		if err != nil {
+			return err
+		}
+		*f.id = normalized
+	}
+	return nil
+}
+
+// normalizeAll нормализует несколько идентификаторов на месте; field - имя поля для ошибки
+func (n IDNormalizer) normalizeAll(field string, ids []string) error {
+	for i, id := range ids {
+		normalized, err := n.Normalize(field, id)
+		if err != nil {
+			return err
+		}
+		ids[i] = normalized
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+	"reviewservice/internal/testutil"
+)
+
+// TestIDNormalizer_Normalize tests trimming, optional lowercasing and rejection of invalid IDs
+func TestIDNormalizer_Normalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		lowercase bool
+		id        string
+		want      string
+		wantErr   bool
+	}{
+		{name: "trims whitespace", id: "  u1\t", want: "u1"},
+		{name: "keeps case by default", id: "U1", want: "U1"},
+		{name: "lowercases when enabled", lowercase: true, id: " Backend ", want: "backend"},
+		{name: "rejects empty", id: "", wantErr: true},
+		{name: "rejects whitespace only", id: "   ", wantErr: true},
+		{name: "accepts max length", id: strings.Repeat("a", maxIDLength), want: strings.Repeat("a", maxIDLength)},
+		{name: "rejects oversized", id: strings.Repeat("a", maxIDLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IDNormalizer{Lowercase: tt.lowercase}.Normalize("user_id", tt.id)
+
+			if tt.wantErr {
+				testutil.AssertTrue(t, errors.Is(err, domain.ErrInvalidInput), "Expected ErrInvalidInput")
+				return
+			}
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, got, tt.want, "Normalized ID")
+		})
+	}
+}
+
+// TestPullRequestService_NormalizedIDsRoundTrip tests that IDs differing only in whitespace
+// and case resolve to the same PR and user through create and get
+func TestPullRequestService_NormalizedIDsRoundTrip(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["u1"] = &domain.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	userRepo.Users["u2"] = &domain.User{UserID: "u2", TeamName: "backend", IsActive: true}
+	cfg := AssignmentConfig{IDs: IDNormalizer{Lowercase: true}}
+	prService := NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), nil, ReviewerPolicy{}, cfg, zap.NewNop())
+	userService := NewUserService(userRepo, prRepo, cfg, zap.NewNop())
+	ctx := context.Background()
+
+	pr, err := prService.CreatePullRequest(ctx, "  PR-1 ", "Feature", " U1", nil, nil, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, pr.PullRequestID, "pr-1", "PR ID normalized on create")
+	testutil.AssertEqual(t, pr.AuthorID, "u1", "Author ID normalized on create")
+
+	_, err = prService.CreatePullRequest(ctx, "pr-1", "Duplicate", "u1", nil, nil, "")
+	testutil.AssertErrorIs(t, err, domain.ErrPRExists)
+
+	explanation, err := prService.ExplainAssignment(ctx, "Pr-1 ")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, explanation.PullRequestID, "pr-1", "PR found by differently written ID")
+
+	reviews, err := prService.GetUserReviews(ctx, " U2 ", domain.Page{Limit: 10})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, reviews.UserID, "u2", "User ID normalized on lookup")
+	testutil.AssertLen(t, reviews.PullRequests, 1, "Review found for normalized reviewer ID")
+
+	user, err := userService.GetUser(ctx, "U1")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, user.UserID, "u1", "User found by differently written ID")
+
+	_, err = prService.MergePullRequest(ctx, "   ")
+	testutil.AssertTrue(t, errors.Is(err, domain.ErrInvalidInput), "Whitespace-only ID rejected")
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+)
+
+// CheckIntegrity возвращает назначения ревьюверов, ссылающиеся на удалённых пользователей
+func (s *PullRequestService) CheckIntegrity(ctx context.Context) ([]domain.OrphanedReviewer, error) {
+	orphans, err := s.prRepo.GetOrphanedReviewers(ctx)
+	if err != nil {
+		s.logger.Error("failed to get orphaned reviewers", zap.Error(err))
+		return nil, err
+	}
+
+	return orphans, nil
+}
+
+// SelfCheck проверяет инварианты назначения ревьюверов: автор не ревьюит свой PR, состав
+// ревьюверов не меняется после мерджа, назначения ссылаются на существующие PR и пользователей
+// (последнее - проверка CheckIntegrity). Пустой список означает, что нарушений нет
+func (s *PullRequestService) SelfCheck(ctx context.Context) ([]domain.InvariantViolation, error) {
+	violations, err := s.prRepo.GetInvariantViolations(ctx)
+	if err != nil {
+		s.logger.Error("failed to get invariant violations", zap.Error(err))
+		return nil, err
+	}
+
+	orphans, err := s.CheckIntegrity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, orphan := range orphans {
+		violations = append(violations, domain.InvariantViolation{
+			Kind:          domain.ViolationMissingUser,
+			PullRequestID: orphan.PullRequestID,
+			UserID:        orphan.UserID,
+		})
+	}
+
+	if len(violations) > 0 {
+		s.logger.Warn("assignment invariant violations found", zap.Int("count", len(violations)))
+	}
+
+	return violations, nil
+}
+
+// RepairReviewers исправляет осиротевшие назначения: в открытых PR ревьювер заменяется
+// кандидатом из пула автора, в смердженных PR или при отсутствии кандидатов запись удаляется
+func (s *PullRequestService) RepairReviewers(ctx context.Context) ([]domain.RepairedReviewer, error) {
+	orphans, err := s.CheckIntegrity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	repaired := make([]domain.RepairedReviewer, 0, len(orphans))
+	for _, orphan := range orphans {
+		replacement := ""
+		if orphan.Status == domain.PRStatusOpen {
+			replacement, err = s.findOrphanReplacement(ctx, orphan.PullRequestID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if replacement != "" {
+			err = s.prRepo.ReassignReviewer(ctx, orphan.PullRequestID, orphan.UserID, replacement)
+		} else {
+			err = s.prRepo.DeleteOrphanedReviewer(ctx, orphan.PullRequestID, orphan.UserID)
+		}
+		if err != nil {
+			s.logger.Error("failed to repair orphaned reviewer",
+				zap.Error(err),
+				zap.String("pr_id", orphan.PullRequestID),
+				zap.String("user_id", orphan.UserID))
+			return nil, fmt.Errorf("failed to repair reviewer %s in %s: %w", orphan.UserID, orphan.PullRequestID, err)
+		}
+		if replacement != "" {
+			recordReviewerHistory(ctx, s.prRepo, s.logger, reassignEntry(orphan.PullRequestID, orphan.UserID, replacement))
+		} else {
+			recordReviewerHistory(ctx, s.prRepo, s.logger, removeEntry(orphan.PullRequestID, orphan.UserID))
+		}
+
+		s.logger.Warn("orphaned reviewer repaired",
+			zap.String("pr_id", orphan.PullRequestID),
+			zap.String("user_id", orphan.UserID),
+			zap.String("replaced_by", replacement))
+
+		repaired = append(repaired, domain.RepairedReviewer{
+			PullRequestID: orphan.PullRequestID,
+			UserID:        orphan.UserID,
+			ReplacedBy:    replacement,
+		})
+	}
+
+	return repaired, nil
+}
+
+// findOrphanReplacement подбирает замену осиротевшему ревьюверу открытого PR
+// по обычным правилам отбора (пусто, если кандидатов нет)
+func (s *PullRequestService) findOrphanReplacement(ctx context.Context, prID string) (string, error) {
+	pr, err := s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return "", err
+	}
+
+	author, err := s.userRepo.Get(ctx, pr.AuthorID)
+	if err != nil {
+		s.logger.Error("failed to get author", zap.Error(err), zap.String("author_id", pr.AuthorID))
+		return "", err
+	}
+
+	pool, _, err := s.reviewPool(ctx, author)
+	if err != nil {
+		return "", err
+	}
+
+	excluded, err := authorExclusions(ctx, s.userRepo, pr.AuthorID)
+	if err != nil {
+		return "", err
+	}
+	for _, reviewerID := range pr.AssignedReviewers {
+		excluded[reviewerID] = ExclusionAlreadyAssigned
+	}
+
+	picked := s.selectReviewers(ctx, pool, excluded, 1, nil)
+	if len(picked) == 0 {
+		return "", nil
+	}
+
+	return picked[0], nil
+}
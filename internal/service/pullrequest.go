@@ -2,100 +2,346 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"math/rand"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"reviewservice/internal/domain"
 )
 
+// TxManager выполняет fn в транзакции, доступной репозиториям через ctx (postgres.TxManager)
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error
+}
+
 // PullRequestService реализует бизнес-логику для работы с Pull Request'ами
 type PullRequestService struct {
-	prRepo   domain.PullRequestRepository
-	userRepo domain.UserRepository
-	logger   *zap.Logger
+	prRepo    domain.PullRequestRepository
+	userRepo  domain.UserRepository
+	teamRepo  domain.TeamRepository
+	txManager TxManager
+	policy    ReviewerPolicy
+	cfg       AssignmentConfig
+	logger    *zap.Logger
+
+	// now возвращает текущее время (подменяется в тестах)
+	now func() time.Time
+
+	// selectionLog - под-логгер деталей отбора ревьюверов со своим уровнем
+	selectionLog *zap.Logger
+
+	// decisions отслеживает фоновую запись решений отбора (WaitSelectionDecisions)
+	decisions sync.WaitGroup
 }
 
-// NewPullRequestService создаёт новый экземпляр PullRequestService
+// NewPullRequestService создаёт новый экземпляр PullRequestService.
+// txManager может быть nil (тесты на моках): тогда операции выполняются без транзакции
 func NewPullRequestService(
 	prRepo domain.PullRequestRepository,
 	userRepo domain.UserRepository,
+	teamRepo domain.TeamRepository,
+	txManager TxManager,
+	policy ReviewerPolicy,
+	cfg AssignmentConfig,
 	logger *zap.Logger,
 ) *PullRequestService {
 	return &PullRequestService{
-		prRepo:   prRepo,
-		userRepo: userRepo,
-		logger:   logger,
+		prRepo:    prRepo,
+		userRepo:  userRepo,
+		teamRepo:  teamRepo,
+		txManager: txManager,
+		policy:    policy,
+		cfg:       cfg,
+		logger:    logger,
+		now:       time.Now,
+
+		selectionLog: newSelectionLogger(logger, cfg.SelectionLogLevel),
 	}
 }
 
-// CreatePullRequest создаёт новый PR и автоматически назначает до 2 ревьюверов
+// CreatePullRequest создаёт новый PR и автоматически назначает ревьюверов
+// (до числа, заданного команде автора, либо до ReviewerPolicy.ReviewersPerPR).
+// Если переданы paths, в первую очередь назначаются владельцы этих путей (code owners).
+// Непустой candidatePool заменяет пул команды: ревьюверы выбираются только из него.
+// Непустой size задаёт число ревьюверов по размеру PR (SizeReviewers)
 func (s *PullRequestService) CreatePullRequest(
 	ctx context.Context,
 	prID, prName, authorID string,
+	paths, candidatePool []string,
+	size domain.PRSize,
+) (*domain.PullRequest, error) {
+	pr, decision, trace, err := s.proposePullRequest(ctx, prID, prName, authorID, paths, candidatePool, size)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers := pr.AssignedReviewers
+	pr.AssignedReviewers = []string{}
+	pr.PrimaryReviewer = ""
+
+	// PR и его ревьюверы сохраняются в одной транзакции: если назначение не удалось,
+	// не остаётся открытого PR без ревьюверов, которые ему уже не будут назначены
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.prRepo.Create(ctx, pr); err != nil {
+			return err
+		}
+		if len(reviewers) == 0 {
+			return nil
+		}
+		if err := s.prRepo.AssignReviewers(ctx, pr.PullRequestID, reviewers); err != nil {
+			return fmt.Errorf("failed to assign reviewers: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to create PR", zap.Error(err), zap.String("pr_id", pr.PullRequestID))
+		return nil, err
+	}
+
+	s.logger.Info("PR created", zap.String("pr_id", pr.PullRequestID), zap.String("author_id", pr.AuthorID))
+
+	if pr.AutoAssignSkipped != "" {
+		return pr, nil
+	}
+
+	if len(reviewers) > 0 {
+		pr.AssignedReviewers = reviewers
+		pr.PrimaryReviewer = reviewers[0]
+		recordReviewerHistory(ctx, s.prRepo, s.logger, s.withTrace(assignEntries(pr.PullRequestID, reviewers), trace)...)
+		s.selectionLog.Debug("reviewers assigned", zap.String("pr_id", pr.PullRequestID), zap.Strings("reviewers", reviewers))
+	} else {
+		s.logger.Warn("no reviewers available", zap.String("pr_id", pr.PullRequestID), zap.String("author_id", pr.AuthorID))
+	}
+	s.recordSelectionDecision(ctx, decision)
+
+	return pr, nil
+}
+
+// withinTransaction выполняет fn в транзакции txManager, а без него - напрямую
+func (s *PullRequestService) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.txManager == nil {
+		return fn(ctx)
+	}
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context, _ *sql.Tx) error {
+		return fn(ctx)
+	})
+}
+
+// PreviewPullRequest выполняет те же проверки и отбор ревьюверов, что и CreatePullRequest,
+// но ничего не сохраняет: возвращает PR в том виде, в каком он был бы создан (dry run).
+// Случайный отбор при последующем создании может дать других ревьюверов
+func (s *PullRequestService) PreviewPullRequest(
+	ctx context.Context,
+	prID, prName, authorID string,
+	paths, candidatePool []string,
+	size domain.PRSize,
 ) (*domain.PullRequest, error) {
+	pr, _, _, err := s.proposePullRequest(ctx, prID, prName, authorID, paths, candidatePool, size)
+	return pr, err
+}
+
+// proposePullRequest проверяет входные данные и собирает новый PR с отобранными ревьюверами
+// (assigned_reviewers и primary_reviewer заполнены), не обращаясь к репозиторию на запись.
+// Решение и трассировка отбора возвращаются, только если автоотбор выполнялся
+func (s *PullRequestService) proposePullRequest(
+	ctx context.Context,
+	prID, prName, authorID string,
+	paths, candidatePool []string,
+	size domain.PRSize,
+) (*domain.PullRequest, *domain.SelectionDecision, *SelectionTrace, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}, idField{"author_id", &authorID}); err != nil {
+		return nil, nil, nil, err
+	}
+	if size != "" && !size.IsValid() {
+		return nil, nil, nil, fmt.Errorf("unknown PR size %q: %w", size, domain.ErrInvalidInput)
+	}
+	if err := s.cfg.IDs.normalizeAll("candidate_pool", candidatePool); err != nil {
+		return nil, nil, nil, err
+	}
+
 	// Проверяем существование PR
 	exists, err := s.prRepo.Exists(ctx, prID)
 	if err != nil {
 		s.logger.Error("failed to check PR existence", zap.Error(err), zap.String("pr_id", prID))
-		return nil, fmt.Errorf("failed to check PR existence: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to check PR existence: %w", err)
 	}
 
 	if exists {
-		return nil, domain.ErrPRExists
+		return nil, nil, nil, domain.ErrPRExists
 	}
 
 	// Получаем автора
 	author, err := s.userRepo.Get(ctx, authorID)
 	if err != nil {
 		s.logger.Error("failed to get author", zap.Error(err), zap.String("author_id", authorID))
-		return nil, err
+		return nil, nil, nil, err
+	}
+
+	if !author.IsActive {
+		return nil, nil, nil, domain.ErrAuthorInactive
+	}
+
+	if err := s.checkAuthorPRLimit(ctx, authorID); err != nil {
+		return nil, nil, nil, err
 	}
 
-	// Создаём PR
 	pr := &domain.PullRequest{
 		PullRequestID:     prID,
 		PullRequestName:   prName,
 		AuthorID:          authorID,
 		Status:            domain.PRStatusOpen,
 		AssignedReviewers: []string{},
+		Size:              size,
 	}
 
-	if err := s.prRepo.Create(ctx, pr); err != nil {
-		s.logger.Error("failed to create PR", zap.Error(err), zap.String("pr_id", prID))
-		return nil, err
+	// Во время заморозки PR создаётся без ревьюверов: ревьюить всё равно некому
+	frozen, err := s.frozen(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if frozen {
+		pr.AutoAssignSkipped = domain.AutoAssignSkippedFreeze
+		pr.Frozen = true
+		s.logger.Info("auto-assignment frozen, skipping", zap.String("pr_id", prID))
+		return pr, nil, nil, nil
 	}
 
-	s.logger.Info("PR created", zap.String("pr_id", prID), zap.String("author_id", authorID))
+	// Маленькие команды не получают автоназначения: ревьюверы добавляются вручную
+	tooSmall, err := s.teamBelowMinSize(ctx, author)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if tooSmall {
+		pr.AutoAssignSkipped = domain.AutoAssignSkippedTeamTooSmall
+		s.logger.Info("team below minimum size, skipping auto-assignment",
+			zap.String("pr_id", prID),
+			zap.String("team_name", author.TeamName),
+			zap.Int("min_team_size", s.cfg.MinTeamSize))
+		return pr, nil, nil, nil
+	}
 
-	// Получаем команду автора
-	teamMembers, err := s.userRepo.GetByTeam(ctx, author.TeamName)
+	// Получаем пул кандидатов: явно переданный candidate_pool, иначе группа ревью автора
+	// либо вся команда
+	var teamMembers []domain.User
+	var source string
+	if len(candidatePool) > 0 {
+		teamMembers, err = s.explicitPool(ctx, candidatePool)
+	} else {
+		teamMembers, source, err = s.reviewPool(ctx, author)
+	}
 	if err != nil {
-		s.logger.Error("failed to get team members", zap.Error(err), zap.String("team_name", author.TeamName))
-		return nil, fmt.Errorf("failed to get team members: %w", err)
+		return nil, nil, nil, err
 	}
+	pr.ReviewerSource = source
 
-	// Выбираем до 2 активных ревьюверов (исключаем автора)
-	reviewers := s.selectReviewers(teamMembers, authorID, 2)
+	reviewerCount, err := s.poolReviewerCount(ctx, author, source)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// Число по размеру PR ограничено только числом подходящих кандидатов в пуле
+	reviewerCount = s.cfg.sizeReviewerCount(size, reviewerCount)
+	pr.ReviewerCount = reviewerCount
 
-	// Назначаем ревьюверов
-	if len(reviewers) > 0 {
-		if err := s.prRepo.AssignReviewers(ctx, prID, reviewers); err != nil {
-			s.logger.Error("failed to assign reviewers", zap.Error(err), zap.String("pr_id", prID))
-			return nil, fmt.Errorf("failed to assign reviewers: %w", err)
+	excluded, err := authorExclusions(ctx, s.userRepo, authorID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Сначала назначаем владельцев затронутых путей, остальные слоты - из команды.
+	// При явном пуле владельцы вне его не назначаются
+	reviewers, err := s.selectCodeOwners(ctx, paths, excluded, reviewerCount)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(candidatePool) > 0 {
+		reviewers = withinPool(reviewers, teamMembers)
+	}
+
+	trace := &SelectionTrace{Considered: []string{}}
+	for _, ownerID := range reviewers {
+		trace.consider(ownerID)
+		excluded[ownerID] = ExclusionAlreadyAssigned
+	}
+	reviewers = append(reviewers, s.selectReviewers(ctx, teamMembers, excluded, reviewerCount-len(reviewers), trace)...)
+	if source == "" && len(candidatePool) == 0 {
+		reviewers, err = s.fillFromBackupTeam(ctx, author.TeamName, reviewers, excluded, reviewerCount, trace)
+		if err != nil {
+			return nil, nil, nil, err
 		}
+	}
+	pr.SelectionStrategy = s.cfg.selector().Name()
+	s.selectionLog.Debug("reviewers selected",
+		zap.String("pr_id", prID),
+		zap.String("team_name", author.TeamName),
+		zap.String("review_group", author.ReviewGroup),
+		zap.String("strategy", pr.SelectionStrategy),
+		zap.Int("pool_size", len(teamMembers)),
+		zap.Strings("reviewers", reviewers))
+
+	if len(reviewers) > 0 {
 		pr.AssignedReviewers = reviewers
-		s.logger.Info("reviewers assigned", zap.String("pr_id", prID), zap.Strings("reviewers", reviewers))
-	} else {
-		s.logger.Warn("no reviewers available", zap.String("pr_id", prID), zap.String("team_name", author.TeamName))
+		pr.PrimaryReviewer = reviewers[0]
 	}
+	pr.Warnings = s.selectionWarnings(ctx, reviewers, reviewerCount, teamMembers)
 
-	return pr, nil
+	decision := &domain.SelectionDecision{
+		PullRequestID: prID,
+		PoolSize:      len(teamMembers),
+		Reviewers:     pr.AssignedReviewers,
+		Strategy:      pr.SelectionStrategy,
+	}
+
+	return pr, decision, trace, nil
+}
+
+// checkAuthorPRLimit возвращает ErrAuthorPRLimit, если у автора уже MaxOpenPRsPerAuthor открытых PR
+func (s *PullRequestService) checkAuthorPRLimit(ctx context.Context, authorID string) error {
+	if s.cfg.MaxOpenPRsPerAuthor <= 0 {
+		return nil
+	}
+
+	open, err := s.prRepo.CountOpenByAuthor(ctx, authorID)
+	if err != nil {
+		s.logger.Error("failed to count author open PRs", zap.Error(err), zap.String("author_id", authorID))
+		return fmt.Errorf("failed to count author open pull requests: %w", err)
+	}
+
+	if open >= s.cfg.MaxOpenPRsPerAuthor {
+		s.logger.Warn("author open PR limit reached",
+			zap.String("author_id", authorID),
+			zap.Int("open_prs", open),
+			zap.Int("limit", s.cfg.MaxOpenPRsPerAuthor))
+		return fmt.Errorf("author %s has %d open pull requests (limit %d): %w",
+			authorID, open, s.cfg.MaxOpenPRsPerAuthor, domain.ErrAuthorPRLimit)
+	}
+
+	return nil
 }
 
 // MergePullRequest помечает PR как смердженный (идемпотентная операция)
 func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}); err != nil {
+		return nil, err
+	}
+
+	if s.cfg.BlockMergeWithoutReviewers {
+		current, err := s.prRepo.Get(ctx, prID)
+		if err != nil {
+			return nil, err
+		}
+		if current.Status != domain.PRStatusMerged && len(current.AssignedReviewers) == 0 {
+			s.logger.Warn("merge blocked: PR has no reviewers", zap.String("pr_id", prID))
+			return nil, domain.ErrNoReviewers
+		}
+	}
+
 	pr, err := s.prRepo.Merge(ctx, prID)
 	if err != nil {
 		s.logger.Error("failed to merge PR", zap.Error(err), zap.String("pr_id", prID))
@@ -107,71 +353,197 @@ func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string)
 	return pr, nil
 }
 
-// ReassignReviewer переназначает ревьювера
+// GetPullRequest возвращает PR с ревьюверами и временем создания/мерджа
+func (s *PullRequestService) GetPullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// DeletePullRequest удаляет ошибочно созданный PR вместе с его ревьюверами
+func (s *PullRequestService) DeletePullRequest(ctx context.Context, prID string) error {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}); err != nil {
+		return err
+	}
+
+	if err := s.prRepo.Delete(ctx, prID); err != nil {
+		s.logger.Error("failed to delete PR", zap.Error(err), zap.String("pr_id", prID))
+		return err
+	}
+
+	s.logger.Info("PR deleted", zap.String("pr_id", prID))
+
+	return nil
+}
+
+// ReassignResult содержит результат переназначения ревьювера:
+// обновлённый PR, нового ревьювера и состав ревьюверов до и после замены
+type ReassignResult struct {
+	PR              *domain.PullRequest
+	ReplacedBy      string
+	BeforeReviewers []string
+	AfterReviewers  []string
+}
+
+// maxReassignAttempts - число попыток переназначения, если выбранную замену
+// одновременно назначил другой запрос
+const maxReassignAttempts = 3
+
+// ReassignReviewer переназначает ревьювера.
+// Это строгая замена один на один: число ревьюверов команды не учитывается, поэтому
+// PR с недобором ревьюверов остаётся с тем же их числом, а недобор восполняет ResetReviewers.
+// Репозиторий блокирует PR на время замены, поэтому одновременные переназначения одного PR
+// выполняются по очереди. Если выбранную замену уже успел назначить параллельный запрос,
+// отбор повторяется по свежему составу ревьюверов (до maxReassignAttempts раз)
 func (s *PullRequestService) ReassignReviewer(
 	ctx context.Context,
 	prID, oldReviewerID string,
-) (*domain.PullRequest, string, error) {
+) (*ReassignResult, error) {
+	return s.ReassignReviewerTo(ctx, prID, oldReviewerID, "")
+}
+
+// ReassignReviewerTo заменяет ревьювера oldReviewerID на выбранного targetID.
+// Цель проходит те же проверки, что и кандидаты обычной замены (команда, активность,
+// лимиты, служебные аккаунты); неподходящая цель - ErrTargetIneligible, автор - ErrAuthorReviewer,
+// уже назначенный ревьювер - ErrAlreadyAssigned. Пустой targetID - обычная замена
+// со случайным выбором (ReassignReviewer). Признак основного ревьювера переходит к цели
+func (s *PullRequestService) ReassignReviewerTo(
+	ctx context.Context,
+	prID, oldReviewerID, targetID string,
+) (*ReassignResult, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}, idField{"old_user_id", &oldReviewerID}); err != nil {
+		return nil, err
+	}
+	if targetID != "" {
+		if err := s.cfg.IDs.normalizeFields(idField{"new_user_id", &targetID}); err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, err := s.reassignReviewerOnce(ctx, prID, oldReviewerID, targetID)
+		// Выбранную цель повторный отбор не заменит, поэтому повторяется только случайная замена
+		if !errors.Is(err, domain.ErrAlreadyAssigned) || targetID != "" || attempt == maxReassignAttempts {
+			return result, err
+		}
+
+		s.logger.Info("replacement assigned concurrently, retrying reassignment",
+			zap.String("pr_id", prID),
+			zap.String("old_reviewer", oldReviewerID),
+			zap.Int("attempt", attempt))
+	}
+}
+
+// reassignReviewerOnce выполняет одну попытку переназначения по текущему составу ревьюверов.
+// Непустой targetID заменяет случайный выбор среди кандидатов
+func (s *PullRequestService) reassignReviewerOnce(
+	ctx context.Context,
+	prID, oldReviewerID, targetID string,
+) (*ReassignResult, error) {
 	// Получаем PR
 	pr, err := s.prRepo.Get(ctx, prID)
 	if err != nil {
 		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
-		return nil, "", err
+		return nil, err
 	}
 
 	// Проверяем, что PR не смерджен
-	if pr.Status == domain.PRStatusMerged {
-		return nil, "", domain.ErrPRMerged
+	if err := guardNotMerged(pr); err != nil {
+		return nil, err
 	}
 
 	// Проверяем, что старый ревьювер назначен
-	isAssigned := false
-	for _, reviewerID := range pr.AssignedReviewers {
-		if reviewerID == oldReviewerID {
-			isAssigned = true
-			break
-		}
+	if !pr.HasReviewer(oldReviewerID) {
+		return nil, domain.ErrNotAssigned
 	}
 
-	if !isAssigned {
-		return nil, "", domain.ErrNotAssigned
+	if targetID != "" {
+		if err := checkAssignmentInvariants(pr, targetID); err != nil {
+			return nil, err
+		}
+		if pr.HasReviewer(targetID) {
+			return nil, domain.ErrAlreadyAssigned
+		}
+		if _, err := s.userRepo.Get(ctx, targetID); err != nil {
+			s.logger.Error("failed to get target reviewer", zap.Error(err), zap.String("user_id", targetID))
+			return nil, err
+		}
 	}
 
 	// Получаем старого ревьювера
 	oldReviewer, err := s.userRepo.Get(ctx, oldReviewerID)
 	if err != nil {
 		s.logger.Error("failed to get old reviewer", zap.Error(err), zap.String("reviewer_id", oldReviewerID))
-		return nil, "", err
+		return nil, err
+	}
+
+	// Замена ищется в команде старого ревьювера, а при ReassignAuthorTeamOnly - только в команде автора
+	teamName := oldReviewer.TeamName
+	if s.cfg.Features.ReassignAuthorTeamOnly {
+		author, err := s.userRepo.Get(ctx, pr.AuthorID)
+		if err != nil {
+			s.logger.Error("failed to get author", zap.Error(err), zap.String("author_id", pr.AuthorID))
+			return nil, err
+		}
+		// У автора без команды нет допустимых кандидатов
+		if author.TeamName == "" {
+			return nil, noReplacement(targetID)
+		}
+		teamName = author.TeamName
 	}
 
-	// Получаем команду старого ревьювера
-	teamMembers, err := s.userRepo.GetByTeam(ctx, oldReviewer.TeamName)
+	teamMembers, err := s.userRepo.GetByTeam(ctx, teamName)
 	if err != nil {
-		s.logger.Error("failed to get team members", zap.Error(err), zap.String("team_name", oldReviewer.TeamName))
-		return nil, "", fmt.Errorf("failed to get team members: %w", err)
+		s.logger.Error("failed to get team members", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get team members: %w", err)
 	}
 
-	// Исключаем автора и текущих ревьюверов
-	excludedIDs := make(map[string]bool)
-	excludedIDs[pr.AuthorID] = true
+	// Исключаем автора, пользователей в конфликте с ним и текущих ревьюверов
+	excluded, err := authorExclusions(ctx, s.userRepo, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
 	for _, reviewerID := range pr.AssignedReviewers {
-		excludedIDs[reviewerID] = true
+		excluded[reviewerID] = ExclusionAlreadyAssigned
 	}
 
 	// Выбираем нового ревьювера
-	candidates := make([]string, 0)
-	for _, member := range teamMembers {
-		if member.IsActive && !excludedIDs[member.UserID] {
-			candidates = append(candidates, member.UserID)
+	trace := &SelectionTrace{Considered: []string{}}
+	candidates := eligibleCandidates(teamMembers, excluded, s.cfg, trace)
+
+	// Отбрасываем кандидатов, у которых сегодня выходной по календарю команды,
+	// исчерпавших дневной или недельный лимит назначений или перегруженных ревью
+	candidates = s.applyCaps(ctx, candidates, teamMembers, trace)
+
+	// Выбранная цель должна оказаться среди кандидатов; иначе выбираем нового ревьювера
+	// согласно стратегии (pickReplacement)
+	newReviewerID := ""
+	if targetID != "" {
+		if !slices.Contains(candidates, targetID) {
+			return nil, fmt.Errorf("user %s: %w", targetID, domain.ErrTargetIneligible)
 		}
+		newReviewerID, err = pickFirstActive(ctx, s.userRepo, []string{targetID}, s.logger)
+	} else {
+		newReviewerID, err = s.pickReplacement(ctx, pr, oldReviewerID, candidates, teamMembers)
 	}
-
-	if len(candidates) == 0 {
-		return nil, "", domain.ErrNoCandidate
+	if err != nil {
+		return nil, err
+	}
+	if newReviewerID == "" {
+		return nil, noReplacement(targetID)
 	}
 
-	// Случайно выбираем нового ревьювера
-	newReviewerID := candidates[rand.Intn(len(candidates))]
+	// Запоминаем состав ревьюверов до замены
+	beforeReviewers := make([]string, len(pr.AssignedReviewers))
+	copy(beforeReviewers, pr.AssignedReviewers)
 
 	// Переназначаем ревьювера
 	if err := s.prRepo.ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID); err != nil {
@@ -180,8 +552,10 @@ func (s *PullRequestService) ReassignReviewer(
 			zap.String("pr_id", prID),
 			zap.String("old_reviewer", oldReviewerID),
 			zap.String("new_reviewer", newReviewerID))
-		return nil, "", err
+		return nil, err
 	}
+	recordReviewerHistory(ctx, s.prRepo, s.logger,
+		s.withTrace([]domain.ReviewerHistoryEntry{reassignEntry(prID, oldReviewerID, newReviewerID)}, trace)...)
 
 	s.logger.Info("reviewer reassigned",
 		zap.String("pr_id", prID),
@@ -195,76 +569,1212 @@ func (s *PullRequestService) ReassignReviewer(
 			break
 		}
 	}
+	// Признак основного ревьювера переходит к замене
+	if pr.PrimaryReviewer == oldReviewerID {
+		pr.PrimaryReviewer = newReviewerID
+	}
 
-	return pr, newReviewerID, nil
+	return &ReassignResult{
+		PR:              pr,
+		ReplacedBy:      newReviewerID,
+		BeforeReviewers: beforeReviewers,
+		AfterReviewers:  pr.AssignedReviewers,
+	}, nil
 }
 
-// GetUserReviews получает PR'ы, где пользователь назначен ревьювером
-func (s *PullRequestService) GetUserReviews(ctx context.Context, userID string) (*domain.UserPullRequests, error) {
-	// Проверяем существование пользователя
-	_, err := s.userRepo.Get(ctx, userID)
-	if err != nil {
-		// Если пользователь не найден, всё равно возвращаем пустой список
-		// (согласно спецификации, эндпоинт не должен возвращать 404)
-		if errors.Is(err, domain.ErrNotFound) {
-			return &domain.UserPullRequests{
-				UserID:       userID,
-				PullRequests: []domain.PullRequestShort{},
-			}, nil
+// noReplacement возвращает ошибку отсутствия замены: ErrNoCandidate при случайном выборе,
+// ErrTargetIneligible для явно выбранной цели targetID
+func noReplacement(targetID string) error {
+	if targetID == "" {
+		return domain.ErrNoCandidate
+	}
+	return fmt.Errorf("user %s: %w", targetID, domain.ErrTargetIneligible)
+}
+
+// pickReplacement выбирает замену ревьювера oldReviewerID среди candidates согласно стратегии,
+// перепроверяя активность. При PreferAvailable сначала среди доступных сейчас, затем среди
+// остальных. Выбор, оказавшийся заменяемым или уже назначенным ревьювером (кандидаты исключают
+// их, так что это защита от ошибки отбора), отбрасывается, и отбор повторяется без него.
+// Пустая строка, если подходящих кандидатов не осталось; ошибка, если активность
+// кандидата не удалось перепроверить
+func (s *PullRequestService) pickReplacement(
+	ctx context.Context,
+	pr *domain.PullRequest,
+	oldReviewerID string,
+	candidates []string,
+	teamMembers []domain.User,
+) (string, error) {
+	rejected := make(map[string]bool)
+	isRejected := func(userID string) bool { return rejected[userID] }
+
+	for {
+		candidates = slices.DeleteFunc(candidates, isRejected)
+		ordered := slices.DeleteFunc(s.cfg.selector().Order(ctx, candidates, teamMembers, len(candidates)), isRejected)
+
+		var (
+			pick string
+			err  error
+		)
+		if s.cfg.Features.PreferAvailable {
+			available, rest := splitByAvailability(ordered, teamMembers, s.now().Hour())
+			pick, err = pickFirstActive(ctx, s.userRepo, available, s.logger)
+			if err == nil && pick == "" {
+				pick, err = pickFirstActive(ctx, s.userRepo, rest, s.logger)
+			}
+		} else {
+			pick, err = pickFirstActive(ctx, s.userRepo, ordered, s.logger)
 		}
+		if err != nil {
+			return "", err
+		}
+
+		if pick == "" || (pick != oldReviewerID && !pr.HasReviewer(pick)) {
+			return pick, nil
+		}
+
+		s.logger.Warn("selected replacement is already a reviewer, retrying selection",
+			zap.String("pr_id", pr.PullRequestID),
+			zap.String("old_reviewer", oldReviewerID),
+			zap.String("selected", pick))
+		rejected[pick] = true
+	}
+}
+
+// ForceAssignReviewer принудительно назначает ревьювера, минуя правила отбора (команда,
+// активность, лимиты). Жёсткие инварианты (PR не смерджен, ревьювер - не автор) сохраняются.
+// Каждое принудительное назначение записывается в журнал как forced override
+func (s *PullRequestService) ForceAssignReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}, idField{"user_id", &userID}); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	if _, err := s.userRepo.Get(ctx, userID); err != nil {
 		s.logger.Error("failed to get user", zap.Error(err), zap.String("user_id", userID))
 		return nil, err
 	}
 
-	// Получаем PR'ы пользователя
-	prs, err := s.prRepo.GetByReviewer(ctx, userID)
+	if err := checkAssignmentInvariants(pr, userID); err != nil {
+		return nil, err
+	}
+
+	// Повторное назначение уже назначенного ревьювера ничего не меняет
+	if pr.HasReviewer(userID) {
+		return pr, nil
+	}
+
+	err = s.prRepo.AddReviewer(ctx, prID, userID)
+	if errors.Is(err, domain.ErrAlreadyAssigned) {
+		// Ревьювер назначен параллельным запросом между чтением PR и вставкой
+		return s.prRepo.Get(ctx, prID)
+	}
 	if err != nil {
-		s.logger.Error("failed to get user reviews", zap.Error(err), zap.String("user_id", userID))
-		return nil, fmt.Errorf("failed to get user reviews: %w", err)
+		s.logger.Error("failed to force-assign reviewer", zap.Error(err), zap.String("pr_id", prID), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to add reviewer: %w", err)
 	}
+	pr.AssignedReviewers = append(pr.AssignedReviewers, userID)
+	if pr.PrimaryReviewer == "" {
+		pr.PrimaryReviewer = userID
+	}
+	recordReviewerHistory(ctx, s.prRepo, s.logger, assignEntries(prID, []string{userID})...)
 
-	return &domain.UserPullRequests{
-		UserID:       userID,
-		PullRequests: prs,
-	}, nil
+	entry := &domain.AssignmentAuditEntry{
+		PullRequestID: prID,
+		UserID:        userID,
+		Action:        domain.AuditActionForceAssign,
+		Forced:        true,
+	}
+	if err := s.prRepo.RecordAssignmentAudit(ctx, entry); err != nil {
+		s.logger.Error("failed to record force-assign audit", zap.Error(err), zap.String("pr_id", prID), zap.String("user_id", userID))
+		return nil, err
+	}
+
+	s.logger.Warn("reviewer force-assigned", zap.String("pr_id", prID), zap.String("user_id", userID))
+
+	return pr, nil
 }
 
-// ListPullRequests возвращает список всех PR с фильтрацией
-func (s *PullRequestService) ListPullRequests(ctx context.Context, status string) ([]*domain.PullRequest, error) {
-	s.logger.Info("listing pull requests", zap.String("status", status))
+// AddReviewer вручную назначает выбранного пользователя дополнительным ревьювером открытого PR
+// (например, по решению техлида). В отличие от ForceAssignReviewer, ревьювер должен быть активен
+// и не в конфликте интересов с автором, иначе ErrTargetIneligible (ErrNoCandidate остаётся за
+// автоматическим подбором); команда и лимиты не проверяются.
+// Смердженный PR - ErrPRMerged, автор - ErrSelfReview, уже назначенный (в том числе
+// параллельным запросом) ревьювер - ErrAlreadyAssigned, неизвестные PR или пользователь - ErrNotFound
+func (s *PullRequestService) AddReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}, idField{"user_id", &userID}); err != nil {
+		return nil, err
+	}
 
-	prs, err := s.prRepo.List(ctx, status)
+	pr, err := s.prRepo.Get(ctx, prID)
 	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
 		return nil, err
 	}
 
-	s.logger.Info("pull requests listed",
-		zap.Int("count", len(prs)),
-		zap.String("status", status))
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get user", zap.Error(err), zap.String("user_id", userID))
+		return nil, err
+	}
 
-	return prs, nil
+	if err := guardNotMerged(pr); err != nil {
+		return nil, err
+	}
+	if pr.AuthorID == userID {
+		return nil, domain.ErrSelfReview
+	}
+	if pr.HasReviewer(userID) {
+		return nil, domain.ErrAlreadyAssigned
+	}
+	if !user.IsActive {
+		return nil, fmt.Errorf("user %s is inactive: %w", userID, domain.ErrTargetIneligible)
+	}
+
+	conflicts, err := s.userRepo.GetReviewConflicts(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review conflicts: %w", err)
+	}
+	if slices.Contains(conflicts, userID) {
+		return nil, fmt.Errorf("user %s has a review conflict with the author: %w", userID, domain.ErrTargetIneligible)
+	}
+
+	if err := s.prRepo.AddReviewer(ctx, prID, userID); err != nil {
+		s.logger.Error("failed to add reviewer", zap.Error(err), zap.String("pr_id", prID), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to add reviewer: %w", err)
+	}
+	recordReviewerHistory(ctx, s.prRepo, s.logger, assignEntries(prID, []string{userID})...)
+
+	s.logger.Info("reviewer added manually", zap.String("pr_id", prID), zap.String("user_id", userID))
+
+	pr, err = s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	return pr, nil
 }
 
-// selectReviewers выбирает до maxCount активных ревьюверов из команды (исключая автора)
-func (s *PullRequestService) selectReviewers(teamMembers []domain.User, authorID string, maxCount int) []string {
-	// Фильтруем активных участников (исключая автора)
-	candidates := make([]string, 0)
-	for _, member := range teamMembers {
-		if member.IsActive && member.UserID != authorID {
-			candidates = append(candidates, member.UserID)
-		}
+// RemoveReviewer снимает назначенного ревьювера с открытого PR без замены. Смердженный PR -
+// ErrPRMerged, пользователь не среди ревьюверов - ErrNotAssigned. Снятие последнего ревьювера
+// разрешено, даже если есть активные кандидаты на замену: такое снятие только логируется
+// предупреждением, а PR остаётся без ревьюверов до ручного или автоматического добора
+func (s *PullRequestService) RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}, idField{"user_id", &userID}); err != nil {
+		return nil, err
 	}
 
-	// Если кандидатов меньше или равно maxCount, возвращаем всех
-	if len(candidates) <= maxCount {
-		return candidates
+	pr, err := s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
 	}
 
-	// Случайно выбираем maxCount ревьюверов
-	// Используем алгоритм Fisher-Yates для перемешивания
-	rand.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
+	if err := guardNotMerged(pr); err != nil {
+		return nil, err
+	}
+	if !pr.HasReviewer(userID) {
+		return nil, domain.ErrNotAssigned
+	}
+
+	if len(pr.AssignedReviewers) == 1 && s.hasReplacementCandidates(ctx, pr) {
+		s.logger.Warn("removing the last reviewer while active candidates exist",
+			zap.String("pr_id", prID),
+			zap.String("user_id", userID))
+	}
+
+	if err := s.prRepo.RemoveReviewer(ctx, prID, userID); err != nil {
+		s.logger.Error("failed to remove reviewer", zap.Error(err), zap.String("pr_id", prID), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to remove reviewer: %w", err)
+	}
+	recordReviewerHistory(ctx, s.prRepo, s.logger, removeEntry(prID, userID))
+
+	s.logger.Info("reviewer removed manually", zap.String("pr_id", prID), zap.String("user_id", userID))
+
+	pr, err = s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// hasReplacementCandidates проверяет, есть ли в пуле автора активные кандидаты, которых можно
+// назначить на PR вместо текущих ревьюверов. Нужна только для предупреждения, поэтому ошибки
+// чтения логируются и считаются отсутствием кандидатов
+func (s *PullRequestService) hasReplacementCandidates(ctx context.Context, pr *domain.PullRequest) bool {
+	author, err := s.userRepo.Get(ctx, pr.AuthorID)
+	if err != nil {
+		s.logger.Warn("failed to get author", zap.Error(err), zap.String("author_id", pr.AuthorID))
+		return false
+	}
+
+	pool, _, err := s.reviewPool(ctx, author)
+	if err != nil {
+		s.logger.Warn("failed to get review pool", zap.Error(err), zap.String("pr_id", pr.PullRequestID))
+		return false
+	}
+
+	excluded, err := authorExclusions(ctx, s.userRepo, pr.AuthorID)
+	if err != nil {
+		s.logger.Warn("failed to get author exclusions", zap.Error(err), zap.String("pr_id", pr.PullRequestID))
+		return false
+	}
+	for _, reviewerID := range pr.AssignedReviewers {
+		excluded[reviewerID] = ExclusionAlreadyAssigned
+	}
+
+	return len(eligibleCandidates(pool, excluded, s.cfg, nil)) > 0
+}
+
+// SetPrimaryReviewer делает назначенного ревьювера основным (ответственным) за PR.
+// Прежний основной ревьювер остаётся дополнительным
+func (s *PullRequestService) SetPrimaryReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}, idField{"user_id", &userID}); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	if err := guardNotMerged(pr); err != nil {
+		return nil, err
+	}
+
+	if err := s.prRepo.SetPrimaryReviewer(ctx, prID, userID); err != nil {
+		if !errors.Is(err, domain.ErrNotAssigned) {
+			s.logger.Error("failed to set primary reviewer", zap.Error(err), zap.String("pr_id", prID), zap.String("user_id", userID))
+		}
+		return nil, err
+	}
+	pr.PrimaryReviewer = userID
+
+	s.logger.Info("primary reviewer set", zap.String("pr_id", prID), zap.String("user_id", userID))
+
+	return pr, nil
+}
+
+// ResetReviewers снимает всех ревьюверов открытого PR и выбирает их заново по правилам
+// автоназначения (без учёта владельцев путей). Прежние ревьюверы назначаются снова,
+// только если других кандидатов не хватает. Замена состава выполняется в одной транзакции
+func (s *PullRequestService) ResetReviewers(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	if err := guardNotMerged(pr); err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepo.Get(ctx, pr.AuthorID)
+	if err != nil {
+		s.logger.Error("failed to get author", zap.Error(err), zap.String("author_id", pr.AuthorID))
+		return nil, err
+	}
+
+	pool, source, err := s.reviewPool(ctx, author)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewerCount, err := s.poolReviewerCount(ctx, author, source)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := pr.AssignedReviewers
+	excluded, err := authorExclusions(ctx, s.userRepo, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	for _, reviewerID := range previous {
+		if _, ok := excluded[reviewerID]; !ok {
+			excluded[reviewerID] = ExclusionPrevious
+		}
+	}
+	trace := &SelectionTrace{Considered: []string{}}
+	reviewers := s.selectReviewers(ctx, pool, excluded, reviewerCount, trace)
+
+	// Недостающие слоты добираем из прежних ревьюверов (кроме исключённых по другой причине)
+	if len(reviewers) < reviewerCount {
+		for _, reviewerID := range previous {
+			if excluded[reviewerID] == ExclusionPrevious {
+				delete(excluded, reviewerID)
+			}
+		}
+		for _, reviewerID := range reviewers {
+			excluded[reviewerID] = ExclusionAlreadyAssigned
+		}
+		reviewers = append(reviewers, s.selectReviewers(ctx, pool, excluded, reviewerCount-len(reviewers), trace)...)
+	}
+	if source == "" {
+		reviewers, err = s.fillFromBackupTeam(ctx, author.TeamName, reviewers, excluded, reviewerCount, trace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.prRepo.ReplaceReviewers(ctx, prID, reviewers); err != nil {
+		s.logger.Error("failed to replace reviewers", zap.Error(err), zap.String("pr_id", prID))
+		return nil, fmt.Errorf("failed to replace reviewers: %w", err)
+	}
+
+	pr.AssignedReviewers = reviewers
+	pr.PrimaryReviewer = ""
+	if len(reviewers) > 0 {
+		pr.PrimaryReviewer = reviewers[0]
+	}
+	recordReviewerHistory(ctx, s.prRepo, s.logger, s.withTrace(resetEntries(prID, previous, reviewers), trace)...)
+
+	s.logger.Info("reviewers reset",
+		zap.String("pr_id", prID),
+		zap.Strings("before", previous),
+		zap.Strings("after", reviewers))
+
+	return pr, nil
+}
+
+// GetUserReviews получает PR'ы (в пределах окна page), где пользователь назначен ревьювером,
+// и общее их число для постраничного обхода
+func (s *PullRequestService) GetUserReviews(ctx context.Context, userID string, page domain.Page) (*domain.UserPullRequests, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"user_id", &userID}); err != nil {
+		return nil, err
+	}
+
+	// Проверяем существование пользователя
+	_, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		// Если пользователь не найден, всё равно возвращаем пустой список
+		// (согласно спецификации, эндпоинт не должен возвращать 404)
+		if errors.Is(err, domain.ErrNotFound) {
+			return &domain.UserPullRequests{
+				UserID:       userID,
+				PullRequests: []domain.PullRequestShort{},
+				Limit:        page.Limit,
+				Offset:       page.Offset,
+			}, nil
+		}
+		s.logger.Error("failed to get user", zap.Error(err), zap.String("user_id", userID))
+		return nil, err
+	}
+
+	// Получаем PR'ы пользователя
+	prs, err := s.prRepo.GetByReviewer(ctx, userID, page)
+	if err != nil {
+		s.logger.Error("failed to get user reviews", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get user reviews: %w", err)
+	}
+
+	total, err := s.prRepo.CountByReviewer(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to count user reviews", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to count user reviews: %w", err)
+	}
+
+	return &domain.UserPullRequests{
+		UserID:       userID,
+		PullRequests: prs,
+		Total:        total,
+		Limit:        page.Limit,
+		Offset:       page.Offset,
+	}, nil
+}
+
+// GetPrimaryReviews получает PR'ы, где пользователь назначен основным ревьювером:
+// сначала открытые, затем от новых к старым. Для неизвестного пользователя, как
+// и в GetUserReviews, возвращается пустой список
+func (s *PullRequestService) GetPrimaryReviews(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"user_id", &userID}); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.Get(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return []domain.PullRequestShort{}, nil
+		}
+		s.logger.Error("failed to get user", zap.Error(err), zap.String("user_id", userID))
+		return nil, err
+	}
+
+	prs, err := s.prRepo.GetByPrimaryReviewer(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get primary reviews", zap.Error(err), zap.String("user_id", userID))
+		return nil, fmt.Errorf("failed to get primary reviews: %w", err)
+	}
+
+	return prs, nil
+}
+
+// ListPullRequests возвращает список PR с любым из статусов statuses (пустой - все)
+// в пределах окна page
+func (s *PullRequestService) ListPullRequests(ctx context.Context, statuses []string, page domain.Page) ([]*domain.PullRequest, error) {
+	s.logger.Info("listing pull requests",
+		zap.Strings("statuses", statuses),
+		zap.Int("limit", page.Limit),
+		zap.Int("offset", page.Offset))
+
+	prs, err := s.prRepo.List(ctx, statuses, page)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("pull requests listed",
+		zap.Int("count", len(prs)),
+		zap.Strings("statuses", statuses))
+
+	return prs, nil
+}
+
+// StreamPullRequests передаёт PR с фильтрацией по статусам в fn по одному, без буферизации списка
+func (s *PullRequestService) StreamPullRequests(
+	ctx context.Context,
+	statuses []string,
+	fn func(pr *domain.PullRequest) error,
+) error {
+	s.logger.Info("streaming pull requests", zap.Strings("statuses", statuses))
+
+	count := 0
+	err := s.prRepo.StreamList(ctx, statuses, func(pr *domain.PullRequest) error {
+		count++
+		return fn(pr)
 	})
+	if err != nil {
+		s.logger.Error("failed to stream pull requests", zap.Error(err), zap.Int("streamed", count))
+		return err
+	}
+
+	s.logger.Info("pull requests streamed",
+		zap.Int("count", count),
+		zap.Strings("statuses", statuses))
+
+	return nil
+}
+
+// ExplainAssignment объясняет назначение ревьюверов PR по трассировке, записанной в историю
+// ревьюверов в момент автоотбора (создание PR, замена, сброс), а не по текущему составу
+// команды. Для каждого ревьювера возвращает рассмотренных кандидатов, а при включённом
+// DebugTrace - и причины исключения. У ревьювера, назначенного без автоотбора, Traced = false
+func (s *PullRequestService) ExplainAssignment(ctx context.Context, prID string) (*AssignmentExplanation, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	author, err := s.userRepo.Get(ctx, pr.AuthorID)
+	if err != nil {
+		s.logger.Error("failed to get author", zap.Error(err), zap.String("author_id", pr.AuthorID))
+		return nil, err
+	}
+
+	history, err := s.prRepo.GetReviewerHistory(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get reviewer history", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	explanation := &AssignmentExplanation{
+		PullRequestID: pr.PullRequestID,
+		AuthorID:      pr.AuthorID,
+		TeamName:      author.TeamName,
+		Reviewers:     make([]ReviewerTrace, 0, len(pr.AssignedReviewers)),
+	}
+
+	for _, reviewerID := range pr.AssignedReviewers {
+		reviewer := ReviewerTrace{ReviewerID: reviewerID, SelectionTrace: SelectionTrace{Considered: []string{}}}
+
+		if data := assignmentTrace(history, reviewerID); len(data) > 0 {
+			if err := json.Unmarshal(data, &reviewer.SelectionTrace); err != nil {
+				return nil, fmt.Errorf("failed to decode selection trace of reviewer %s: %w", reviewerID, err)
+			}
+			reviewer.Traced = true
+		}
+		if !s.cfg.Features.DebugTrace {
+			reviewer.Excluded = nil
+		}
+
+		explanation.Reviewers = append(explanation.Reviewers, reviewer)
+	}
+
+	return explanation, nil
+}
+
+// assignmentTrace возвращает трассировку отбора из последнего события истории, которым
+// ревьювер был добавлен в PR (назначение, замена на него или отмена замены).
+// Пусто, если трассировка при этом не записывалась
+func assignmentTrace(history []domain.ReviewerHistoryEntry, reviewerID string) json.RawMessage {
+	for i := len(history) - 1; i >= 0; i-- {
+		entry := history[i]
+		switch {
+		case entry.Action == domain.ReviewerActionAssign && entry.UserID == reviewerID,
+			entry.Action == domain.ReviewerActionReassign && entry.ReplacedBy == reviewerID,
+			entry.Action == domain.ReviewerActionUndo && entry.ReplacedBy == reviewerID:
+			return entry.SelectionTrace
+		}
+	}
+	return nil
+}
+
+// EligibleReviewers возвращает пользователей, которых можно назначить ревьювером открытого PR:
+// активных участников пула автора, кроме автора и текущих ревьюверов, по тем же правилам,
+// что и при замене. Результат отсортирован по username; пустой список, если таких нет
+func (s *PullRequestService) EligibleReviewers(ctx context.Context, prID string) ([]EligibleReviewer, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	if err := guardNotMerged(pr); err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepo.Get(ctx, pr.AuthorID)
+	if err != nil {
+		s.logger.Error("failed to get author", zap.Error(err), zap.String("author_id", pr.AuthorID))
+		return nil, err
+	}
+
+	pool, _, err := s.reviewPool(ctx, author)
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make(map[string]string, len(pool))
+	for _, member := range pool {
+		usernames[member.UserID] = member.Username
+	}
+
+	conflicts, err := s.userRepo.GetReviewConflicts(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review conflicts: %w", err)
+	}
+
+	candidates := s.cfg.filterReassignCandidates(pool, pr.AuthorID, pr.AssignedReviewers, conflicts, "")
+	reviewers := make([]EligibleReviewer, 0, len(candidates))
+	for _, userID := range candidates {
+		reviewers = append(reviewers, EligibleReviewer{UserID: userID, Username: usernames[userID]})
+	}
+
+	sort.Slice(reviewers, func(i, j int) bool {
+		if reviewers[i].Username != reviewers[j].Username {
+			return reviewers[i].Username < reviewers[j].Username
+		}
+		return reviewers[i].UserID < reviewers[j].UserID
+	})
+
+	return reviewers, nil
+}
+
+// SLABreaches возвращает открытые PR, самое раннее назначение ревьювера которых старше
+// ReviewSLA, от самых давних. Если SLA не задан, список пуст
+func (s *PullRequestService) SLABreaches(ctx context.Context) ([]domain.SLABreach, error) {
+	if s.cfg.ReviewSLA <= 0 {
+		return []domain.SLABreach{}, nil
+	}
+
+	now := s.now()
+	breaches, err := s.prRepo.GetOpenAssignedBefore(ctx, now.Add(-s.cfg.ReviewSLA))
+	if err != nil {
+		s.logger.Error("failed to get SLA breaches", zap.Error(err))
+		return nil, err
+	}
+
+	for i := range breaches {
+		breached := now.Sub(breaches[i].OldestAssignedAt) - s.cfg.ReviewSLA
+		breaches[i].BreachedHours = math.Round(breached.Hours()*100) / 100
+	}
+
+	return breaches, nil
+}
+
+// reviewPool возвращает пул кандидатов для автора: участников его группы ревью,
+// а если автор не состоит в группе - всю команду. Для автора без команды пул
+// определяет externalPool, source содержит его источник (для остальных - пусто)
+func (s *PullRequestService) reviewPool(ctx context.Context, author *domain.User) ([]domain.User, string, error) {
+	if author.TeamName == "" {
+		return s.externalPool(ctx)
+	}
+
+	if author.ReviewGroup != "" {
+		members, err := s.userRepo.GetByReviewGroup(ctx, author.TeamName, author.ReviewGroup)
+		if err != nil {
+			s.logger.Error("failed to get review group members",
+				zap.Error(err),
+				zap.String("team_name", author.TeamName),
+				zap.String("review_group", author.ReviewGroup))
+			return nil, "", fmt.Errorf("failed to get review group members: %w", err)
+		}
+		return members, "", nil
+	}
+
+	members, err := s.userRepo.GetByTeam(ctx, author.TeamName)
+	if err != nil {
+		s.logger.Error("failed to get team members", zap.Error(err), zap.String("team_name", author.TeamName))
+		return nil, "", fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	return members, "", nil
+}
+
+// explicitPool возвращает пользователей явно переданного пула кандидатов (без повторов).
+// Все они должны существовать: неизвестный пользователь - ErrNotFound. Активность и прочие
+// правила отбора проверяются позже, как для пула команды
+func (s *PullRequestService) explicitPool(ctx context.Context, candidatePool []string) ([]domain.User, error) {
+	seen := make(map[string]bool, len(candidatePool))
+	members := make([]domain.User, 0, len(candidatePool))
+	for _, userID := range candidatePool {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		user, err := s.userRepo.Get(ctx, userID)
+		if err != nil {
+			s.logger.Error("failed to get candidate pool member", zap.Error(err), zap.String("user_id", userID))
+			return nil, fmt.Errorf("candidate_pool member %s: %w", userID, err)
+		}
+		members = append(members, *user)
+	}
+
+	return members, nil
+}
+
+// withinPool оставляет из userIDs только участников пула members, сохраняя порядок
+func withinPool(userIDs []string, members []domain.User) []string {
+	inPool := make(map[string]bool, len(members))
+	for _, member := range members {
+		inPool[member.UserID] = true
+	}
+
+	filtered := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if inPool[userID] {
+			filtered = append(filtered, userID)
+		}
+	}
+	return filtered
+}
+
+// externalPool возвращает пул кандидатов для автора без команды (внешнего контрибьютора):
+// участников ExternalReviewerTeam, а если она не задана или пуста - активных
+// пользователей всех команд
+func (s *PullRequestService) externalPool(ctx context.Context) ([]domain.User, string, error) {
+	if s.cfg.ExternalReviewerTeam != "" {
+		members, err := s.userRepo.GetByTeam(ctx, s.cfg.ExternalReviewerTeam)
+		if err != nil {
+			s.logger.Error("failed to get external reviewer team members",
+				zap.Error(err), zap.String("team_name", s.cfg.ExternalReviewerTeam))
+			return nil, "", fmt.Errorf("failed to get team members: %w", err)
+		}
+		if len(members) > 0 {
+			return members, domain.ReviewerSourceDefaultTeam, nil
+		}
+		s.logger.Warn("external reviewer team has no members, using global pool",
+			zap.String("team_name", s.cfg.ExternalReviewerTeam))
+	}
+
+	// Пользователи без команды (сами внешние контрибьюторы) в пул не попадают
+	members, err := s.userRepo.GetActiveUsersExcludingTeam(ctx, "")
+	if err != nil {
+		s.logger.Error("failed to get active users", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to get active users: %w", err)
+	}
+
+	return members, domain.ReviewerSourceGlobalPool, nil
+}
+
+// fillFromBackupTeam добирает ревьюверов до count из резервной команды teamName,
+// если в самой команде кандидатов не хватило. excluded дополняется выбранными,
+// отбор из резервной команды записывается в trace (может быть nil)
+func (s *PullRequestService) fillFromBackupTeam(
+	ctx context.Context,
+	teamName string,
+	reviewers []string,
+	excluded map[string]ExclusionReason,
+	count int,
+	trace *SelectionTrace,
+) ([]string, error) {
+	if len(reviewers) >= count {
+		return reviewers, nil
+	}
+
+	backupTeam, err := s.teamRepo.GetBackupTeam(ctx, teamName)
+	if err != nil {
+		s.logger.Error("failed to get backup team", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get backup team: %w", err)
+	}
+	if backupTeam == "" {
+		return reviewers, nil
+	}
+
+	members, err := s.userRepo.GetByTeam(ctx, backupTeam)
+	if err != nil {
+		s.logger.Error("failed to get backup team members", zap.Error(err), zap.String("team_name", backupTeam))
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	for _, reviewerID := range reviewers {
+		excluded[reviewerID] = ExclusionAlreadyAssigned
+	}
+	borrowed := s.selectReviewers(ctx, members, excluded, count-len(reviewers), trace)
+	if len(borrowed) > 0 {
+		s.logger.Info("reviewers borrowed from backup team",
+			zap.String("team_name", teamName),
+			zap.String("backup_team_name", backupTeam),
+			zap.Strings("reviewers", borrowed))
+	}
+
+	return append(reviewers, borrowed...), nil
+}
+
+// poolReviewerCount возвращает число ревьюверов на PR автора с учётом источника пула:
+// для команды по умолчанию действует её настройка, а не команды автора
+func (s *PullRequestService) poolReviewerCount(ctx context.Context, author *domain.User, source string) (int, error) {
+	teamName := author.TeamName
+	if source == domain.ReviewerSourceDefaultTeam {
+		teamName = s.cfg.ExternalReviewerTeam
+	}
+	return s.reviewerCount(ctx, teamName)
+}
+
+// reviewerCount возвращает число ревьюверов на PR команды teamName
+// (ReviewersPerPR из политики ревьюверов, если команде оно не задано или команды нет)
+func (s *PullRequestService) reviewerCount(ctx context.Context, teamName string) (int, error) {
+	if teamName == "" {
+		return s.policy.reviewersPerPR(), nil
+	}
+
+	count, err := s.teamRepo.GetReviewerCount(ctx, teamName)
+	if err != nil {
+		s.logger.Error("failed to get team reviewer count", zap.Error(err), zap.String("team_name", teamName))
+		return 0, fmt.Errorf("failed to get team reviewer count: %w", err)
+	}
+
+	if count <= 0 {
+		return s.policy.reviewersPerPR(), nil
+	}
+
+	return count, nil
+}
+
+// teamBelowMinSize проверяет, что в команде автора меньше активных участников, чем MinTeamSize
+func (s *PullRequestService) teamBelowMinSize(ctx context.Context, author *domain.User) (bool, error) {
+	// Автор без команды получает ревьюверов из внешнего пула, размер команды не важен
+	if s.cfg.MinTeamSize <= 0 || author.TeamName == "" {
+		return false, nil
+	}
+
+	members, err := s.userRepo.GetByTeam(ctx, author.TeamName)
+	if err != nil {
+		s.logger.Error("failed to get team members", zap.Error(err), zap.String("team_name", author.TeamName))
+		return false, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	active := 0
+	for _, member := range members {
+		if member.IsActive {
+			active++
+		}
+	}
+
+	return active < s.cfg.MinTeamSize, nil
+}
+
+// selectCodeOwners выбирает до maxCount активных владельцев путей paths (исключая автора).
+// Владельцы более специфичных (длинных) префиксов имеют приоритет. Владелец, которого нет
+// среди пользователей, пропускается; прочие ошибки чтения пользователя возвращаются
+func (s *PullRequestService) selectCodeOwners(
+	ctx context.Context,
+	paths []string,
+	excluded map[string]ExclusionReason,
+	maxCount int,
+) ([]string, error) {
+	ownerIDs := matchCodeOwners(s.cfg.CodeOwners, paths)
+	if len(ownerIDs) == 0 {
+		return []string{}, nil
+	}
+
+	owners := make([]string, 0, maxCount)
+	for _, ownerID := range ownerIDs {
+		if len(owners) == maxCount {
+			break
+		}
+		if _, ok := excluded[ownerID]; ok {
+			continue
+		}
+
+		owner, err := s.userRepo.Get(ctx, ownerID)
+		if errors.Is(err, domain.ErrNotFound) {
+			s.logger.Warn("code owner not found, skipping", zap.String("user_id", ownerID))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get code owner %s: %w", ownerID, err)
+		}
+		if !owner.IsActive || s.cfg.excludesUsername(owner.Username) {
+			continue
+		}
+
+		owners = append(owners, ownerID)
+	}
+
+	s.selectionLog.Debug("code owners selected",
+		zap.Strings("paths", paths),
+		zap.Strings("matched_owners", ownerIDs),
+		zap.Strings("selected", owners))
+
+	return owners, nil
+}
+
+// applyCaps отбрасывает кандидатов с выходным по календарю команды и исчерпавших дневной
+// или недельный лимит назначений либо лимит незавершённых ревью. Отброшенные записываются
+// в trace (может быть nil) с причинами day_off и capped
+func (s *PullRequestService) applyCaps(
+	ctx context.Context,
+	candidates []string,
+	teamMembers []domain.User,
+	trace *SelectionTrace,
+) []string {
+	working := s.applyCalendar(ctx, candidates, teamMembers)
+	trace.drop(candidates, working, ExclusionDayOff)
+
+	capped := s.applyDailyLimit(ctx, working, teamMembers)
+	capped = s.applyWeeklyLimit(ctx, capped)
+	capped = s.applyPendingLimit(ctx, capped)
+	trace.drop(working, capped, ExclusionCapped)
+
+	return capped
+}
+
+// applyDailyLimit отбрасывает кандидатов, исчерпавших дневной лимит назначений.
+// Если лимит исчерпан у всех, остаются кандидаты с наименьшим превышением.
+// При ошибке подсчёта назначений лимит не применяется
+func (s *PullRequestService) applyDailyLimit(ctx context.Context, candidates []string, members []domain.User) []string {
+	limits := dailyLimits(members, s.cfg.MaxDailyAssignments)
+	if len(limits) == 0 || len(candidates) == 0 {
+		return candidates
+	}
+
+	counts, err := s.prRepo.GetAssignmentCountsSince(ctx, startOfDay(s.now()))
+	if err != nil {
+		s.logger.Warn("failed to get daily assignment counts, limit not applied", zap.Error(err))
+		return candidates
+	}
+
+	filtered := withinLimit(candidates, limits, counts)
+	s.selectionLog.Debug("daily limit applied",
+		zap.Strings("candidates", candidates),
+		zap.Strings("within_limit", filtered))
+
+	return filtered
+}
+
+// applyWeeklyLimit отбрасывает кандидатов, получивших с начала недели MaxWeeklyAssignments
+// назначений. Применяется после дневного лимита, поэтому кандидат должен укладываться в оба.
+// Если лимит исчерпан у всех, остаются кандидаты с наименьшим превышением.
+// При ошибке подсчёта назначений лимит не применяется
+func (s *PullRequestService) applyWeeklyLimit(ctx context.Context, candidates []string) []string {
+	if s.cfg.MaxWeeklyAssignments <= 0 || len(candidates) == 0 {
+		return candidates
+	}
+
+	counts, err := s.prRepo.GetAssignmentCountsSince(ctx, startOfWeek(s.now()))
+	if err != nil {
+		s.logger.Warn("failed to get weekly assignment counts, limit not applied", zap.Error(err))
+		return candidates
+	}
+
+	limits := make(map[string]int, len(candidates))
+	for _, userID := range candidates {
+		limits[userID] = s.cfg.MaxWeeklyAssignments
+	}
+
+	filtered := withinLimit(candidates, limits, counts)
+	s.selectionLog.Debug("weekly limit applied",
+		zap.Strings("candidates", candidates),
+		zap.Strings("within_limit", filtered))
+
+	return filtered
+}
+
+// applyCalendar отбрасывает кандидатов, у команды которых сегодня (по времени сервиса)
+// нерабочий день или праздник. Если выходной у всех, кандидаты остаются без изменений,
+// чтобы PR не остался без ревьюверов. При ошибке чтения календарей фильтр не применяется
+func (s *PullRequestService) applyCalendar(ctx context.Context, candidates []string, teamMembers []domain.User) []string {
+	if len(candidates) == 0 {
+		return candidates
+	}
+
+	teams := make(map[string]string, len(teamMembers))
+	teamNames := make([]string, 0)
+	for _, member := range teamMembers {
+		teams[member.UserID] = member.TeamName
+		if member.TeamName != "" && !slices.Contains(teamNames, member.TeamName) {
+			teamNames = append(teamNames, member.TeamName)
+		}
+	}
+
+	calendars, err := s.teamRepo.GetCalendars(ctx, teamNames)
+	if err != nil {
+		s.logger.Warn("failed to get team calendars, calendar not applied", zap.Error(err))
+		return candidates
+	}
+	if len(calendars) == 0 {
+		return candidates
+	}
+
+	today := s.now()
+	working := make([]string, 0, len(candidates))
+	for _, userID := range candidates {
+		if calendar, ok := calendars[teams[userID]]; !ok || calendar.IsWorkingDay(today) {
+			working = append(working, userID)
+		}
+	}
+
+	s.selectionLog.Debug("team calendar applied",
+		zap.Strings("candidates", candidates),
+		zap.Strings("working", working))
+
+	if len(working) == 0 {
+		s.logger.Info("all candidates are off today, ignoring team calendar",
+			zap.Strings("candidates", candidates))
+		return candidates
+	}
+
+	return working
+}
+
+// applyPendingLimit отбрасывает кандидатов, у которых больше MaxPendingReviews незавершённых
+// ревью: они и так задерживают чужие PR. Если превышение у всех, остаются кандидаты с
+// наименьшим превышением. При ошибке подсчёта ограничение не применяется
+func (s *PullRequestService) applyPendingLimit(ctx context.Context, candidates []string) []string {
+	if s.cfg.MaxPendingReviews <= 0 || len(candidates) == 0 {
+		return candidates
+	}
+
+	counts, err := s.prRepo.CountOpenByUsers(ctx, candidates)
+	if err != nil {
+		s.logger.Warn("failed to get pending review counts, limit not applied", zap.Error(err))
+		return candidates
+	}
+
+	// "Больше N" означает, что проходят кандидаты с числом меньше N+1
+	limits := make(map[string]int, len(candidates))
+	for _, userID := range candidates {
+		limits[userID] = s.cfg.MaxPendingReviews + 1
+	}
+
+	filtered := withinLimit(candidates, limits, counts)
+	s.selectionLog.Debug("pending review limit applied",
+		zap.Strings("candidates", candidates),
+		zap.Strings("within_limit", filtered))
+
+	return filtered
+}
+
+// applyFreshPair ставит первыми пару кандидатов, реже всего назначавшуюся вместе на последние
+// PR автора (AVOID_REPEAT_REVIEWER_PAIRS). При DiverseReviewGroups пары из одной группы
+// рассматриваются, только если других пар нет. Ошибка получения истории не прерывает отбор
+func (s *PullRequestService) applyFreshPair(
+	ctx context.Context,
+	candidates []string,
+	members []domain.User,
+	authorID string,
+) []string {
+	if authorID == "" {
+		return candidates
+	}
+
+	pairCounts, err := s.prRepo.GetReviewerPairCounts(ctx, authorID, recentPairPRs)
+	if err != nil {
+		s.logger.Warn("failed to get reviewer pair counts, pairing not applied", zap.Error(err), zap.String("author_id", authorID))
+		return candidates
+	}
+
+	anyPair := func(_, _ string) bool { return true }
+	allowed := anyPair
+	if s.cfg.Features.DiverseReviewGroups {
+		groups := make(map[string]string, len(members))
+		for _, member := range members {
+			groups[member.UserID] = member.ReviewGroup
+		}
+		// diversifyByGroup уже поставил первыми кандидатов из разных групп: если первые двое
+		// из одной группы, альтернатив нет и ограничение не действует
+		if group := groups[candidates[0]]; group == "" || group != groups[candidates[1]] {
+			allowed = func(a, b string) bool {
+				return groups[a] == "" || groups[a] != groups[b]
+			}
+		}
+	}
+
+	ordered := preferFreshPair(candidates, pairCounts, allowed)
+	s.selectionLog.Debug("fresh reviewer pair preferred",
+		zap.String("author_id", authorID),
+		zap.Strings("pair", ordered[:2]),
+		zap.Int("pair_count", pairCounts[domain.NewReviewerPair(ordered[0], ordered[1])]))
+
+	return ordered
+}
+
+// applyReciprocal переносит в конец кандидатов, чей последний PR ревьюил автор
+// (AVOID_RECIPROCAL_REVIEWS): они занимают слоты, только если остальных не хватает.
+// Относительный порядок внутри обеих частей сохраняется. Ошибка получения истории не прерывает отбор
+func (s *PullRequestService) applyReciprocal(ctx context.Context, candidates []string, authorID string) []string {
+	if authorID == "" {
+		return candidates
+	}
+
+	reciprocal, err := s.prRepo.GetReciprocalReviewers(ctx, authorID, candidates)
+	if err != nil {
+		s.logger.Warn("failed to get reciprocal reviewers, rule not applied", zap.Error(err), zap.String("author_id", authorID))
+		return candidates
+	}
+	if len(reciprocal) == 0 {
+		return candidates
+	}
+
+	ordered := make([]string, 0, len(candidates))
+	deferred := make([]string, 0, len(reciprocal))
+	for _, userID := range candidates {
+		if slices.Contains(reciprocal, userID) {
+			deferred = append(deferred, userID)
+		} else {
+			ordered = append(ordered, userID)
+		}
+	}
+	s.selectionLog.Debug("reciprocal reviewers deprioritized",
+		zap.String("author_id", authorID),
+		zap.Strings("reciprocal", deferred))
+
+	return append(ordered, deferred...)
+}
+
+// selectionWarnings возвращает некритичные замечания к отобранным ревьюверам: нехватку
+// кандидатов и ревьюверов, которые этим назначением исчерпывают дневной лимит
+// (команда близка к пределу нагрузки). Создание PR они не прерывают
+func (s *PullRequestService) selectionWarnings(
+	ctx context.Context,
+	reviewers []string,
+	reviewerCount int,
+	members []domain.User,
+) []string {
+	var warnings []string
+
+	switch {
+	case len(reviewers) >= reviewerCount:
+	case len(reviewers) == 0:
+		warnings = append(warnings, "no reviewers available")
+	case len(reviewers) == 1:
+		warnings = append(warnings, "only one reviewer available")
+	default:
+		warnings = append(warnings, fmt.Sprintf("only %d of %d reviewers available", len(reviewers), reviewerCount))
+	}
+
+	limits := dailyLimits(members, s.cfg.MaxDailyAssignments)
+	if len(limits) == 0 || len(reviewers) == 0 {
+		return warnings
+	}
+
+	counts, err := s.prRepo.GetAssignmentCountsSince(ctx, startOfDay(s.now()))
+	if err != nil {
+		s.logger.Warn("failed to get daily assignment counts for warnings", zap.Error(err))
+		return warnings
+	}
+
+	for _, reviewerID := range reviewers {
+		if limit, ok := limits[reviewerID]; ok && counts[reviewerID]+1 >= limit {
+			warnings = append(warnings, fmt.Sprintf("reviewer %s has reached the daily assignment limit", reviewerID))
+		}
+	}
+
+	return warnings
+}
+
+// selectReviewers выбирает до maxCount активных ревьюверов из команды, пропуская excluded,
+// участников с выходным по календарю команды и исчерпавших лимиты назначений.
+// Если trace не nil, в него записываются рассмотренные и исключённые кандидаты
+func (s *PullRequestService) selectReviewers(
+	ctx context.Context,
+	teamMembers []domain.User,
+	excluded map[string]ExclusionReason,
+	maxCount int,
+	trace *SelectionTrace,
+) []string {
+	if maxCount <= 0 {
+		return []string{}
+	}
+
+	// Фильтруем активных участников (исключая автора и уже выбранных)
+	candidates := eligibleCandidates(teamMembers, excluded, s.cfg, trace)
+	candidates = s.applyCaps(ctx, candidates, teamMembers, trace)
+
+	// Если кандидатов меньше или равно maxCount, возвращаем всех в стабильном порядке
+	// (по user_id): выбирать не из чего, и результат не должен зависеть от порядка участников
+	if len(candidates) <= maxCount {
+		sort.Strings(candidates)
+		return candidates
+	}
+
+	// Упорядочиваем только когда нужно отсечь лишних кандидатов:
+	// стратегия (REVIEWER_STRATEGY) определяет, кто займёт maxCount слотов.
+	// Без дальнейших перестановок достаточно упорядочить первые maxCount позиций,
+	// что для большого пула (cross-team) намного дешевле полного перемешивания
+	ordered := maxCount
+	if s.cfg.Features.PreferAvailable || s.cfg.Features.DiverseReviewGroups || s.cfg.Features.RequireSenior ||
+		s.cfg.Features.AvoidReciprocal || (s.cfg.Features.AvoidRepeatPairs && maxCount == 2) {
+		ordered = len(candidates)
+	}
+	candidates = s.cfg.selector().Order(ctx, candidates, teamMembers, ordered)
+
+	// Взаимное ревью - самое слабое предпочтение: следующие правила могут его перекрыть
+	if s.cfg.Features.AvoidReciprocal {
+		candidates = s.applyReciprocal(ctx, candidates, excludedAuthor(excluded))
+	}
+
+	// Доступные сейчас кандидаты занимают слоты первыми
+	if s.cfg.Features.PreferAvailable {
+		available, rest := splitByAvailability(candidates, teamMembers, s.now().Hour())
+		candidates = append(available, rest...)
+	}
+
+	// Разные группы ревью важнее доступности: сначала по одному кандидату на группу
+	if s.cfg.Features.DiverseReviewGroups {
+		candidates = diversifyByGroup(candidates, teamMembers)
+	}
+
+	// Из пар, не нарушающих разнообразие групп, выбираем реже всего назначавшуюся вместе
+	if s.cfg.Features.AvoidRepeatPairs && maxCount == 2 {
+		candidates = s.applyFreshPair(ctx, candidates, teamMembers, excludedAuthor(excluded))
+	}
+
+	selected := candidates[:maxCount]
+	if s.cfg.Features.RequireSenior && maxCount > 0 {
+		ensureSenior(selected, candidates[maxCount:], teamMembers)
+	}
 
-	return candidates[:maxCount]
+	return selected
 }
@@ -2,15 +2,34 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"reviewservice/internal/domain"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 	"reviewservice/internal/testutil"
 )
 
+// newTestService creates a PullRequestService over the given mocks with an empty team
+// repository, no transaction manager and the default reviewer policy
+func newTestService(prRepo *testutil.MockPRRepository, userRepo *testutil.MockUserRepository, cfg AssignmentConfig) *PullRequestService {
+	return NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), nil, ReviewerPolicy{}, cfg, zap.NewNop())
+}
+
+// newTeamRepos creates mocks holding active members of teamName
+func newTeamRepos(teamName string, userIDs ...string) (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.AddTeamMembers(teamName, userIDs...)
+	return testutil.NewMockPRRepository(), userRepo
+}
+
 // TestPullRequestService_CreatePullRequest tests PR creation scenarios
 func TestPullRequestService_CreatePullRequest(t *testing.T) {
 	tests := []struct {
@@ -118,10 +137,10 @@ func TestPullRequestService_CreatePullRequest(t *testing.T) {
 			tt.setupMocks(prRepo, userRepo)
 
 			logger := zap.NewNop()
-			svc := NewPullRequestService(prRepo, userRepo, logger)
+			svc := NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), nil, ReviewerPolicy{}, AssignmentConfig{}, logger)
 
 			// Act
-			pr, err := svc.CreatePullRequest(context.Background(), tt.prID, tt.prName, tt.authorID)
+			pr, err := svc.CreatePullRequest(context.Background(), tt.prID, tt.prName, tt.authorID, nil, nil, "")
 
 			// Assert
 			if tt.wantErr != nil {
@@ -188,7 +207,7 @@ func TestPullRequestService_MergePullRequest(t *testing.T) {
 			tt.setupMocks(prRepo)
 
 			logger := zap.NewNop()
-			svc := NewPullRequestService(prRepo, userRepo, logger)
+			svc := NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), nil, ReviewerPolicy{}, AssignmentConfig{}, logger)
 
 			// Act
 			pr, err := svc.MergePullRequest(context.Background(), tt.prID)
@@ -206,6 +225,221 @@ func TestPullRequestService_MergePullRequest(t *testing.T) {
 	}
 }
 
+// TestPullRequestService_MergePullRequest_BlockWithoutReviewers tests that BlockMergeWithoutReviewers
+// rejects merging an open PR with no reviewers but keeps re-merges idempotent
+func TestPullRequestService_MergePullRequest_BlockWithoutReviewers(t *testing.T) {
+	tests := []struct {
+		name    string
+		block   bool
+		pr      *domain.PullRequest
+		wantErr error
+	}{
+		{
+			name:    "blocks open PR without reviewers",
+			block:   true,
+			pr:      &domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusOpen},
+			wantErr: domain.ErrNoReviewers,
+		},
+		{
+			name:  "allows open PR with reviewers",
+			block: true,
+			pr:    &domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}},
+		},
+		{
+			name:  "allows open PR without reviewers when flag is off",
+			block: false,
+			pr:    &domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusOpen},
+		},
+		{
+			name:  "re-merge of merged PR without reviewers stays idempotent",
+			block: true,
+			pr:    &domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusMerged},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := testutil.NewMockPRRepository()
+			prRepo.PRs["pr-1"] = tt.pr
+			cfg := AssignmentConfig{BlockMergeWithoutReviewers: tt.block}
+			svc := newTestService(prRepo, testutil.NewMockUserRepository(), cfg)
+
+			pr, err := svc.MergePullRequest(context.Background(), "pr-1")
+
+			if tt.wantErr != nil {
+				testutil.AssertErrorIs(t, err, tt.wantErr)
+				testutil.AssertEqual(t, prRepo.PRs["pr-1"].Status, domain.PRStatusOpen, "Status after blocked merge")
+				return
+			}
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, pr.Status, domain.PRStatusMerged, "Status after merge")
+		})
+	}
+}
+
+// TestPullRequestService_CreatePullRequest_AuthorPRLimit tests that MaxOpenPRsPerAuthor rejects
+// an author already at the limit, counting only their open PRs
+func TestPullRequestService_CreatePullRequest_AuthorPRLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   int
+		open    int
+		wantErr bool
+	}{
+		{name: "under the limit is allowed", limit: 2, open: 1},
+		{name: "at the limit is rejected", limit: 2, open: 2, wantErr: true},
+		{name: "unlimited by default", limit: 0, open: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo, userRepo := newTeamRepos("backend", "u1", "u2")
+			for i := 0; i < tt.open; i++ {
+				id := fmt.Sprintf("open-%d", i)
+				prRepo.PRs[id] = &domain.PullRequest{PullRequestID: id, AuthorID: "u1", Status: domain.PRStatusOpen}
+			}
+			// Merged PRs and other authors' PRs do not count towards the limit
+			prRepo.PRs["merged"] = &domain.PullRequest{PullRequestID: "merged", AuthorID: "u1", Status: domain.PRStatusMerged}
+			prRepo.PRs["foreign"] = &domain.PullRequest{PullRequestID: "foreign", AuthorID: "u2", Status: domain.PRStatusOpen}
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{MaxOpenPRsPerAuthor: tt.limit})
+
+			_, err := svc.CreatePullRequest(context.Background(), "pr-new", "Feature", "u1", nil, nil, "")
+
+			if tt.wantErr {
+				testutil.AssertTrue(t, errors.Is(err, domain.ErrAuthorPRLimit), "Author PR limit error")
+				testutil.AssertTrue(t, prRepo.PRs["pr-new"] == nil, "PR not created")
+				return
+			}
+			testutil.AssertNoError(t, err)
+			testutil.AssertTrue(t, prRepo.PRs["pr-new"] != nil, "PR created")
+		})
+	}
+}
+
+// TestPullRequestService_CreatePullRequest_Size tests that each PR size maps to its
+// configured reviewer count, bounded by the eligible team members
+func TestPullRequestService_CreatePullRequest_Size(t *testing.T) {
+	tests := []struct {
+		name          string
+		size          domain.PRSize
+		wantCount     int
+		wantReviewers int
+		wantErr       error
+	}{
+		{name: "no size keeps the team count", size: "", wantCount: 2, wantReviewers: 2},
+		{name: "small", size: domain.PRSizeSmall, wantCount: 1, wantReviewers: 1},
+		{name: "medium", size: domain.PRSizeMedium, wantCount: 2, wantReviewers: 2},
+		{name: "large", size: domain.PRSizeLarge, wantCount: 3, wantReviewers: 3},
+		{name: "unknown size", size: "huge", wantErr: domain.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo, userRepo := newTeamRepos("backend", "author", "u1", "u2", "u3", "u4")
+			cfg := AssignmentConfig{SizeReviewers: map[domain.PRSize]int{
+				domain.PRSizeSmall: 1, domain.PRSizeMedium: 2, domain.PRSizeLarge: 3,
+			}}
+			svc := newTestService(prRepo, userRepo, cfg)
+
+			pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, tt.size)
+
+			if tt.wantErr != nil {
+				testutil.AssertTrue(t, errors.Is(err, tt.wantErr), "Invalid size rejected")
+				testutil.AssertTrue(t, prRepo.PRs["pr-1"] == nil, "PR not created")
+				return
+			}
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, pr.Size, tt.size, "Size")
+			testutil.AssertEqual(t, pr.ReviewerCount, tt.wantCount, "Reviewer count")
+			testutil.AssertLen(t, pr.AssignedReviewers, tt.wantReviewers, "Assigned reviewers")
+		})
+	}
+
+	t.Run("bounded by team size", func(t *testing.T) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "u1", "u2")
+		cfg := AssignmentConfig{SizeReviewers: map[domain.PRSize]int{domain.PRSizeLarge: 3}}
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, domain.PRSizeLarge)
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.ReviewerCount, 3, "Reviewer count")
+		testutil.AssertLen(t, pr.AssignedReviewers, 2, "Every eligible teammate assigned")
+	})
+}
+
+// TestParseSizeReviewers tests validation of the PR size to reviewer count mapping
+func TestParseSizeReviewers(t *testing.T) {
+	counts, err := ParseSizeReviewers(map[string]int{"small": 1, "large": 3})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, counts, map[domain.PRSize]int{domain.PRSizeSmall: 1, domain.PRSizeLarge: 3})
+
+	_, err = ParseSizeReviewers(map[string]int{"huge": 4})
+	testutil.AssertTrue(t, err != nil, "Unknown size rejected")
+
+	_, err = ParseSizeReviewers(map[string]int{"small": 0})
+	testutil.AssertTrue(t, err != nil, "Zero reviewers rejected")
+}
+
+// TestPullRequestService_CreatePullRequest_Transaction tests that the PR insert and the
+// reviewer assignment run in one transaction that is rolled back when assigning fails
+func TestPullRequestService_CreatePullRequest_Transaction(t *testing.T) {
+	assignErr := errors.New("assign failed")
+
+	tests := []struct {
+		name          string
+		assignErr     error
+		wantCommits   int
+		wantRollbacks int
+		wantHistory   int
+	}{
+		{
+			name:        "commits PR with reviewers",
+			wantCommits: 1,
+			wantHistory: 2,
+		},
+		{
+			name:          "rolls back when assigning fails",
+			assignErr:     assignErr,
+			wantRollbacks: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			prRepo, userRepo := newTeamRepos("backend", "u1", "u2", "u3")
+			txManager := &testutil.MockTxManager{}
+			inTx := false
+			prRepo.CreateFunc = func(ctx context.Context, pr *domain.PullRequest) error {
+				inTx = testutil.InMockTransaction(ctx)
+				prRepo.PRs[pr.PullRequestID] = pr
+				return nil
+			}
+			if tt.assignErr != nil {
+				prRepo.AssignReviewersFunc = func(ctx context.Context, prID string, reviewerIDs []string) error {
+					return tt.assignErr
+				}
+			}
+			svc := NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), txManager, ReviewerPolicy{}, AssignmentConfig{}, zap.NewNop())
+
+			// Act
+			_, err := svc.CreatePullRequest(context.Background(), "pr-new", "Feature", "u1", nil, nil, "")
+
+			// Assert
+			if tt.assignErr != nil {
+				testutil.AssertTrue(t, errors.Is(err, tt.assignErr), "Assign error returned")
+			} else {
+				testutil.AssertNoError(t, err)
+			}
+			testutil.AssertTrue(t, inTx, "PR created inside the transaction")
+			testutil.AssertEqual(t, txManager.Commits, tt.wantCommits, "Commits")
+			testutil.AssertEqual(t, txManager.Rollbacks, tt.wantRollbacks, "Rollbacks")
+			testutil.AssertEqual(t, len(prRepo.ReviewerHistory), tt.wantHistory, "History entries")
+		})
+	}
+}
+
 // TestPullRequestService_ReassignReviewer tests reviewer reassignment
 func TestPullRequestService_ReassignReviewer(t *testing.T) {
 	tests := []struct {
@@ -325,10 +559,10 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 			tt.setupMocks(prRepo, userRepo)
 
 			logger := zap.NewNop()
-			svc := NewPullRequestService(prRepo, userRepo, logger)
+			svc := NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), nil, ReviewerPolicy{}, AssignmentConfig{}, logger)
 
 			// Act
-			pr, replacedBy, err := svc.ReassignReviewer(context.Background(), tt.prID, tt.oldUserID)
+			result, err := svc.ReassignReviewer(context.Background(), tt.prID, tt.oldUserID)
 
 			// Assert
 			if tt.wantErr != nil {
@@ -337,10 +571,10 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 			}
 
 			testutil.AssertNoError(t, err)
-			testutil.AssertNotNil(t, pr)
+			testutil.AssertNotNil(t, result.PR)
 
 			if tt.validate != nil {
-				tt.validate(t, pr, replacedBy)
+				tt.validate(t, result.PR, result.ReplacedBy)
 			}
 		})
 	}
@@ -350,13 +584,13 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 func TestPullRequestService_ListPullRequests(t *testing.T) {
 	tests := []struct {
 		name       string
-		status     string
+		statuses   []string
 		setupMocks func(*testutil.MockPRRepository)
 		wantCount  int
 	}{
 		{
-			name:   "lists all PRs when no status filter",
-			status: "",
+			name:     "lists all PRs when no status filter",
+			statuses: nil,
 			setupMocks: func(prRepo *testutil.MockPRRepository) {
 				prRepo.PRs["pr-1"] = &domain.PullRequest{
 					PullRequestID: "pr-1", Status: domain.PRStatusOpen,
@@ -368,8 +602,8 @@ func TestPullRequestService_ListPullRequests(t *testing.T) {
 			wantCount: 2,
 		},
 		{
-			name:   "filters only open PRs",
-			status: "OPEN",
+			name:     "filters only open PRs",
+			statuses: []string{"OPEN"},
 			setupMocks: func(prRepo *testutil.MockPRRepository) {
 				prRepo.PRs["pr-1"] = &domain.PullRequest{
 					PullRequestID: "pr-1", Status: domain.PRStatusOpen,
@@ -383,6 +617,19 @@ func TestPullRequestService_ListPullRequests(t *testing.T) {
 			},
 			wantCount: 2,
 		},
+		{
+			name:     "multiple statuses",
+			statuses: []string{"OPEN", "MERGED"},
+			setupMocks: func(prRepo *testutil.MockPRRepository) {
+				prRepo.PRs["pr-1"] = &domain.PullRequest{
+					PullRequestID: "pr-1", Status: domain.PRStatusOpen,
+				}
+				prRepo.PRs["pr-2"] = &domain.PullRequest{
+					PullRequestID: "pr-2", Status: domain.PRStatusMerged,
+				}
+			},
+			wantCount: 2,
+		},
 	}
 
 	for _, tt := range tests {
@@ -393,10 +640,10 @@ func TestPullRequestService_ListPullRequests(t *testing.T) {
 			tt.setupMocks(prRepo)
 
 			logger := zap.NewNop()
-			svc := NewPullRequestService(prRepo, userRepo, logger)
+			svc := NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), nil, ReviewerPolicy{}, AssignmentConfig{}, logger)
 
 			// Act
-			prs, err := svc.ListPullRequests(context.Background(), tt.status)
+			prs, err := svc.ListPullRequests(context.Background(), tt.statuses, domain.Page{})
 
 			// Assert
 			testutil.AssertNoError(t, err)
@@ -419,7 +666,7 @@ func TestPullRequestService_GetUserReviews(t *testing.T) {
 			userID: "u1",
 			setupMocks: func(prRepo *testutil.MockPRRepository, userRepo *testutil.MockUserRepository) {
 				userRepo.Users["u1"] = &domain.User{UserID: "u1", Username: "Alice", IsActive: true}
-				prRepo.GetByReviewerFunc = func(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+				prRepo.GetByReviewerFunc = func(ctx context.Context, userID string, page domain.Page) ([]domain.PullRequestShort, error) {
 					return []domain.PullRequestShort{
 						{PullRequestID: "pr-1", PullRequestName: "Feature 1"},
 						{PullRequestID: "pr-2", PullRequestName: "Feature 2"},
@@ -443,7 +690,7 @@ func TestPullRequestService_GetUserReviews(t *testing.T) {
 			userID: "u1",
 			setupMocks: func(prRepo *testutil.MockPRRepository, userRepo *testutil.MockUserRepository) {
 				userRepo.Users["u1"] = &domain.User{UserID: "u1", Username: "Alice", IsActive: true}
-				prRepo.GetByReviewerFunc = func(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+				prRepo.GetByReviewerFunc = func(ctx context.Context, userID string, page domain.Page) ([]domain.PullRequestShort, error) {
 					return []domain.PullRequestShort{}, nil
 				}
 			},
@@ -455,7 +702,7 @@ func TestPullRequestService_GetUserReviews(t *testing.T) {
 			userID: "u1",
 			setupMocks: func(prRepo *testutil.MockPRRepository, userRepo *testutil.MockUserRepository) {
 				userRepo.Users["u1"] = &domain.User{UserID: "u1", Username: "Alice", IsActive: true}
-				prRepo.GetByReviewerFunc = func(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+				prRepo.GetByReviewerFunc = func(ctx context.Context, userID string, page domain.Page) ([]domain.PullRequestShort, error) {
 					return nil, fmt.Errorf("database error")
 				}
 			},
@@ -471,10 +718,10 @@ func TestPullRequestService_GetUserReviews(t *testing.T) {
 			tt.setupMocks(prRepo, userRepo)
 
 			logger := zap.NewNop()
-			svc := NewPullRequestService(prRepo, userRepo, logger)
+			svc := NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), nil, ReviewerPolicy{}, AssignmentConfig{}, logger)
 
 			// Act
-			result, err := svc.GetUserReviews(context.Background(), tt.userID)
+			result, err := svc.GetUserReviews(context.Background(), tt.userID, domain.Page{})
 
 			// Assert
 			if tt.wantErr != nil {
@@ -488,3 +735,2685 @@ func TestPullRequestService_GetUserReviews(t *testing.T) {
 		})
 	}
 }
+
+// TestPullRequestService_GetUserReviews_Paging tests walking a reviewer's PRs page by page:
+// every PR is seen exactly once and total reports the full count on each page
+func TestPullRequestService_GetUserReviews_Paging(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["u1"] = &domain.User{UserID: "u1", IsActive: true}
+	for i := 0; i < 7; i++ {
+		prID := fmt.Sprintf("pr-%d", i)
+		prRepo.PRs[prID] = &domain.PullRequest{PullRequestID: prID, Status: domain.PRStatusOpen, AssignedReviewers: []string{"u1"}}
+	}
+	prRepo.PRs["pr-other"] = &domain.PullRequest{PullRequestID: "pr-other", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}}
+	svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+	seen := make(map[string]bool)
+	pages := 0
+	for offset := 0; ; offset += 3 {
+		result, err := svc.GetUserReviews(context.Background(), "u1", domain.Page{Limit: 3, Offset: offset})
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, result.Total, 7)
+		testutil.AssertEqual(t, result.Limit, 3)
+		testutil.AssertEqual(t, result.Offset, offset)
+		if len(result.PullRequests) == 0 {
+			break
+		}
+		pages++
+		for _, pr := range result.PullRequests {
+			testutil.AssertFalse(t, seen[pr.PullRequestID], "PR appears on one page only: "+pr.PullRequestID)
+			seen[pr.PullRequestID] = true
+		}
+	}
+
+	testutil.AssertEqual(t, pages, 3)
+	testutil.AssertLen(t, seen, 7, "Every PR of the reviewer seen")
+
+	t.Run("unbounded page", func(t *testing.T) {
+		result, err := svc.GetUserReviews(context.Background(), "u1", domain.Page{})
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, result.PullRequests, 7, "Limit 0 returns everything")
+		testutil.AssertEqual(t, result.Total, 7)
+	})
+}
+
+// TestPullRequestService_GetUserReviews_Timestamps tests that review lists carry
+// the PR timestamps so clients can sort by age
+func TestPullRequestService_GetUserReviews_Timestamps(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["u1"] = &domain.User{UserID: "u1", IsActive: true}
+	created := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	merged := created.Add(3 * time.Hour)
+	prRepo.PRs["pr-1"] = &domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusOpen,
+		AssignedReviewers: []string{"u1"}, CreatedAt: &created}
+	prRepo.PRs["pr-2"] = &domain.PullRequest{PullRequestID: "pr-2", Status: domain.PRStatusMerged,
+		AssignedReviewers: []string{"u1"}, CreatedAt: &created, MergedAt: &merged}
+	svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+	result, err := svc.GetUserReviews(context.Background(), "u1", domain.Page{})
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertLen(t, result.PullRequests, 2)
+	testutil.AssertEqual(t, *result.PullRequests[0].CreatedAt, created, "Open PR createdAt")
+	testutil.AssertTrue(t, result.PullRequests[0].MergedAt == nil, "Open PR has no mergedAt")
+	testutil.AssertEqual(t, *result.PullRequests[1].MergedAt, merged, "Merged PR mergedAt")
+}
+
+// TestPullRequestService_GetPrimaryReviews tests that only primary assignments are listed,
+// open PRs first, and that users without them get an empty list
+func TestPullRequestService_GetPrimaryReviews(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["u1"] = &domain.User{UserID: "u1", IsActive: true}
+	userRepo.Users["u2"] = &domain.User{UserID: "u2", IsActive: true}
+	userRepo.Users["u3"] = &domain.User{UserID: "u3", IsActive: true}
+	prRepo.PRs["pr-1"] = &domain.PullRequest{PullRequestID: "pr-1", Status: domain.PRStatusMerged,
+		AssignedReviewers: []string{"u1", "u2"}, PrimaryReviewer: "u1"}
+	prRepo.PRs["pr-2"] = &domain.PullRequest{PullRequestID: "pr-2", Status: domain.PRStatusOpen,
+		AssignedReviewers: []string{"u1", "u2"}, PrimaryReviewer: "u1"}
+	prRepo.PRs["pr-3"] = &domain.PullRequest{PullRequestID: "pr-3", Status: domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u1"}, PrimaryReviewer: "u2"}
+	svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+	ctx := context.Background()
+
+	prs, err := svc.GetPrimaryReviews(ctx, "u1")
+	testutil.AssertNoError(t, err)
+	testutil.AssertLen(t, prs, 2, "Secondary assignment on pr-3 excluded")
+	testutil.AssertEqual(t, prs[0].PullRequestID, "pr-2", "Open PR first")
+	testutil.AssertEqual(t, prs[1].PullRequestID, "pr-1", "Merged PR last")
+
+	prs, err = svc.GetPrimaryReviews(ctx, "u2")
+	testutil.AssertNoError(t, err)
+	testutil.AssertLen(t, prs, 1, "Only the primary assignment")
+	testutil.AssertEqual(t, prs[0].PullRequestID, "pr-3")
+
+	t.Run("no primary assignments", func(t *testing.T) {
+		prs, err := svc.GetPrimaryReviews(ctx, "u3")
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, prs, 0, "Empty list")
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		prs, err := svc.GetPrimaryReviews(ctx, "ghost")
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, prs, 0, "Empty list instead of 404")
+	})
+}
+
+// TestPullRequestService_ExplainAssignment tests that the explanation comes from the trace
+// recorded at selection time (including capped candidates), not from the current team
+func TestPullRequestService_ExplainAssignment(t *testing.T) {
+	setup := func(cfg AssignmentConfig) (*PullRequestService, *testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "u1", "u2", "u3", "u4", "u5")
+		userRepo.Users["u4"].IsActive = false
+		// u5 already has two pending reviews and is over MaxPendingReviews
+		prRepo.AddOpenPR("pr-old-1", "x", "u5")
+		prRepo.AddOpenPR("pr-old-2", "x", "u5")
+		cfg.MaxPendingReviews = 1
+		return newTestService(prRepo, userRepo, cfg), prRepo, userRepo
+	}
+	ctx := context.Background()
+
+	t.Run("debug trace recorded at creation survives team changes", func(t *testing.T) {
+		svc, _, userRepo := setup(AssignmentConfig{Features: FeatureFlags{DebugTrace: true}})
+		pr, err := svc.CreatePullRequest(ctx, "pr-001", "Feature", "u1", nil, nil, "")
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u2", "u3"})
+
+		// Membership and activity change after the assignment
+		userRepo.Users["u2"].IsActive = false
+		userRepo.AddTeamMembers("backend", "u6")
+
+		explanation, err := svc.ExplainAssignment(ctx, "pr-001")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, explanation.TeamName, "backend", "Team name")
+		testutil.AssertLen(t, explanation.Reviewers, 2, "Trace per assigned reviewer")
+		trace := explanation.Reviewers[0]
+		testutil.AssertEqual(t, trace.ReviewerID, "u2", "Reviewer ID")
+		testutil.AssertTrue(t, trace.Traced, "Trace recorded at creation")
+		testutil.AssertEqual(t, trace.Considered, []string{"u2", "u3"}, "Candidates as of creation")
+		testutil.AssertContains(t, trace.Excluded, CandidateExclusion{UserID: "u1", Reason: ExclusionAuthor})
+		testutil.AssertContains(t, trace.Excluded, CandidateExclusion{UserID: "u4", Reason: ExclusionInactive})
+		testutil.AssertContains(t, trace.Excluded, CandidateExclusion{UserID: "u5", Reason: ExclusionCapped})
+		testutil.AssertLen(t, trace.Excluded, 3, "Later member u6 is not in the trace")
+	})
+
+	t.Run("reassignment records its own trace", func(t *testing.T) {
+		svc, _, userRepo := setup(AssignmentConfig{Features: FeatureFlags{DebugTrace: true}})
+		_, err := svc.CreatePullRequest(ctx, "pr-001", "Feature", "u1", nil, nil, "")
+		testutil.AssertNoError(t, err)
+		userRepo.AddTeamMembers("backend", "u6")
+
+		result, err := svc.ReassignReviewer(ctx, "pr-001", "u3")
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, result.ReplacedBy, "u6")
+
+		explanation, err := svc.ExplainAssignment(ctx, "pr-001")
+
+		testutil.AssertNoError(t, err)
+		trace := explanation.Reviewers[1]
+		testutil.AssertEqual(t, trace.ReviewerID, "u6", "Replacement reviewer")
+		testutil.AssertEqual(t, trace.Considered, []string{"u6"}, "Candidates of the reassignment")
+		testutil.AssertContains(t, trace.Excluded, CandidateExclusion{UserID: "u3", Reason: ExclusionAlreadyAssigned})
+		testutil.AssertContains(t, trace.Excluded, CandidateExclusion{UserID: "u5", Reason: ExclusionCapped})
+		testutil.AssertEqual(t, explanation.Reviewers[0].Considered, []string{"u2", "u3"}, "Creation trace kept for u2")
+	})
+
+	t.Run("exclusion reasons not stored without debug trace", func(t *testing.T) {
+		svc, prRepo, _ := setup(AssignmentConfig{})
+		_, err := svc.CreatePullRequest(ctx, "pr-001", "Feature", "u1", nil, nil, "")
+		testutil.AssertNoError(t, err)
+
+		explanation, err := svc.ExplainAssignment(ctx, "pr-001")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, explanation.Reviewers, 2)
+		testutil.AssertEqual(t, explanation.Reviewers[0].Considered, []string{"u2", "u3"})
+		testutil.AssertLen(t, explanation.Reviewers[0].Excluded, 0, "Excluded should be hidden")
+		testutil.AssertFalse(t, strings.Contains(string(prRepo.ReviewerHistory[0].SelectionTrace), "excluded"),
+			"Exclusion reasons not stored")
+	})
+
+	t.Run("manual assignment has no trace", func(t *testing.T) {
+		svc, _, _ := setup(AssignmentConfig{Features: FeatureFlags{DebugTrace: true}})
+		_, err := svc.CreatePullRequest(ctx, "pr-001", "Feature", "u1", nil, nil, "")
+		testutil.AssertNoError(t, err)
+		_, err = svc.AddReviewer(ctx, "pr-001", "u5")
+		testutil.AssertNoError(t, err)
+
+		explanation, err := svc.ExplainAssignment(ctx, "pr-001")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, explanation.Reviewers[2].ReviewerID, "u5")
+		testutil.AssertFalse(t, explanation.Reviewers[2].Traced, "Manual assignment not traced")
+		testutil.AssertLen(t, explanation.Reviewers[2].Considered, 0)
+	})
+
+	t.Run("returns error when PR not found", func(t *testing.T) {
+		svc, _, _ := setup(AssignmentConfig{Features: FeatureFlags{DebugTrace: true}})
+
+		_, err := svc.ExplainAssignment(ctx, "pr-missing")
+
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestPullRequestService_CreatePullRequest_ReviewGroups tests reviewer selection from review groups
+func TestPullRequestService_CreatePullRequest_ReviewGroups(t *testing.T) {
+	setupUsers := func(userRepo *testutil.MockUserRepository) {
+		userRepo.Users["core1"] = &domain.User{UserID: "core1", TeamName: "payments", IsActive: true, ReviewGroup: "payments-core"}
+		userRepo.Users["core2"] = &domain.User{UserID: "core2", TeamName: "payments", IsActive: true, ReviewGroup: "payments-core"}
+		userRepo.Users["core3"] = &domain.User{UserID: "core3", TeamName: "payments", IsActive: true, ReviewGroup: "payments-core"}
+		userRepo.Users["infra1"] = &domain.User{UserID: "infra1", TeamName: "payments", IsActive: true, ReviewGroup: "payments-infra"}
+		userRepo.Users["infra2"] = &domain.User{UserID: "infra2", TeamName: "payments", IsActive: true, ReviewGroup: "payments-infra"}
+		userRepo.AddTeamMembers("payments", "solo")
+	}
+
+	t.Run("grouped author draws reviewers only from own group", func(t *testing.T) {
+		prRepo := testutil.NewMockPRRepository()
+		userRepo := testutil.NewMockUserRepository()
+		setupUsers(userRepo)
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-core", "Core change", "core1", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, pr.AssignedReviewers, 2)
+		for _, reviewerID := range pr.AssignedReviewers {
+			testutil.AssertEqual(t, userRepo.Users[reviewerID].ReviewGroup, "payments-core", "Reviewer must be from author's group")
+		}
+	})
+
+	t.Run("ungrouped author draws reviewers from whole team", func(t *testing.T) {
+		prRepo := testutil.NewMockPRRepository()
+		userRepo := testutil.NewMockUserRepository()
+		setupUsers(userRepo)
+		// Keep only members of another group to verify no group filtering is applied
+		delete(userRepo.Users, "core1")
+		delete(userRepo.Users, "core2")
+		delete(userRepo.Users, "core3")
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-solo", "Solo change", "solo", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, pr.AssignedReviewers, 2)
+		testutil.AssertContains(t, pr.AssignedReviewers, "infra1")
+		testutil.AssertContains(t, pr.AssignedReviewers, "infra2")
+	})
+}
+
+// TestPullRequestService_CreatePullRequest_RequireSenior tests senior reviewer guarantee
+func TestPullRequestService_CreatePullRequest_RequireSenior(t *testing.T) {
+	t.Run("senior is guaranteed a slot", func(t *testing.T) {
+		// Repeat to make sure the guarantee does not depend on shuffle luck
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := newTeamRepos("backend", "author")
+			userRepo.Users["senior"] = &domain.User{
+				UserID: "senior", TeamName: "backend", IsActive: true, Seniority: domain.SenioritySenior,
+			}
+			for _, id := range []string{"j1", "j2", "j3", "j4", "j5"} {
+				userRepo.Users[id] = &domain.User{
+					UserID: id, TeamName: "backend", IsActive: true, Seniority: domain.SeniorityJunior,
+				}
+			}
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{Features: FeatureFlags{RequireSenior: true}})
+
+			pr, err := svc.CreatePullRequest(context.Background(), fmt.Sprintf("pr-%d", i), "Feature", "author", nil, nil, "")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertLen(t, pr.AssignedReviewers, 2)
+			testutil.AssertContains(t, pr.AssignedReviewers, "senior", "Senior should always be assigned")
+		}
+	})
+
+	t.Run("falls back to juniors when team has no seniors", func(t *testing.T) {
+		prRepo, userRepo := newTeamRepos("backend", "author")
+		for _, id := range []string{"j1", "j2", "j3"} {
+			userRepo.Users[id] = &domain.User{
+				UserID: id, TeamName: "backend", IsActive: true, Seniority: domain.SeniorityJunior,
+			}
+		}
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{Features: FeatureFlags{RequireSenior: true}})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-juniors", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, pr.AssignedReviewers, 2, "Should still assign juniors")
+	})
+}
+
+// TestPullRequestService_SelectionLogLevel tests that selection details respect their own log level
+func TestPullRequestService_SelectionLogLevel(t *testing.T) {
+	tests := []struct {
+		name           string
+		selectionLevel zapcore.Level
+		wantDetails    bool
+	}{
+		{
+			name:           "info level hides selection details",
+			selectionLevel: zapcore.InfoLevel,
+			wantDetails:    false,
+		},
+		{
+			name:           "debug level emits selection details despite global info level",
+			selectionLevel: zapcore.DebugLevel,
+			wantDetails:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			prRepo, userRepo := newTeamRepos("backend", "u1", "u2")
+
+			core, logs := observer.New(zapcore.InfoLevel)
+			svc := NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), nil, ReviewerPolicy{}, AssignmentConfig{SelectionLogLevel: tt.selectionLevel}, zap.New(core))
+
+			// Act
+			_, err := svc.CreatePullRequest(context.Background(), "pr-001", "Feature", "u1", nil, nil, "")
+
+			// Assert
+			testutil.AssertNoError(t, err)
+			selectionLogs := logs.Filter(func(e observer.LoggedEntry) bool {
+				return e.LoggerName == "selection"
+			}).Len()
+			testutil.AssertEqual(t, selectionLogs > 0, tt.wantDetails, "Selection details emitted")
+			testutil.AssertEqual(t, logs.FilterMessage("PR created").Len(), 1, "Regular info logs are unaffected")
+		})
+	}
+}
+
+// TestPullRequestService_ReassignReviewer_StaleCandidate tests that a candidate deactivated
+// after the team snapshot was taken is never assigned
+func TestPullRequestService_ReassignReviewer_StaleCandidate(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		prRepo, userRepo := newTeamRepos("backend", "u1", "u2", "stale", "fresh")
+		prRepo.AddOpenPR("pr-001", "u1", "u2")
+
+		// The team snapshot still shows "stale" as active, but it flips inactive before assignment
+		userRepo.GetFunc = func(ctx context.Context, userID string) (*domain.User, error) {
+			user, ok := userRepo.Users[userID]
+			if !ok {
+				return nil, domain.ErrNotFound
+			}
+			if userID == "stale" {
+				flipped := *user
+				flipped.IsActive = false
+				return &flipped, nil
+			}
+			return user, nil
+		}
+
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		result, err := svc.ReassignReviewer(context.Background(), "pr-001", "u2")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, result.ReplacedBy, "fresh", "Still-active candidate should be chosen")
+	}
+}
+
+// TestPullRequestService_ReassignReviewer_CandidateLookup tests that only a deleted candidate
+// is skipped on the re-check; other lookup errors are returned instead of ErrNoCandidate
+func TestPullRequestService_ReassignReviewer_CandidateLookup(t *testing.T) {
+	errLookup := errors.New("connection reset")
+
+	tests := []struct {
+		name      string
+		lookupErr error
+		wantErr   error
+	}{
+		{
+			name:      "deleted candidate is skipped",
+			lookupErr: domain.ErrNotFound,
+			wantErr:   domain.ErrNoCandidate,
+		},
+		{
+			name:      "lookup failure is returned",
+			lookupErr: errLookup,
+			wantErr:   errLookup,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			prRepo, userRepo := newTeamRepos("backend", "u1", "u2", "u3")
+			prRepo.AddOpenPR("pr-001", "u1", "u2")
+
+			// The only candidate cannot be re-checked before assignment
+			userRepo.GetFunc = func(ctx context.Context, userID string) (*domain.User, error) {
+				if userID == "u3" {
+					return nil, tt.lookupErr
+				}
+				return userRepo.Users[userID], nil
+			}
+
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+			// Act
+			_, err := svc.ReassignReviewer(context.Background(), "pr-001", "u2")
+
+			// Assert
+			testutil.AssertTrue(t, errors.Is(err, tt.wantErr), "expected %v, got %v", tt.wantErr, err)
+			testutil.AssertEqual(t, prRepo.PRs["pr-001"].AssignedReviewers, []string{"u2"}, "Reviewer should be kept")
+		})
+	}
+}
+
+// TestPullRequestService_CreatePullRequest_CodeOwners tests that path owners are assigned first
+func TestPullRequestService_CreatePullRequest_CodeOwners(t *testing.T) {
+	cfg := AssignmentConfig{
+		CodeOwners: map[string][]string{
+			"services/payments/":      {"owner1"},
+			"services/payments/core/": {"owner2"},
+			"web/":                    {"webowner"},
+		},
+	}
+
+	setup := func() (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "b1", "b2")
+		userRepo.AddTeamMembers("payments", "owner1", "owner2")
+		userRepo.Users["webowner"] = &domain.User{UserID: "webowner", TeamName: "web", IsActive: false}
+		return prRepo, userRepo
+	}
+
+	t.Run("owners of matching paths are assigned first", func(t *testing.T) {
+		prRepo, userRepo := setup()
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Payments fix", "author",
+			[]string{"services/payments/core/ledger.go"}, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"owner2", "owner1"}, "Most specific owner first")
+	})
+
+	t.Run("remaining slots are filled from author team", func(t *testing.T) {
+		prRepo, userRepo := setup()
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-2", "Payments api", "author",
+			[]string{"services/payments/api.go"}, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, pr.AssignedReviewers, 2)
+		testutil.AssertEqual(t, pr.AssignedReviewers[0], "owner1", "Owner assigned first")
+		testutil.AssertEqual(t, userRepo.Users[pr.AssignedReviewers[1]].TeamName, "backend", "Second slot from team")
+	})
+
+	t.Run("inactive owners are skipped", func(t *testing.T) {
+		prRepo, userRepo := setup()
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-3", "Web tweak", "author",
+			[]string{"web/index.html"}, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertNotContains(t, pr.AssignedReviewers, "webowner", "Inactive owner should be skipped")
+		testutil.AssertLen(t, pr.AssignedReviewers, 2)
+	})
+
+	t.Run("unknown owners are skipped", func(t *testing.T) {
+		prRepo, userRepo := setup()
+		delete(userRepo.Users, "owner1")
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-4", "Payments api", "author",
+			[]string{"services/payments/api.go"}, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertNotContains(t, pr.AssignedReviewers, "owner1", "Unknown owner should be skipped")
+		testutil.AssertLen(t, pr.AssignedReviewers, 2)
+	})
+
+	t.Run("owner lookup failure is returned", func(t *testing.T) {
+		prRepo, userRepo := setup()
+		errLookup := errors.New("connection reset")
+		userRepo.GetFunc = func(ctx context.Context, userID string) (*domain.User, error) {
+			if userID == "owner1" {
+				return nil, errLookup
+			}
+			return userRepo.Users[userID], nil
+		}
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		_, err := svc.CreatePullRequest(context.Background(), "pr-5", "Payments api", "author",
+			[]string{"services/payments/api.go"}, nil, "")
+
+		testutil.AssertTrue(t, errors.Is(err, errLookup), "expected lookup error, got %v", err)
+		_, exists := prRepo.PRs["pr-5"]
+		testutil.AssertFalse(t, exists, "PR should not be created")
+	})
+}
+
+// TestPullRequestService_DailyLimit tests that candidates at their daily limit are skipped
+func TestPullRequestService_DailyLimit(t *testing.T) {
+	t.Run("user at daily limit is skipped on create", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := newTeamRepos("backend", "author", "busy", "free")
+			prRepo.GetAssignmentCountsSinceFunc = func(ctx context.Context, since time.Time) (map[string]int, error) {
+				return map[string]int{"busy": 3}, nil
+			}
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{MaxDailyAssignments: 3})
+
+			pr, err := svc.CreatePullRequest(context.Background(), fmt.Sprintf("pr-%d", i), "Feature", "author", nil, nil, "")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, pr.AssignedReviewers, []string{"free"}, "Busy user should be skipped")
+		}
+	})
+
+	t.Run("personal limit overrides default", func(t *testing.T) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "busy", "free")
+		userRepo.Users["busy"].MaxDailyAssignments = 5
+		prRepo.GetAssignmentCountsSinceFunc = func(ctx context.Context, since time.Time) (map[string]int, error) {
+			return map[string]int{"busy": 3}, nil
+		}
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{MaxDailyAssignments: 3})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-personal", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertContains(t, pr.AssignedReviewers, "busy", "Personal limit not reached yet")
+	})
+
+	t.Run("falls back to least over limit when everyone is at limit", func(t *testing.T) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "busy", "free")
+		prRepo.AddOpenPR("pr-001", "author", "reviewer")
+		userRepo.AddTeamMembers("backend", "reviewer")
+		prRepo.GetAssignmentCountsSinceFunc = func(ctx context.Context, since time.Time) (map[string]int, error) {
+			return map[string]int{"busy": 4, "free": 2}, nil
+		}
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{MaxDailyAssignments: 2})
+
+		result, err := svc.ReassignReviewer(context.Background(), "pr-001", "reviewer")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, result.ReplacedBy, "free", "Least over limit candidate should be chosen")
+	})
+}
+
+// TestPullRequestService_WeeklyLimit tests that a candidate under the daily limit but at
+// the weekly limit is skipped, and that the week starts on Monday
+func TestPullRequestService_WeeklyLimit(t *testing.T) {
+	// Wednesday: the week started two days before the day
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	prRepo, userRepo := newTeamRepos("backend", "author", "busy", "free")
+	var sinces []time.Time
+	prRepo.GetAssignmentCountsSinceFunc = func(ctx context.Context, since time.Time) (map[string]int, error) {
+		sinces = append(sinces, since)
+		if since.Equal(monday) {
+			return map[string]int{"busy": 5, "free": 4}, nil
+		}
+		return map[string]int{"busy": 1, "free": 1}, nil
+	}
+	cfg := AssignmentConfig{MaxDailyAssignments: 3, MaxWeeklyAssignments: 5}
+	svc := newTestService(prRepo, userRepo, cfg)
+	svc.now = func() time.Time { return now }
+
+	pr, err := svc.CreatePullRequest(context.Background(), "pr-weekly", "Feature", "author", nil, nil, "")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, pr.AssignedReviewers, []string{"free"}, "Busy is under the daily but at the weekly limit")
+	testutil.AssertContains(t, sinces, monday, "Weekly counts start on Monday")
+}
+
+// TestPullRequestService_TeamCalendar tests that candidates whose team is off today are skipped
+// in favour of working ones, and that they are still assigned when nobody else is working
+func TestPullRequestService_TeamCalendar(t *testing.T) {
+	// Saturday, 2025-05-03
+	now := time.Date(2025, 5, 3, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		calendar *domain.TeamCalendar
+		pool     []string
+		expected []string
+	}{
+		{
+			name:     "teammate off on a holiday is skipped",
+			calendar: &domain.TeamCalendar{Holidays: []string{"2025-05-03"}},
+			pool:     []string{"b1", "b2", "f1"},
+			expected: []string{"b1", "b2"},
+		},
+		{
+			name:     "teammate off on a weekend is skipped",
+			calendar: &domain.TeamCalendar{WorkingDays: []int{1, 2, 3, 4, 5}},
+			pool:     []string{"b1", "b2", "f1"},
+			expected: []string{"b1", "b2"},
+		},
+		{
+			name:     "working day keeps everyone",
+			calendar: &domain.TeamCalendar{WorkingDays: []int{6, 7}, Holidays: []string{"2025-05-01"}},
+			pool:     []string{"b1", "f1"},
+			expected: []string{"b1", "f1"},
+		},
+		{
+			name:     "everyone off falls back to any eligible",
+			calendar: &domain.TeamCalendar{Holidays: []string{"2025-05-03"}},
+			pool:     []string{"f1"},
+			expected: []string{"f1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo, userRepo := newTeamRepos("backend", "author", "b1", "b2")
+			userRepo.AddTeamMembers("frontend", "f1")
+			teamRepo := testutil.NewMockTeamRepository()
+			teamRepo.Teams["backend"] = &domain.Team{TeamName: "backend"}
+			teamRepo.Teams["frontend"] = &domain.Team{TeamName: "frontend", Calendar: tt.calendar}
+			svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, ReviewerPolicy{}, AssignmentConfig{}, zap.NewNop())
+			svc.now = func() time.Time { return now }
+
+			pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, tt.pool, "")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, pr.AssignedReviewers, tt.expected, "Assigned reviewers")
+		})
+	}
+}
+
+// TestPullRequestService_PendingLimit tests that candidates with too many open reviews are
+// skipped, falling back to the least overloaded when everyone is over the limit
+func TestPullRequestService_PendingLimit(t *testing.T) {
+	setup := func(busyPending, freePending int) (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "busy", "free")
+		pending := map[string]int{"busy": busyPending, "free": freePending}
+		for userID, count := range pending {
+			for i := 0; i < count; i++ {
+				prRepo.AddOpenPR(fmt.Sprintf("pending-%s-%d", userID, i), "someone", userID)
+			}
+		}
+		return prRepo, userRepo
+	}
+
+	t.Run("reviewer over the limit is skipped on create", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := setup(4, 1)
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{MaxPendingReviews: 3})
+
+			pr, err := svc.CreatePullRequest(context.Background(), fmt.Sprintf("pr-%d", i), "Feature", "author", nil, nil, "")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, pr.AssignedReviewers, []string{"free"}, "Busy reviewer should be skipped")
+		}
+	})
+
+	t.Run("reviewer at the limit is still eligible", func(t *testing.T) {
+		prRepo, userRepo := setup(3, 0)
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{MaxPendingReviews: 3})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-at-limit", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertContains(t, pr.AssignedReviewers, "busy", "Exactly N pending reviews is allowed")
+	})
+
+	t.Run("falls back to least over limit when everyone is over", func(t *testing.T) {
+		prRepo, userRepo := setup(6, 4)
+		prRepo.AddOpenPR("pr-001", "author", "reviewer")
+		userRepo.AddTeamMembers("backend", "reviewer")
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{MaxPendingReviews: 2})
+
+		result, err := svc.ReassignReviewer(context.Background(), "pr-001", "reviewer")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, result.ReplacedBy, "free", "Least over limit candidate should be chosen")
+	})
+}
+
+// TestPullRequestService_PreferAvailable tests that reviewers inside their availability window are preferred
+func TestPullRequestService_PreferAvailable(t *testing.T) {
+	hour := func(h int) *int { return &h }
+	// 14:00 is inside the 9-18 window and outside the 20-4 window
+	fixedNow := func() time.Time { return time.Date(2024, 5, 10, 14, 0, 0, 0, time.UTC) }
+
+	setup := func() (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "day", "night1", "night2")
+		userRepo.Users["day"].AvailableFrom, userRepo.Users["day"].AvailableTo = hour(9), hour(18)
+		for _, id := range []string{"night1", "night2"} {
+			userRepo.Users[id].AvailableFrom, userRepo.Users[id].AvailableTo = hour(20), hour(4)
+		}
+		return prRepo, userRepo
+	}
+
+	t.Run("in-window candidate is preferred on create", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := setup()
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{Features: FeatureFlags{PreferAvailable: true}})
+			svc.now = fixedNow
+
+			pr, err := svc.CreatePullRequest(context.Background(), fmt.Sprintf("pr-%d", i), "Feature", "author", nil, nil, "")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertLen(t, pr.AssignedReviewers, 2)
+			testutil.AssertEqual(t, pr.AssignedReviewers[0], "day", "Available reviewer takes the first slot")
+		}
+	})
+
+	t.Run("in-window candidate is preferred on reassign", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := setup()
+			userRepo.AddTeamMembers("backend", "old")
+			prRepo.AddOpenPR("pr-001", "author", "old")
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{Features: FeatureFlags{PreferAvailable: true}})
+			svc.now = fixedNow
+
+			result, err := svc.ReassignReviewer(context.Background(), "pr-001", "old")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, result.ReplacedBy, "day", "Available reviewer should be chosen")
+		}
+	})
+
+	t.Run("falls back to out-of-window candidates", func(t *testing.T) {
+		prRepo, userRepo := setup()
+		userRepo.Users["day"].IsActive = false
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{Features: FeatureFlags{PreferAvailable: true}})
+		svc.now = fixedNow
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-fallback", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, pr.AssignedReviewers, 2, "Out-of-window reviewers fill the slots")
+	})
+}
+
+// TestPullRequestService_ForceAssignReviewer tests that forced assignment bypasses eligibility
+// rules but keeps hard invariants
+func TestPullRequestService_ForceAssignReviewer(t *testing.T) {
+	tests := []struct {
+		name     string
+		prStatus domain.PRStatus
+		userID   string
+		wantErr  error
+	}{
+		{
+			name:     "bypasses team check",
+			prStatus: domain.PRStatusOpen,
+			userID:   "outsider",
+		},
+		{
+			name:     "bypasses active check",
+			prStatus: domain.PRStatusOpen,
+			userID:   "inactive",
+		},
+		{
+			name:     "rejects author",
+			prStatus: domain.PRStatusOpen,
+			userID:   "author",
+			wantErr:  domain.ErrAuthorReviewer,
+		},
+		{
+			name:     "rejects merged PR",
+			prStatus: domain.PRStatusMerged,
+			userID:   "outsider",
+			wantErr:  domain.ErrPRMerged,
+		},
+		{
+			name:     "unknown user",
+			prStatus: domain.PRStatusOpen,
+			userID:   "ghost",
+			wantErr:  domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			prRepo, userRepo := newTeamRepos("backend", "author")
+			userRepo.AddTeamMembers("frontend", "outsider")
+			userRepo.Users["inactive"] = &domain.User{UserID: "inactive", TeamName: "backend", IsActive: false}
+			prRepo.PRs["pr-001"] = &domain.PullRequest{
+				PullRequestID:     "pr-001",
+				AuthorID:          "author",
+				Status:            tt.prStatus,
+				AssignedReviewers: []string{},
+			}
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+			// Act
+			pr, err := svc.ForceAssignReviewer(context.Background(), "pr-001", tt.userID)
+
+			// Assert
+			if tt.wantErr != nil {
+				testutil.AssertErrorIs(t, err, tt.wantErr)
+				testutil.AssertLen(t, prRepo.AuditEntries, 0, "No audit entry on rejection")
+				return
+			}
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertContains(t, pr.AssignedReviewers, tt.userID)
+			testutil.AssertLen(t, prRepo.AuditEntries, 1)
+			testutil.AssertEqual(t, prRepo.AuditEntries[0].Action, domain.AuditActionForceAssign, "Audit action")
+			testutil.AssertTrue(t, prRepo.AuditEntries[0].Forced, "Audit entry marked as forced")
+		})
+	}
+}
+
+// TestPullRequestService_AddReviewer tests manual assignment of a chosen reviewer
+func TestPullRequestService_AddReviewer(t *testing.T) {
+	tests := []struct {
+		name     string
+		prID     string
+		prStatus domain.PRStatus
+		userID   string
+		wantErr  error
+	}{
+		{name: "adds active user from another team", prID: "pr-001", prStatus: domain.PRStatusOpen, userID: "outsider"},
+		{name: "rejects author", prID: "pr-001", prStatus: domain.PRStatusOpen, userID: "author", wantErr: domain.ErrSelfReview},
+		{name: "rejects merged PR", prID: "pr-001", prStatus: domain.PRStatusMerged, userID: "outsider", wantErr: domain.ErrPRMerged},
+		{name: "rejects inactive user", prID: "pr-001", prStatus: domain.PRStatusOpen, userID: "inactive", wantErr: domain.ErrTargetIneligible},
+		{name: "rejects conflicted user", prID: "pr-001", prStatus: domain.PRStatusOpen, userID: "rival", wantErr: domain.ErrTargetIneligible},
+		{name: "rejects current reviewer", prID: "pr-001", prStatus: domain.PRStatusOpen, userID: "u2", wantErr: domain.ErrAlreadyAssigned},
+		{name: "unknown user", prID: "pr-001", prStatus: domain.PRStatusOpen, userID: "ghost", wantErr: domain.ErrNotFound},
+		{name: "unknown PR", prID: "missing", prStatus: domain.PRStatusOpen, userID: "outsider", wantErr: domain.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo, userRepo := newTeamRepos("backend", "author", "u2")
+			userRepo.AddTeamMembers("frontend", "outsider")
+			userRepo.Users["inactive"] = &domain.User{UserID: "inactive", TeamName: "backend", IsActive: false}
+			userRepo.AddTeamMembers("backend", "rival")
+			userRepo.Conflicts = []domain.ReviewConflict{{UserA: "author", UserB: "rival"}}
+			prRepo.PRs["pr-001"] = &domain.PullRequest{
+				PullRequestID:     "pr-001",
+				AuthorID:          "author",
+				Status:            tt.prStatus,
+				AssignedReviewers: []string{"u2"},
+			}
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+			pr, err := svc.AddReviewer(context.Background(), tt.prID, tt.userID)
+
+			if tt.wantErr != nil {
+				testutil.AssertTrue(t, errors.Is(err, tt.wantErr), fmt.Sprintf("got %v, want %v", err, tt.wantErr))
+				testutil.AssertEqual(t, prRepo.PRs["pr-001"].AssignedReviewers, []string{"u2"}, "Reviewers unchanged")
+				return
+			}
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u2", tt.userID}, "Returned PR")
+			testutil.AssertTrue(t, prRepo.PRs["pr-001"].HasReviewer(tt.userID), "Reviewer stored")
+			testutil.AssertLen(t, prRepo.ReviewerHistory, 1, "Assignment recorded in history")
+			testutil.AssertLen(t, prRepo.AuditEntries, 0, "Not a forced override")
+		})
+	}
+}
+
+// TestPullRequestService_AddReviewer_ConcurrentAssignment tests that a reviewer assigned by
+// a concurrent request between the read and the insert is reported as ErrAlreadyAssigned
+// without a second history entry
+func TestPullRequestService_AddReviewer_ConcurrentAssignment(t *testing.T) {
+	prRepo, userRepo := newTeamRepos("backend", "author", "u2")
+	prRepo.AddOpenPR("pr-001", "author", "u2")
+	// The service reads the PR before u2 was assigned
+	prRepo.GetFunc = func(ctx context.Context, prID string) (*domain.PullRequest, error) {
+		return &domain.PullRequest{PullRequestID: prID, AuthorID: "author", Status: domain.PRStatusOpen}, nil
+	}
+	svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+	_, err := svc.AddReviewer(context.Background(), "pr-001", "u2")
+
+	testutil.AssertTrue(t, errors.Is(err, domain.ErrAlreadyAssigned), fmt.Sprintf("got %v", err))
+	testutil.AssertLen(t, prRepo.ReviewerHistory, 0, "No duplicate history entry")
+}
+
+// TestPullRequestService_RemoveReviewer tests manual removal of a reviewer and the warning
+// logged when the last reviewer is removed while active candidates exist
+func TestPullRequestService_RemoveReviewer(t *testing.T) {
+	tests := []struct {
+		name        string
+		prStatus    domain.PRStatus
+		reviewers   []string
+		userID      string
+		wantErr     error
+		wantWarning bool
+	}{
+		{name: "removes one of two reviewers", prStatus: domain.PRStatusOpen, reviewers: []string{"u2", "u3"}, userID: "u3"},
+		{name: "removes last reviewer with a warning", prStatus: domain.PRStatusOpen, reviewers: []string{"u2"}, userID: "u2", wantWarning: true},
+		{name: "rejects merged PR", prStatus: domain.PRStatusMerged, reviewers: []string{"u2"}, userID: "u2", wantErr: domain.ErrPRMerged},
+		{name: "rejects unassigned user", prStatus: domain.PRStatusOpen, reviewers: []string{"u2"}, userID: "u3", wantErr: domain.ErrNotAssigned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo, userRepo := newTeamRepos("backend", "author", "u2", "u3")
+			prRepo.PRs["pr-001"] = &domain.PullRequest{
+				PullRequestID:     "pr-001",
+				AuthorID:          "author",
+				Status:            tt.prStatus,
+				AssignedReviewers: tt.reviewers,
+				PrimaryReviewer:   tt.reviewers[0],
+			}
+			core, logs := observer.New(zapcore.WarnLevel)
+			svc := NewPullRequestService(prRepo, userRepo, testutil.NewMockTeamRepository(), nil, ReviewerPolicy{}, AssignmentConfig{}, zap.New(core))
+
+			pr, err := svc.RemoveReviewer(context.Background(), "pr-001", tt.userID)
+
+			if tt.wantErr != nil {
+				testutil.AssertTrue(t, errors.Is(err, tt.wantErr), fmt.Sprintf("got %v, want %v", err, tt.wantErr))
+				testutil.AssertLen(t, prRepo.PRs["pr-001"].AssignedReviewers, len(tt.reviewers), "Reviewers unchanged")
+				return
+			}
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertFalse(t, pr.HasReviewer(tt.userID), "Reviewer removed from returned PR")
+			testutil.AssertFalse(t, prRepo.PRs["pr-001"].HasReviewer(tt.userID), "Reviewer removed from storage")
+			testutil.AssertLen(t, prRepo.ReviewerHistory, 1, "Removal recorded in history")
+			testutil.AssertEqual(t, prRepo.ReviewerHistory[0].Action, domain.ReviewerActionRemove)
+			testutil.AssertEqual(t, logs.FilterMessage("removing the last reviewer while active candidates exist").Len() == 1,
+				tt.wantWarning, "Last reviewer warning")
+		})
+	}
+}
+
+// TestPullRequestService_ExcludeUsernamePattern tests that bot accounts are never auto-assigned
+func TestPullRequestService_ExcludeUsernamePattern(t *testing.T) {
+	cfg := AssignmentConfig{ExcludeUsername: regexp.MustCompile(`-bot$`)}
+
+	setup := func() (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "bot", "u2", "u3")
+		for id, username := range map[string]string{"author": "alice", "bot": "ci-bot", "u2": "bob", "u3": "carol"} {
+			userRepo.Users[id].Username = username
+		}
+		return prRepo, userRepo
+	}
+
+	t.Run("bot is skipped on create", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := setup()
+			svc := newTestService(prRepo, userRepo, cfg)
+
+			pr, err := svc.CreatePullRequest(context.Background(), fmt.Sprintf("pr-%d", i), "Feature", "author", nil, nil, "")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertLen(t, pr.AssignedReviewers, 2)
+			testutil.AssertNotContains(t, pr.AssignedReviewers, "bot", "Bot should never be assigned")
+		}
+	})
+
+	t.Run("bot is skipped on reassign", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := setup()
+			prRepo.AddOpenPR("pr-001", "author", "u2")
+			svc := newTestService(prRepo, userRepo, cfg)
+
+			result, err := svc.ReassignReviewer(context.Background(), "pr-001", "u2")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, result.ReplacedBy, "u3", "Only the human candidate is eligible")
+		}
+	})
+
+	t.Run("empty pattern disables filtering", func(t *testing.T) {
+		prRepo, userRepo := setup()
+		delete(userRepo.Users, "u3")
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-nofilter", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertContains(t, pr.AssignedReviewers, "bot", "Bot is eligible without a pattern")
+	})
+}
+
+// TestPullRequestService_CreatePullRequest_StableWithoutTrim tests that selection is deterministic
+// when every eligible candidate fits into the available slots
+func TestPullRequestService_CreatePullRequest_StableWithoutTrim(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		prRepo, userRepo := newTeamRepos("backend", "u1", "u3", "u2")
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		pr, err := svc.CreatePullRequest(context.Background(), fmt.Sprintf("pr-%d", i), "Feature", "u1", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u2", "u3"}, "Stable order without trimming")
+	}
+}
+
+// TestPullRequestService_CreatePullRequest_MinTeamSize tests that teams below
+// MinTeamSize skip auto-assignment and report the reason
+func TestPullRequestService_CreatePullRequest_MinTeamSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		minTeamSize   int
+		wantReviewers []string
+		wantSkipped   string
+	}{
+		{
+			name:          "below threshold skips auto-assignment",
+			minTeamSize:   3,
+			wantReviewers: []string{},
+			wantSkipped:   domain.AutoAssignSkippedTeamTooSmall,
+		},
+		{
+			name:          "at threshold assigns normally",
+			minTeamSize:   2,
+			wantReviewers: []string{"u2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			prRepo, userRepo := newTeamRepos("backend", "u1", "u2", "u3")
+			userRepo.Users["u3"].IsActive = false
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{MinTeamSize: tt.minTeamSize})
+
+			// Act
+			pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "u1", nil, nil, "")
+
+			// Assert
+			testutil.AssertNoError(t, err)
+			testutil.AssertLen(t, pr.AssignedReviewers, len(tt.wantReviewers), "Reviewers")
+			for i, reviewerID := range tt.wantReviewers {
+				testutil.AssertEqual(t, pr.AssignedReviewers[i], reviewerID, "Reviewer")
+			}
+			testutil.AssertEqual(t, pr.AutoAssignSkipped, tt.wantSkipped, "Skip reason")
+			testutil.AssertTrue(t, prRepo.PRs["pr-1"] != nil, "PR is still created")
+		})
+	}
+}
+
+// TestPullRequestService_CreatePullRequest_Freeze tests that PRs created during a freeze
+// window stay unreviewed and that assignment resumes once the window ends
+func TestPullRequestService_CreatePullRequest_Freeze(t *testing.T) {
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	freezeEnd := now.Add(48 * time.Hour)
+
+	newService := func() (*PullRequestService, *testutil.MockPRRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "u1", "u2")
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+		svc.now = func() time.Time { return now }
+		return svc, prRepo
+	}
+
+	t.Run("during freeze creates PR without reviewers", func(t *testing.T) {
+		svc, prRepo := newService()
+		_, err := svc.SetFreeze(context.Background(), &freezeEnd)
+		testutil.AssertNoError(t, err)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "u1", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, pr.AssignedReviewers, 0, "No reviewers during freeze")
+		testutil.AssertTrue(t, pr.Frozen, "Frozen note")
+		testutil.AssertEqual(t, pr.AutoAssignSkipped, domain.AutoAssignSkippedFreeze, "Skip reason")
+		testutil.AssertTrue(t, prRepo.PRs["pr-1"] != nil, "PR is still created")
+	})
+
+	t.Run("after freeze assigns normally", func(t *testing.T) {
+		svc, _ := newService()
+		_, err := svc.SetFreeze(context.Background(), &freezeEnd)
+		testutil.AssertNoError(t, err)
+		svc.now = func() time.Time { return freezeEnd }
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "u1", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u2"}, "Reviewer assigned")
+		testutil.AssertTrue(t, !pr.Frozen, "Not frozen")
+	})
+
+	t.Run("lifted freeze assigns normally", func(t *testing.T) {
+		svc, prRepo := newService()
+		_, err := svc.SetFreeze(context.Background(), &freezeEnd)
+		testutil.AssertNoError(t, err)
+
+		until, err := svc.SetFreeze(context.Background(), nil)
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertTrue(t, until == nil && prRepo.FreezeUntil == nil, "Freeze cleared")
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "u1", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u2"}, "Reviewer assigned")
+	})
+
+	t.Run("rejects freeze end in the past", func(t *testing.T) {
+		svc, prRepo := newService()
+		past := now.Add(-time.Hour)
+
+		_, err := svc.SetFreeze(context.Background(), &past)
+
+		testutil.AssertTrue(t, errors.Is(err, domain.ErrInvalidInput), "Invalid input")
+		testutil.AssertTrue(t, prRepo.FreezeUntil == nil, "Nothing stored")
+	})
+}
+
+// TestPullRequestService_RepairReviewers tests that orphaned reviewers are replaced
+// in open PRs and removed from merged ones
+func TestPullRequestService_RepairReviewers(t *testing.T) {
+	prRepo, userRepo := newTeamRepos("backend", "author", "u2")
+	prRepo.AddOpenPR("pr-open", "author", "ghost")
+	prRepo.PRs["pr-merged"] = &domain.PullRequest{
+		PullRequestID: "pr-merged", AuthorID: "author", Status: domain.PRStatusMerged,
+		AssignedReviewers: []string{"ghost"},
+	}
+	prRepo.GetOrphanedReviewersFunc = func(ctx context.Context) ([]domain.OrphanedReviewer, error) {
+		return []domain.OrphanedReviewer{
+			{PullRequestID: "pr-merged", UserID: "ghost", Status: domain.PRStatusMerged},
+			{PullRequestID: "pr-open", UserID: "ghost", Status: domain.PRStatusOpen},
+		}, nil
+	}
+	svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+	repaired, err := svc.RepairReviewers(context.Background())
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, repaired, []domain.RepairedReviewer{
+		{PullRequestID: "pr-merged", UserID: "ghost"},
+		{PullRequestID: "pr-open", UserID: "ghost", ReplacedBy: "u2"},
+	}, "Repair outcome")
+	testutil.AssertEqual(t, prRepo.PRs["pr-open"].AssignedReviewers, []string{"u2"}, "Open PR reviewer replaced")
+	testutil.AssertLen(t, prRepo.PRs["pr-merged"].AssignedReviewers, 0, "Merged PR reviewer removed")
+}
+
+// TestPullRequestService_SelfCheck tests that self-check reports authors reviewing their own PRs
+// together with reviewers that no longer exist
+func TestPullRequestService_SelfCheck(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	prRepo.AddOpenPR("pr-1", "author", "author", "u2")
+	prRepo.AddOpenPR("pr-2", "author", "u2")
+	prRepo.GetOrphanedReviewersFunc = func(ctx context.Context) ([]domain.OrphanedReviewer, error) {
+		return []domain.OrphanedReviewer{
+			{PullRequestID: "pr-2", UserID: "ghost", Status: domain.PRStatusOpen},
+		}, nil
+	}
+	svc := newTestService(prRepo, testutil.NewMockUserRepository(), AssignmentConfig{})
+
+	violations, err := svc.SelfCheck(context.Background())
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, violations, []domain.InvariantViolation{
+		{Kind: domain.ViolationAuthorIsReviewer, PullRequestID: "pr-1", UserID: "author"},
+		{Kind: domain.ViolationMissingUser, PullRequestID: "pr-2", UserID: "ghost"},
+	}, "Violations")
+
+	// Consistent data yields an empty report
+	prRepo.PRs["pr-1"].AssignedReviewers = []string{"u2"}
+	prRepo.GetOrphanedReviewersFunc = nil
+
+	violations, err = svc.SelfCheck(context.Background())
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertLen(t, violations, 0, "Violations")
+}
+
+// TestPullRequestService_CreatePullRequest_DiverseReviewGroups tests that with DiverseReviewGroups
+// the two reviewers never share a review group when another group is available
+func TestPullRequestService_CreatePullRequest_DiverseReviewGroups(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		prRepo, userRepo := newTeamRepos("backend", "author")
+		userRepo.Users["api1"] = &domain.User{UserID: "api1", TeamName: "backend", IsActive: true, ReviewGroup: "api"}
+		userRepo.Users["api2"] = &domain.User{UserID: "api2", TeamName: "backend", IsActive: true, ReviewGroup: "api"}
+		userRepo.Users["db1"] = &domain.User{UserID: "db1", TeamName: "backend", IsActive: true, ReviewGroup: "db"}
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{Features: FeatureFlags{DiverseReviewGroups: true}})
+
+		pr, err := svc.CreatePullRequest(context.Background(), fmt.Sprintf("pr-%d", i), "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, pr.AssignedReviewers, 2, "Two reviewers assigned")
+		testutil.AssertContains(t, pr.AssignedReviewers, "db1", "The only db reviewer is always picked")
+	}
+}
+
+// assertSinglePrimary checks that a PR with reviewers has exactly one primary reviewer among them
+func assertSinglePrimary(t *testing.T, pr *domain.PullRequest) {
+	t.Helper()
+	if len(pr.AssignedReviewers) == 0 {
+		testutil.AssertEqual(t, pr.PrimaryReviewer, "", "PR without reviewers has no primary")
+		return
+	}
+	testutil.AssertContains(t, pr.AssignedReviewers, pr.PrimaryReviewer, "Primary reviewer is one of the assigned reviewers")
+}
+
+// TestPullRequestService_PrimaryReviewer tests that exactly one primary reviewer exists
+// across creation, reassignment of the primary and explicit changes
+func TestPullRequestService_PrimaryReviewer(t *testing.T) {
+	prRepo, userRepo := newTeamRepos("backend", "author", "u2", "u3", "u4")
+	svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+	ctx := context.Background()
+
+	pr, err := svc.CreatePullRequest(ctx, "pr-001", "Feature", "author", nil, nil, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertLen(t, pr.AssignedReviewers, 2, "Two reviewers assigned")
+	testutil.AssertEqual(t, pr.PrimaryReviewer, pr.AssignedReviewers[0], "First selected reviewer is primary")
+	assertSinglePrimary(t, prRepo.PRs["pr-001"])
+
+	// Reassigning the primary transfers the flag to the replacement
+	oldPrimary := pr.PrimaryReviewer
+	result, err := svc.ReassignReviewer(ctx, "pr-001", oldPrimary)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, result.PR.PrimaryReviewer, result.ReplacedBy, "Primary flag moves to the replacement")
+	testutil.AssertEqual(t, prRepo.PRs["pr-001"].PrimaryReviewer, result.ReplacedBy, "Stored primary moves to the replacement")
+	assertSinglePrimary(t, prRepo.PRs["pr-001"])
+
+	// Explicitly promote the other reviewer
+	var other string
+	for _, id := range prRepo.PRs["pr-001"].AssignedReviewers {
+		if id != result.ReplacedBy {
+			other = id
+		}
+	}
+	pr, err = svc.SetPrimaryReviewer(ctx, "pr-001", other)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, pr.PrimaryReviewer, other, "Primary changed")
+	assertSinglePrimary(t, prRepo.PRs["pr-001"])
+
+	// Removing the primary promotes a remaining reviewer
+	testutil.AssertNoError(t, prRepo.RemoveReviewer(ctx, "pr-001", other))
+	testutil.AssertEqual(t, prRepo.PRs["pr-001"].PrimaryReviewer, result.ReplacedBy, "Remaining reviewer promoted")
+	assertSinglePrimary(t, prRepo.PRs["pr-001"])
+}
+
+// TestPullRequestService_SetPrimaryReviewer_Errors tests domain errors of SetPrimaryReviewer
+func TestPullRequestService_SetPrimaryReviewer_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		prID    string
+		userID  string
+		status  domain.PRStatus
+		wantErr error
+	}{
+		{name: "user not assigned", prID: "pr-001", userID: "u9", status: domain.PRStatusOpen, wantErr: domain.ErrNotAssigned},
+		{name: "merged PR", prID: "pr-001", userID: "u3", status: domain.PRStatusMerged, wantErr: domain.ErrPRMerged},
+		{name: "PR not found", prID: "pr-missing", userID: "u3", status: domain.PRStatusOpen, wantErr: domain.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := testutil.NewMockPRRepository()
+			pr := prRepo.AddOpenPR("pr-001", "author", "u2", "u3")
+			pr.Status, pr.PrimaryReviewer = tt.status, "u2"
+			svc := newTestService(prRepo, testutil.NewMockUserRepository(), AssignmentConfig{})
+
+			_, err := svc.SetPrimaryReviewer(context.Background(), tt.prID, tt.userID)
+
+			testutil.AssertErrorIs(t, err, tt.wantErr)
+			testutil.AssertEqual(t, prRepo.PRs["pr-001"].PrimaryReviewer, "u2", "Primary unchanged")
+		})
+	}
+}
+
+// TestPullRequestService_CreatePullRequest_ExternalAuthor tests that an author without a team
+// gets reviewers from the default reviewer team or, failing that, from all teams
+func TestPullRequestService_CreatePullRequest_ExternalAuthor(t *testing.T) {
+	newRepos := func() (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("", "ext", "ext2")
+		userRepo.AddTeamMembers("core", "c1", "c2", "c3")
+		userRepo.AddTeamMembers("web", "w1")
+		return prRepo, userRepo
+	}
+
+	t.Run("draws from the default reviewer team", func(t *testing.T) {
+		prRepo, userRepo := newRepos()
+		teamRepo := testutil.NewMockTeamRepository()
+		teamRepo.Teams["core"] = &domain.Team{TeamName: "core", ReviewerCount: 3}
+		cfg := AssignmentConfig{ExternalReviewerTeam: "core", MinTeamSize: 5}
+		svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, ReviewerPolicy{}, cfg, zap.NewNop())
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Fix typo", "ext", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.ReviewerSource, domain.ReviewerSourceDefaultTeam, "Reviewer source")
+		testutil.AssertEqual(t, pr.AutoAssignSkipped, "", "Min team size does not apply")
+		testutil.AssertLen(t, pr.AssignedReviewers, 3, "Default team reviewer count applies")
+		for _, reviewerID := range pr.AssignedReviewers {
+			testutil.AssertEqual(t, userRepo.Users[reviewerID].TeamName, "core", "Reviewer from the default team")
+		}
+	})
+
+	t.Run("falls back to the global pool", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := newRepos()
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+			pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Fix typo", "ext", nil, nil, "")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, pr.ReviewerSource, domain.ReviewerSourceGlobalPool, "Reviewer source")
+			testutil.AssertLen(t, pr.AssignedReviewers, defaultReviewerCount, "Default reviewer count")
+			testutil.AssertNotContains(t, pr.AssignedReviewers, "ext2", "Teamless users are not reviewers")
+		}
+	})
+
+	t.Run("empty default team falls back to the global pool", func(t *testing.T) {
+		prRepo, userRepo := newRepos()
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{ExternalReviewerTeam: "ghosts"})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Fix typo", "ext", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.ReviewerSource, domain.ReviewerSourceGlobalPool, "Reviewer source")
+		testutil.AssertLen(t, pr.AssignedReviewers, defaultReviewerCount, "Reviewers assigned")
+	})
+
+	t.Run("team authors have no reviewer source", func(t *testing.T) {
+		prRepo, userRepo := newRepos()
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{ExternalReviewerTeam: "core"})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "w1", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.ReviewerSource, "", "No reviewer source")
+		testutil.AssertLen(t, pr.AssignedReviewers, 0, "Lone team member gets no reviewers")
+	})
+}
+
+// TestPullRequestService_CreatePullRequest_ReviewersPerPR tests that the configured reviewer
+// count replaces the built-in default, is clamped to the available candidates and yields to
+// a count set on the team
+func TestPullRequestService_CreatePullRequest_ReviewersPerPR(t *testing.T) {
+	newRepos := func(members int) (*testutil.MockPRRepository, *testutil.MockUserRepository, *testutil.MockTeamRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author")
+		for i := 1; i <= members; i++ {
+			userRepo.AddTeamMembers("backend", fmt.Sprintf("u%d", i))
+		}
+		return prRepo, userRepo, testutil.NewMockTeamRepository()
+	}
+
+	tests := []struct {
+		name          string
+		perPR         int
+		teamCount     int
+		members       int
+		wantReviewers int
+	}{
+		{name: "unset keeps the default", perPR: 0, members: 5, wantReviewers: defaultReviewerCount},
+		{name: "one reviewer", perPR: 1, members: 5, wantReviewers: 1},
+		{name: "three reviewers", perPR: 3, members: 5, wantReviewers: 3},
+		{name: "clamped to candidates", perPR: 3, members: 2, wantReviewers: 2},
+		{name: "team count wins", perPR: 1, teamCount: 3, members: 5, wantReviewers: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo, userRepo, teamRepo := newRepos(tt.members)
+			if tt.teamCount > 0 {
+				teamRepo.Teams["backend"] = &domain.Team{TeamName: "backend", ReviewerCount: tt.teamCount}
+			}
+			svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, ReviewerPolicy{ReviewersPerPR: tt.perPR}, AssignmentConfig{}, zap.NewNop())
+
+			pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertLen(t, pr.AssignedReviewers, tt.wantReviewers, "Reviewers assigned")
+		})
+	}
+}
+
+// TestPullRequestService_ResetReviewers tests that resetting replaces the whole reviewer set,
+// never picks the author and rejects merged PRs
+func TestPullRequestService_ResetReviewers(t *testing.T) {
+	newRepos := func(status domain.PRStatus) (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "u2", "u3", "u4", "u5")
+		pr := prRepo.AddOpenPR("pr-001", "author", "u2", "u3")
+		pr.Status, pr.PrimaryReviewer = status, "u2"
+		return prRepo, userRepo
+	}
+
+	t.Run("replaces the old set entirely", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := newRepos(domain.PRStatusOpen)
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+			pr, err := svc.ResetReviewers(context.Background(), "pr-001")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u4", "u5"}, "New reviewer set")
+			testutil.AssertEqual(t, prRepo.PRs["pr-001"].AssignedReviewers, []string{"u4", "u5"}, "Stored reviewer set")
+			testutil.AssertEqual(t, pr.PrimaryReviewer, "u4", "First new reviewer is primary")
+			assertSinglePrimary(t, prRepo.PRs["pr-001"])
+		}
+	})
+
+	t.Run("tops up from previous reviewers and never picks the author", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := newRepos(domain.PRStatusOpen)
+			userRepo.Users["u5"].IsActive = false
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+			pr, err := svc.ResetReviewers(context.Background(), "pr-001")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertLen(t, pr.AssignedReviewers, 2, "Slots are filled")
+			testutil.AssertEqual(t, pr.AssignedReviewers[0], "u4", "New candidate picked first")
+			testutil.AssertNotContains(t, pr.AssignedReviewers, "author", "Author is never a reviewer")
+		}
+	})
+
+	t.Run("rejects merged PR", func(t *testing.T) {
+		prRepo, userRepo := newRepos(domain.PRStatusMerged)
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		_, err := svc.ResetReviewers(context.Background(), "pr-001")
+
+		testutil.AssertErrorIs(t, err, domain.ErrPRMerged)
+		testutil.AssertEqual(t, prRepo.PRs["pr-001"].AssignedReviewers, []string{"u2", "u3"}, "Reviewers untouched")
+	})
+}
+
+// TestPullRequestService_CreatePullRequest_BackupTeam tests that a short-staffed team borrows
+// reviewers from its backup team and never from unrelated teams
+func TestPullRequestService_CreatePullRequest_BackupTeam(t *testing.T) {
+	newRepos := func() (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "u2")
+		userRepo.AddTeamMembers("platform", "p1")
+		userRepo.Users["p2"] = &domain.User{UserID: "p2", TeamName: "platform", IsActive: false}
+		userRepo.AddTeamMembers("web", "w1")
+		return prRepo, userRepo
+	}
+
+	t.Run("borrows from backup team", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := newRepos()
+			teamRepo := testutil.NewMockTeamRepository()
+			teamRepo.Teams["backend"] = &domain.Team{TeamName: "backend", BackupTeamName: "platform"}
+			svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, ReviewerPolicy{}, AssignmentConfig{}, zap.NewNop())
+
+			pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u2", "p1"}, "Home reviewer first, then active backup reviewer")
+			testutil.AssertEqual(t, pr.PrimaryReviewer, "u2", "Home reviewer is primary")
+		}
+	})
+
+	t.Run("no backup team leaves slots empty", func(t *testing.T) {
+		prRepo, userRepo := newRepos()
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u2"}, "Only home reviewer")
+	})
+
+	t.Run("full home team does not borrow", func(t *testing.T) {
+		prRepo, userRepo := newRepos()
+		userRepo.AddTeamMembers("backend", "u3")
+		teamRepo := testutil.NewMockTeamRepository()
+		teamRepo.Teams["backend"] = &domain.Team{TeamName: "backend", BackupTeamName: "platform"}
+		svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, ReviewerPolicy{}, AssignmentConfig{}, zap.NewNop())
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertNotContains(t, pr.AssignedReviewers, "p1", "Backup team not used")
+	})
+}
+
+// TestPullRequestService_AlphabeticalStrategy tests that the alphabetical selector
+// deterministically picks the first eligible members by username
+func TestPullRequestService_AlphabeticalStrategy(t *testing.T) {
+	newRepos := func() (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "u1", "u2", "u3", "u4", "u5")
+		for id, username := range map[string]string{"author": "Aaron", "u1": "Dave", "u2": "Carol", "u3": "Bob", "u4": "Alice", "u5": "Eve"} {
+			userRepo.Users[id].Username = username
+		}
+		userRepo.Users["u4"].IsActive = false
+		return prRepo, userRepo
+	}
+	cfg := AssignmentConfig{Selector: alphabeticalSelector{}}
+
+	t.Run("create picks first eligible usernames", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := newRepos()
+			svc := newTestService(prRepo, userRepo, cfg)
+
+			pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u3", "u2"}, "Bob and Carol: author and inactive Alice are skipped")
+		}
+	})
+
+	t.Run("reassign picks next eligible username", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := newRepos()
+			svc := newTestService(prRepo, userRepo, cfg)
+			_, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+			testutil.AssertNoError(t, err)
+
+			result, err := svc.ReassignReviewer(context.Background(), "pr-1", "u3")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, result.ReplacedBy, "u1", "Dave is the next eligible by username")
+		}
+	})
+}
+
+// TestNewReviewerSelector tests parsing of REVIEWER_STRATEGY
+func TestNewReviewerSelector(t *testing.T) {
+	selector, err := NewReviewerSelector("", nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, selector, ReviewerSelector(randomSelector{}), "empty strategy is random")
+
+	selector, err = NewReviewerSelector(" alphabetical ", nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, selector, ReviewerSelector(alphabeticalSelector{}), "alphabetical strategy")
+
+	_, err = NewReviewerSelector("round_robin", nil)
+	testutil.AssertTrue(t, err != nil, "unknown strategy must be rejected")
+}
+
+// TestPullRequestService_CreatePullRequest_SelectionStrategy tests that the create response
+// names the configured strategy and omits it when auto-assignment is skipped
+func TestPullRequestService_CreatePullRequest_SelectionStrategy(t *testing.T) {
+	tests := []struct {
+		strategy string
+		want     string
+	}{
+		{strategy: "", want: StrategyRandom},
+		{strategy: StrategyAlphabetical, want: StrategyAlphabetical},
+		{strategy: StrategyThroughput, want: StrategyThroughput},
+		{strategy: StrategyLeastLoaded, want: StrategyLeastLoaded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			prRepo, userRepo := newTeamRepos("backend", "u1", "u2")
+			selector, err := NewReviewerSelector(tt.strategy, prRepo)
+			testutil.AssertNoError(t, err)
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{Selector: selector})
+
+			pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "u1", nil, nil, "")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, pr.SelectionStrategy, tt.want, "Selection strategy")
+		})
+	}
+
+	t.Run("omitted when auto-assignment is skipped", func(t *testing.T) {
+		userRepo := testutil.NewMockUserRepository()
+		userRepo.AddTeamMembers("backend", "u1")
+		svc := newTestService(testutil.NewMockPRRepository(), userRepo, AssignmentConfig{MinTeamSize: 3, Selector: alphabeticalSelector{}})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "u1", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.SelectionStrategy, "", "No strategy without selection")
+	})
+}
+
+// TestPullRequestService_MergedPRMutations tests that every reviewer mutation
+// rejects a merged PR with the same error
+func TestPullRequestService_MergedPRMutations(t *testing.T) {
+	newService := func() (*PullRequestService, *testutil.MockPRRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "u1", "u2", "u3")
+		pr := prRepo.AddOpenPR("pr-merged", "author", "u1", "u2")
+		pr.Status, pr.PrimaryReviewer = domain.PRStatusMerged, "u1"
+		return newTestService(prRepo, userRepo, AssignmentConfig{}), prRepo
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(svc *PullRequestService) error
+	}{
+		{"add", func(svc *PullRequestService) error {
+			_, err := svc.ForceAssignReviewer(context.Background(), "pr-merged", "u3")
+			return err
+		}},
+		{"reassign", func(svc *PullRequestService) error {
+			_, err := svc.ReassignReviewer(context.Background(), "pr-merged", "u1")
+			return err
+		}},
+		{"reset", func(svc *PullRequestService) error {
+			_, err := svc.ResetReviewers(context.Background(), "pr-merged")
+			return err
+		}},
+		{"set primary", func(svc *PullRequestService) error {
+			_, err := svc.SetPrimaryReviewer(context.Background(), "pr-merged", "u2")
+			return err
+		}},
+		{"remove", func(svc *PullRequestService) error {
+			// There is no manual remove endpoint; the repository guards removal itself
+			return svc.prRepo.RemoveReviewer(context.Background(), "pr-merged", "u1")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, prRepo := newService()
+
+			err := tt.mutate(svc)
+
+			testutil.AssertErrorIs(t, err, domain.ErrPRMerged)
+			testutil.AssertEqual(t, domain.MapErrorToCode(err), domain.CodePRMerged, "Error code")
+			testutil.AssertEqual(t, prRepo.PRs["pr-merged"].AssignedReviewers, []string{"u1", "u2"}, "Reviewers unchanged")
+			testutil.AssertEqual(t, prRepo.PRs["pr-merged"].PrimaryReviewer, "u1", "Primary unchanged")
+		})
+	}
+}
+
+// TestPullRequestService_ReassignReviewer_AuthorTeamOnly tests that strict mode replaces
+// reviewers only from the author's team and fails when that team is exhausted
+func TestPullRequestService_ReassignReviewer_AuthorTeamOnly(t *testing.T) {
+	newRepos := func() (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("frontend", "author", "f1")
+		userRepo.AddTeamMembers("backend", "b1", "b2")
+		prRepo.AddOpenPR("pr-1", "author", "b1")
+		return prRepo, userRepo
+	}
+	cfg := AssignmentConfig{Features: FeatureFlags{ReassignAuthorTeamOnly: true}}
+
+	t.Run("replaces from author team", func(t *testing.T) {
+		prRepo, userRepo := newRepos()
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		result, err := svc.ReassignReviewer(context.Background(), "pr-1", "b1")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, result.ReplacedBy, "f1", "Replacement from the author's team")
+	})
+
+	t.Run("errors when author team is exhausted", func(t *testing.T) {
+		prRepo, userRepo := newRepos()
+		userRepo.Users["f1"].IsActive = false
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		_, err := svc.ReassignReviewer(context.Background(), "pr-1", "b1")
+
+		testutil.AssertErrorIs(t, err, domain.ErrNoCandidate)
+		testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"b1"}, "Reviewer kept; b2 from reviewer team not used")
+	})
+
+	t.Run("errors for teamless author", func(t *testing.T) {
+		prRepo, userRepo := newRepos()
+		userRepo.Users["author"].TeamName = ""
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		_, err := svc.ReassignReviewer(context.Background(), "pr-1", "b1")
+
+		testutil.AssertErrorIs(t, err, domain.ErrNoCandidate)
+	})
+}
+
+// TestPullRequestService_ReassignReviewerTo tests that an explicit target replaces the old
+// reviewer (taking over the primary flag) and that ineligible targets are rejected
+func TestPullRequestService_ReassignReviewerTo(t *testing.T) {
+	newRepos := func() (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "u1", "u2", "u3", "u4")
+		userRepo.Users["idle"] = &domain.User{UserID: "idle", TeamName: "backend", IsActive: false}
+		userRepo.AddTeamMembers("frontend", "f1")
+		prRepo.AddOpenPR("pr-1", "author", "u1", "u2").PrimaryReviewer = "u1"
+		return prRepo, userRepo
+	}
+
+	t.Run("replaces with explicit target", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := newRepos()
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+			result, err := svc.ReassignReviewerTo(context.Background(), "pr-1", "u1", "u4")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, result.ReplacedBy, "u4", "Replaced by the chosen target")
+			testutil.AssertEqual(t, result.AfterReviewers, []string{"u4", "u2"}, "Reviewers after reassignment")
+			testutil.AssertEqual(t, result.PR.PrimaryReviewer, "u4", "Primary flag moves to the target")
+		}
+	})
+
+	t.Run("empty target picks a random candidate", func(t *testing.T) {
+		seen := map[string]bool{}
+		for i := 0; i < 50; i++ {
+			prRepo, userRepo := newRepos()
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+			result, err := svc.ReassignReviewerTo(context.Background(), "pr-1", "u1", "")
+
+			testutil.AssertNoError(t, err)
+			seen[result.ReplacedBy] = true
+		}
+		testutil.AssertEqual(t, seen, map[string]bool{"u3": true, "u4": true}, "Both eligible candidates are picked")
+	})
+
+	tests := []struct {
+		name    string
+		target  string
+		wantErr error
+	}{
+		{"author", "author", domain.ErrAuthorReviewer},
+		{"already assigned", "u2", domain.ErrAlreadyAssigned},
+		{"unknown user", "ghost", domain.ErrNotFound},
+		{"inactive user", "idle", domain.ErrTargetIneligible},
+		{"other team", "f1", domain.ErrTargetIneligible},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			prRepo, userRepo := newRepos()
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+			_, err := svc.ReassignReviewerTo(context.Background(), "pr-1", "u1", tt.target)
+
+			testutil.AssertTrue(t, errors.Is(err, tt.wantErr), "Error is "+tt.wantErr.Error())
+			testutil.AssertFalse(t, errors.Is(err, domain.ErrNoCandidate), "ErrNoCandidate is only for random replacement")
+			testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"u1", "u2"}, "Reviewers unchanged")
+		})
+	}
+}
+
+// TestPullRequestService_ReviewConflicts tests that a user in conflict with the author is never
+// assigned to the author's PRs, while non-conflicting teammates are
+func TestPullRequestService_ReviewConflicts(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		prRepo, userRepo := newTeamRepos("backend", "author", "rival", "u1", "u2", "u3")
+		userRepo.Conflicts = []domain.ReviewConflict{domain.NewReviewConflict("rival", "author")}
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, pr.AssignedReviewers, 2, "Two reviewers assigned")
+		testutil.AssertFalse(t, pr.HasReviewer("rival"), "Conflicting user is not assigned")
+
+		// Replacement skips the conflicting user as well, leaving a single teammate
+		result, err := svc.ReassignReviewer(context.Background(), "pr-1", pr.AssignedReviewers[0])
+		testutil.AssertNoError(t, err)
+		testutil.AssertTrue(t, result.ReplacedBy != "rival", "Conflicting user is not a replacement")
+
+		// The conflict only concerns the author's PRs: rival still reviews others
+		userRepo.Users["u1"].IsActive, userRepo.Users["u2"].IsActive = false, false
+		other, err := svc.CreatePullRequest(context.Background(), "pr-2", "Other", "u3", nil, nil, "")
+		testutil.AssertNoError(t, err)
+		testutil.AssertTrue(t, other.HasReviewer("rival"), "Rival reviews a non-conflicting author")
+	}
+}
+
+// TestPullRequestService_ReviewerHistory tests that mutation paths record who was
+// ever assigned, including reviewers that were reassigned away
+func TestPullRequestService_ReviewerHistory(t *testing.T) {
+	prRepo, userRepo := newTeamRepos("backend", "author", "u1", "u2", "u3")
+	svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+	pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertLen(t, pr.AssignedReviewers, 2, "Two reviewers assigned")
+	first, second := pr.AssignedReviewers[0], pr.AssignedReviewers[1]
+
+	result, err := svc.ReassignReviewer(context.Background(), "pr-1", first)
+	testutil.AssertNoError(t, err)
+
+	history, err := svc.GetReviewerHistory(context.Background(), "pr-1")
+	testutil.AssertNoError(t, err)
+	testutil.AssertLen(t, history, 3, "Two assign records and one reassign record")
+
+	testutil.AssertEqual(t, history[0].Action, domain.ReviewerActionAssign)
+	testutil.AssertEqual(t, history[0].UserID, first)
+	testutil.AssertEqual(t, history[1].Action, domain.ReviewerActionAssign)
+	testutil.AssertEqual(t, history[1].UserID, second)
+	testutil.AssertEqual(t, history[2].Action, domain.ReviewerActionReassign)
+	testutil.AssertEqual(t, history[2].UserID, first, "Reassigned-away reviewer kept in history")
+	testutil.AssertEqual(t, history[2].ReplacedBy, result.ReplacedBy)
+
+	t.Run("unknown PR", func(t *testing.T) {
+		_, err := svc.GetReviewerHistory(context.Background(), "pr-missing")
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestPullRequestService_UndoAssignment tests that the latest assignment change is reverted
+// within the undo window and rejected once the window has passed
+func TestPullRequestService_UndoAssignment(t *testing.T) {
+	newService := func() (*PullRequestService, *testutil.MockPRRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "u1", "u2", "u3")
+		cfg := AssignmentConfig{Selector: alphabeticalSelector{}, UndoWindow: 15 * time.Minute}
+		return newTestService(prRepo, userRepo, cfg), prRepo
+	}
+
+	t.Run("recent reassignment reverted", func(t *testing.T) {
+		svc, prRepo := newService()
+		_, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+		testutil.AssertNoError(t, err)
+		reassigned, err := svc.ReassignReviewer(context.Background(), "pr-1", "u1")
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, reassigned.ReplacedBy, "u3")
+
+		result, err := svc.UndoAssignment(context.Background(), "pr-1")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, result.PR.AssignedReviewers, []string{"u1", "u2"}, "Previous reviewer restored")
+		testutil.AssertLen(t, result.Undone, 1, "Only the reassignment is undone")
+		testutil.AssertEqual(t, result.Undone[0].Action, domain.ReviewerActionReassign)
+
+		last := prRepo.ReviewerHistory[len(prRepo.ReviewerHistory)-1]
+		testutil.AssertEqual(t, last.Action, domain.ReviewerActionUndo)
+		testutil.AssertEqual(t, last.UserID, "u3", "Replacement removed")
+		testutil.AssertEqual(t, last.ReplacedBy, "u1", "Previous reviewer restored")
+
+		_, err = svc.UndoAssignment(context.Background(), "pr-1")
+		testutil.AssertTrue(t, errors.Is(err, domain.ErrNotFound), "An undo is not undone again")
+	})
+
+	t.Run("old reassignment rejected", func(t *testing.T) {
+		svc, prRepo := newService()
+		_, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+		testutil.AssertNoError(t, err)
+		_, err = svc.ReassignReviewer(context.Background(), "pr-1", "u1")
+		testutil.AssertNoError(t, err)
+		svc.now = func() time.Time { return time.Now().Add(time.Hour) }
+
+		_, err = svc.UndoAssignment(context.Background(), "pr-1")
+
+		testutil.AssertErrorIs(t, err, domain.ErrUndoExpired)
+		testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"u3", "u2"}, "Reviewers unchanged")
+	})
+
+	t.Run("auto-assignment on create removed", func(t *testing.T) {
+		svc, _ := newService()
+		_, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+		testutil.AssertNoError(t, err)
+
+		result, err := svc.UndoAssignment(context.Background(), "pr-1")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, result.PR.AssignedReviewers, 0, "All auto-assigned reviewers removed")
+		testutil.AssertLen(t, result.Undone, 2, "Both assignments undone")
+	})
+
+	t.Run("failed removal rolls back the whole undo", func(t *testing.T) {
+		svc, prRepo := newService()
+		txManager := &testutil.MockTxManager{}
+		svc.txManager = txManager
+		_, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+		testutil.AssertNoError(t, err)
+		historyLen := len(prRepo.ReviewerHistory)
+
+		errRemove := errors.New("connection reset")
+		var calls int
+		prRepo.RemoveReviewerFunc = func(ctx context.Context, prID, reviewerID string) error {
+			calls++
+			testutil.AssertTrue(t, testutil.InMockTransaction(ctx), "Removal runs inside the transaction")
+			if calls == 2 {
+				return errRemove
+			}
+			return nil
+		}
+
+		_, err = svc.UndoAssignment(context.Background(), "pr-1")
+
+		testutil.AssertTrue(t, errors.Is(err, errRemove), "expected removal error, got %v", err)
+		testutil.AssertEqual(t, txManager.Rollbacks, 1, "Undo rolled back")
+		testutil.AssertEqual(t, txManager.Commits, 1, "Only the creation committed")
+		testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"u1", "u2"}, "Reviewers unchanged")
+		testutil.AssertLen(t, prRepo.ReviewerHistory, historyLen, "No undo recorded")
+
+		// The assignment is still in the history, so a retry succeeds
+		prRepo.RemoveReviewerFunc = nil
+		result, err := svc.UndoAssignment(context.Background(), "pr-1")
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, result.PR.AssignedReviewers, 0, "Retry removes both reviewers")
+	})
+
+	t.Run("disabled window", func(t *testing.T) {
+		svc, _ := newService()
+		svc.cfg.UndoWindow = 0
+		_, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+		testutil.AssertNoError(t, err)
+
+		_, err = svc.UndoAssignment(context.Background(), "pr-1")
+
+		testutil.AssertErrorIs(t, err, domain.ErrUndoExpired)
+	})
+}
+
+// TestPullRequestService_SelectionDecisions tests that creating a PR records its selection
+// decision in the background, that dry runs record nothing and that a failed write does
+// not fail the creation
+func TestPullRequestService_SelectionDecisions(t *testing.T) {
+	newService := func() (*PullRequestService, *testutil.MockPRRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "u1", "u2", "u3")
+		cfg := AssignmentConfig{Selector: alphabeticalSelector{}}
+		return newTestService(prRepo, userRepo, cfg), prRepo
+	}
+
+	t.Run("recorded on create", func(t *testing.T) {
+		svc, _ := newService()
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+		testutil.AssertNoError(t, err)
+		svc.WaitSelectionDecisions()
+
+		decisions, err := svc.GetSelectionDecisions(context.Background(), "pr-1")
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, decisions, 1, "One decision per created PR")
+		testutil.AssertEqual(t, decisions[0].PullRequestID, "pr-1")
+		testutil.AssertEqual(t, decisions[0].PoolSize, 4, "Whole team is the pool")
+		testutil.AssertEqual(t, decisions[0].Reviewers, pr.AssignedReviewers)
+		testutil.AssertEqual(t, decisions[0].Strategy, pr.SelectionStrategy)
+		testutil.AssertFalse(t, decisions[0].CreatedAt.IsZero(), "Timestamp recorded")
+	})
+
+	t.Run("dry run not recorded", func(t *testing.T) {
+		svc, prRepo := newService()
+
+		_, err := svc.PreviewPullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+		testutil.AssertNoError(t, err)
+		svc.WaitSelectionDecisions()
+
+		testutil.AssertLen(t, prRepo.SelectionDecisions, 0, "Preview records no decision")
+	})
+
+	t.Run("write failure ignored", func(t *testing.T) {
+		svc, prRepo := newService()
+		prRepo.RecordSelectionDecisionFunc = func(ctx context.Context, decision *domain.SelectionDecision) error {
+			return errors.New("database unavailable")
+		}
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+		svc.WaitSelectionDecisions()
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, pr.AssignedReviewers, 2, "Reviewers assigned regardless")
+	})
+
+	t.Run("unknown PR", func(t *testing.T) {
+		svc, _ := newService()
+
+		_, err := svc.GetSelectionDecisions(context.Background(), "pr-missing")
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestPullRequestService_EligibleReviewers tests that the author, current reviewers,
+// inactive members and excluded usernames are not offered as reviewers
+func TestPullRequestService_EligibleReviewers(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["author"] = &domain.User{UserID: "author", Username: "Alice", TeamName: "backend", IsActive: true}
+	userRepo.Users["u1"] = &domain.User{UserID: "u1", Username: "Bob", TeamName: "backend", IsActive: true}
+	userRepo.Users["u2"] = &domain.User{UserID: "u2", Username: "Dave", TeamName: "backend", IsActive: true}
+	userRepo.Users["u3"] = &domain.User{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true}
+	userRepo.Users["u4"] = &domain.User{UserID: "u4", Username: "Erin", TeamName: "backend", IsActive: false}
+	userRepo.Users["bot"] = &domain.User{UserID: "bot", Username: "ci-bot", TeamName: "backend", IsActive: true}
+	userRepo.Users["other"] = &domain.User{UserID: "other", Username: "Frank", TeamName: "frontend", IsActive: true}
+	prRepo.AddOpenPR("pr-1", "author", "u1")
+	cfg := AssignmentConfig{ExcludeUsername: regexp.MustCompile(`-bot$`)}
+	svc := newTestService(prRepo, userRepo, cfg)
+
+	reviewers, err := svc.EligibleReviewers(context.Background(), "pr-1")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, reviewers, []EligibleReviewer{
+		{UserID: "u3", Username: "Carol"},
+		{UserID: "u2", Username: "Dave"},
+	})
+
+	t.Run("no candidates", func(t *testing.T) {
+		prRepo.AddOpenPR("pr-2", "author", "u1", "u2", "u3")
+
+		reviewers, err := svc.EligibleReviewers(context.Background(), "pr-2")
+		testutil.AssertNoError(t, err)
+		testutil.AssertTrue(t, reviewers != nil, "Empty list, not nil")
+		testutil.AssertLen(t, reviewers, 0, "No eligible reviewers")
+	})
+
+	t.Run("unknown PR", func(t *testing.T) {
+		_, err := svc.EligibleReviewers(context.Background(), "pr-missing")
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestPullRequestService_SLABreaches tests the SLA cutoff passed to the repository
+// and how far past the SLA each PR is reported
+func TestPullRequestService_SLABreaches(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	prRepo := testutil.NewMockPRRepository()
+	var cutoff time.Time
+	prRepo.GetOpenAssignedBeforeFunc = func(ctx context.Context, before time.Time) ([]domain.SLABreach, error) {
+		cutoff = before
+		return []domain.SLABreach{
+			{PullRequestID: "pr-old", OldestAssignedAt: now.Add(-30 * time.Hour)},
+		}, nil
+	}
+	cfg := AssignmentConfig{ReviewSLA: 24 * time.Hour}
+	svc := newTestService(prRepo, testutil.NewMockUserRepository(), cfg)
+	svc.now = func() time.Time { return now }
+
+	breaches, err := svc.SLABreaches(context.Background())
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, cutoff, now.Add(-24*time.Hour))
+	testutil.AssertLen(t, breaches, 1, "One breaching PR")
+	testutil.AssertEqual(t, breaches[0].BreachedHours, 6.0)
+
+	t.Run("SLA not set", func(t *testing.T) {
+		cutoff = time.Time{}
+		svc := newTestService(prRepo, testutil.NewMockUserRepository(), AssignmentConfig{})
+
+		breaches, err := svc.SLABreaches(context.Background())
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, breaches, 0, "No breaches without an SLA")
+		testutil.AssertTrue(t, cutoff.IsZero(), "Repository not queried")
+	})
+}
+
+// TestResetEntries tests that a reset records only the reviewers that actually changed
+func TestResetEntries(t *testing.T) {
+	entries := resetEntries("pr-1", []string{"u1", "u2"}, []string{"u2", "u3"})
+
+	testutil.AssertLen(t, entries, 2, "One removal and one assignment")
+	testutil.AssertEqual(t, entries[0], removeEntry("pr-1", "u1"))
+	testutil.AssertEqual(t, entries[1].Action, domain.ReviewerActionAssign)
+	testutil.AssertEqual(t, entries[1].UserID, "u3")
+}
+
+// TestPullRequestService_CreatePullRequest_Warnings tests that non-fatal selection
+// issues are reported as warnings while the PR is still created
+func TestPullRequestService_CreatePullRequest_Warnings(t *testing.T) {
+	t.Run("single candidate team", func(t *testing.T) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "u1")
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u1"})
+		testutil.AssertEqual(t, pr.Warnings, []string{"only one reviewer available"})
+		_, persisted := prRepo.PRs["pr-1"]
+		testutil.AssertTrue(t, persisted, "PR persisted despite warning")
+	})
+
+	t.Run("full team has no warnings", func(t *testing.T) {
+		userRepo := testutil.NewMockUserRepository()
+		userRepo.AddTeamMembers("backend", "author", "u1", "u2")
+		svc := newTestService(testutil.NewMockPRRepository(), userRepo, AssignmentConfig{})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, pr.Warnings, 0, "No warnings expected")
+	})
+
+	t.Run("reviewer reaching daily limit", func(t *testing.T) {
+		prRepo := testutil.NewMockPRRepository()
+		prRepo.GetAssignmentCountsSinceFunc = func(ctx context.Context, since time.Time) (map[string]int, error) {
+			return map[string]int{"u1": 1}, nil
+		}
+		userRepo := testutil.NewMockUserRepository()
+		userRepo.AddTeamMembers("backend", "author", "u1", "u2")
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{MaxDailyAssignments: 2})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.Warnings, []string{"reviewer u1 has reached the daily assignment limit"})
+	})
+}
+
+// TestMockPRRepository_DeduplicatesReviewers tests that the mock mirrors the pr_reviewers
+// unique constraint, so tests cannot pass on duplicate reviewers that prod would reject
+func TestMockPRRepository_DeduplicatesReviewers(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	prRepo.PRs["pr-1"] = &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "author", Status: domain.PRStatusOpen}
+
+	testutil.AssertNoError(t, prRepo.AddReviewer(context.Background(), "pr-1", "u1"))
+	testutil.AssertErrorIs(t, prRepo.AddReviewer(context.Background(), "pr-1", "u1"), domain.ErrAlreadyAssigned)
+	testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"u1"}, "Added twice, stored once")
+
+	testutil.AssertNoError(t, prRepo.AssignReviewers(context.Background(), "pr-1", []string{"u1", "u2", "u2"}))
+	testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"u1", "u2"}, "Assigned duplicates skipped")
+
+	testutil.AssertTrue(t, prRepo.PRs["pr-1"].HasReviewer("u2"), "u2 assigned")
+	testutil.AssertFalse(t, prRepo.PRs["pr-1"].HasReviewer("u3"), "u3 not assigned")
+}
+
+// TestPullRequestService_ReassignReviewer_IgnoresReviewerCount tests that reassignment is a
+// strict 1-for-1 swap: a team wanting 3 reviewers with a single replacement available still
+// succeeds, and the under-count is left for ResetReviewers to top up
+func TestPullRequestService_ReassignReviewer_IgnoresReviewerCount(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+	teamRepo := testutil.NewMockTeamRepository()
+	teamRepo.Teams["backend"] = &domain.Team{TeamName: "backend", ReviewerCount: 3}
+	userRepo.AddTeamMembers("backend", "author", "r1", "r2", "c1")
+	prRepo.AddOpenPR("pr-1", "author", "r1", "r2")
+	svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, ReviewerPolicy{}, AssignmentConfig{}, zap.NewNop())
+
+	result, err := svc.ReassignReviewer(context.Background(), "pr-1", "r1")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, result.ReplacedBy, "c1")
+	testutil.AssertEqual(t, result.AfterReviewers, []string{"c1", "r2"}, "Only the departing reviewer is swapped")
+
+	t.Run("single reviewer stays single", func(t *testing.T) {
+		prRepo.AddOpenPR("pr-2", "author", "r1")
+
+		result, err := svc.ReassignReviewer(context.Background(), "pr-2", "r1")
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, result.AfterReviewers, 1, "Reassign does not top up to the team count")
+		testutil.AssertFalse(t, result.PR.HasReviewer("r1"), "Departing reviewer removed")
+
+		pr, err := svc.ResetReviewers(context.Background(), "pr-2")
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, pr.AssignedReviewers, 3, "Reset tops up to the team count")
+	})
+}
+
+// TestPullRequestService_ReassignReviewer_ConcurrentReplacement tests that when the chosen
+// replacement was assigned by a concurrent request, selection is retried on fresh state
+func TestPullRequestService_ReassignReviewer_ConcurrentReplacement(t *testing.T) {
+	setup := func() (*testutil.MockPRRepository, *PullRequestService) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "r1", "r2", "c1", "c2")
+		prRepo.AddOpenPR("pr-1", "author", "r1", "r2")
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+		return prRepo, svc
+	}
+
+	t.Run("retries with another candidate", func(t *testing.T) {
+		prRepo, svc := setup()
+		calls := 0
+		prRepo.ReassignReviewerFunc = func(ctx context.Context, prID, oldID, newID string) error {
+			calls++
+			pr := prRepo.PRs[prID]
+			if calls == 1 {
+				// A concurrent reassign of r2 has just taken the same candidate
+				pr.AssignedReviewers = []string{"r1", newID}
+				return domain.ErrAlreadyAssigned
+			}
+			pr.AssignedReviewers[0] = newID
+			return nil
+		}
+
+		result, err := svc.ReassignReviewer(context.Background(), "pr-1", "r1")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, calls, 2, "Second attempt succeeds")
+		testutil.AssertLen(t, result.AfterReviewers, 2, "Two reviewers after reassignment")
+		testutil.AssertTrue(t, result.AfterReviewers[0] != result.AfterReviewers[1], "No duplicate reviewer")
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		prRepo, svc := setup()
+		calls := 0
+		prRepo.ReassignReviewerFunc = func(ctx context.Context, prID, oldID, newID string) error {
+			calls++
+			return domain.ErrAlreadyAssigned
+		}
+
+		_, err := svc.ReassignReviewer(context.Background(), "pr-1", "r1")
+
+		testutil.AssertErrorIs(t, err, domain.ErrAlreadyAssigned)
+		testutil.AssertEqual(t, calls, maxReassignAttempts, "Attempts are bounded")
+	})
+}
+
+// staleSelector simulates a faulty strategy that ranks the given users ahead of the
+// candidates even though they were excluded from the pool
+type staleSelector struct {
+	first []string
+}
+
+func (staleSelector) Name() string { return "stale" }
+
+func (s staleSelector) Order(_ context.Context, candidates []string, _ []domain.User, _ int) []string {
+	return append(append([]string{}, s.first...), candidates...)
+}
+
+// TestPullRequestService_ReassignReviewer_DuplicateSelection tests that a replacement who is
+// already a reviewer is discarded and selection is retried, and that ErrNoCandidate is
+// returned only once the pool is exhausted
+func TestPullRequestService_ReassignReviewer_DuplicateSelection(t *testing.T) {
+	setup := func(selector ReviewerSelector, members ...string) (*testutil.MockPRRepository, *PullRequestService) {
+		prRepo, userRepo := newTeamRepos("backend", append([]string{"author", "r1", "r2"}, members...)...)
+		prRepo.AddOpenPR("pr-1", "author", "r1", "r2")
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{Selector: selector})
+		return prRepo, svc
+	}
+
+	t.Run("retries past current reviewers", func(t *testing.T) {
+		_, svc := setup(staleSelector{first: []string{"r1", "r2"}}, "c1")
+
+		result, err := svc.ReassignReviewer(context.Background(), "pr-1", "r1")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, result.ReplacedBy, "c1", "First candidate that is not a reviewer")
+		testutil.AssertEqual(t, result.AfterReviewers, []string{"c1", "r2"})
+	})
+
+	t.Run("exhausted pool", func(t *testing.T) {
+		prRepo, svc := setup(staleSelector{first: []string{"r2", "r1"}})
+		prRepo.ReassignReviewerFunc = func(ctx context.Context, prID, oldID, newID string) error {
+			t.Fatalf("unexpected reassignment to %s", newID)
+			return nil
+		}
+
+		_, err := svc.ReassignReviewer(context.Background(), "pr-1", "r1")
+
+		testutil.AssertErrorIs(t, err, domain.ErrNoCandidate)
+	})
+}
+
+// TestPullRequestService_BulkActivateTeam tests that a team is brought back online and,
+// when requested, its understaffed open PRs gain reviewers without losing current ones
+func TestPullRequestService_BulkActivateTeam(t *testing.T) {
+	setup := func() (*testutil.MockPRRepository, *testutil.MockUserRepository, *PullRequestService) {
+		prRepo, userRepo := newTeamRepos("backend", "author")
+		for _, id := range []string{"r1", "r2", "r3"} {
+			userRepo.Users[id] = &domain.User{UserID: id, TeamName: "backend", IsActive: false}
+		}
+		userRepo.AddTeamMembers("frontend", "other")
+		prRepo.PRs["pr-empty"] = &domain.PullRequest{PullRequestID: "pr-empty", AuthorID: "author", Status: domain.PRStatusOpen}
+		prRepo.AddOpenPR("pr-short", "author", "r1")
+		prRepo.PRs["pr-merged"] = &domain.PullRequest{PullRequestID: "pr-merged", AuthorID: "author", Status: domain.PRStatusMerged}
+		prRepo.PRs["pr-foreign"] = &domain.PullRequest{PullRequestID: "pr-foreign", AuthorID: "other", Status: domain.PRStatusOpen}
+		prRepo.AuthorTeams = map[string]string{"author": "backend", "other": "frontend"}
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+		return prRepo, userRepo, svc
+	}
+
+	t.Run("activates and tops up", func(t *testing.T) {
+		prRepo, userRepo, svc := setup()
+
+		result, err := svc.BulkActivateTeam(context.Background(), "backend", true)
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, result.ActivatedUsers, 3, "Inactive members activated")
+		for _, id := range []string{"r1", "r2", "r3"} {
+			testutil.AssertTrue(t, userRepo.Users[id].IsActive, id+" is active")
+		}
+
+		testutil.AssertEqual(t, result.ToppedUpPRs, 2)
+		testutil.AssertEqual(t, result.Errors, 0)
+		testutil.AssertLen(t, prRepo.PRs["pr-empty"].AssignedReviewers, 2, "Empty PR filled to the team count")
+		testutil.AssertLen(t, prRepo.PRs["pr-short"].AssignedReviewers, 2, "Short PR topped up")
+		testutil.AssertEqual(t, prRepo.PRs["pr-short"].AssignedReviewers[0], "r1", "Current reviewer kept")
+		testutil.AssertLen(t, prRepo.PRs["pr-merged"].AssignedReviewers, 0, "Merged PR untouched")
+		testutil.AssertLen(t, prRepo.PRs["pr-foreign"].AssignedReviewers, 0, "Other team's PR untouched")
+	})
+
+	t.Run("without top up", func(t *testing.T) {
+		prRepo, _, svc := setup()
+
+		result, err := svc.BulkActivateTeam(context.Background(), "backend", false)
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, result.ActivatedUsers, 3, "Inactive members activated")
+		testutil.AssertEqual(t, result.ToppedUpPRs, 0)
+		testutil.AssertLen(t, prRepo.PRs["pr-empty"].AssignedReviewers, 0, "PRs left as they were")
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		_, _, svc := setup()
+
+		_, err := svc.BulkActivateTeam(context.Background(), "missing", true)
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestPullRequestService_ReconcileReviewerCounts tests that open PRs of a grown team are
+// topped up to the team count, over-reviewed PRs keep their reviewers, and PRs that still
+// cannot be filled are reported
+func TestPullRequestService_ReconcileReviewerCounts(t *testing.T) {
+	t.Run("tops up after the team grows", func(t *testing.T) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "r1")
+		prRepo.AddOpenPR("pr-short", "author", "r1")
+		prRepo.AddOpenPR("pr-over", "author", "r1", "moved", "left")
+		prRepo.PRs["pr-merged"] = &domain.PullRequest{PullRequestID: "pr-merged", AuthorID: "author", Status: domain.PRStatusMerged}
+		prRepo.AuthorTeams = map[string]string{"author": "backend"}
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		// The team grows after the PRs were created
+		userRepo.AddTeamMembers("backend", "r2")
+
+		result, err := svc.ReconcileReviewerCounts(context.Background(), "backend")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, *result, ReconcileResult{
+			TeamName: "backend", CheckedPRs: 2, ToppedUpPRs: 1, OverReviewed: 1,
+		}, "Reconcile result")
+		testutil.AssertEqual(t, prRepo.PRs["pr-short"].AssignedReviewers, []string{"r1", "r2"}, "Short PR topped up")
+		testutil.AssertEqual(t, prRepo.PRs["pr-over"].AssignedReviewers, []string{"r1", "moved", "left"}, "Extra reviewers kept")
+		testutil.AssertLen(t, prRepo.PRs["pr-merged"].AssignedReviewers, 0, "Merged PR untouched")
+	})
+
+	t.Run("reports PRs the shrunk team cannot fill", func(t *testing.T) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "r1")
+		prRepo.PRs["pr-empty"] = &domain.PullRequest{PullRequestID: "pr-empty", AuthorID: "author", Status: domain.PRStatusOpen}
+		prRepo.AuthorTeams = map[string]string{"author": "backend"}
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		result, err := svc.ReconcileReviewerCounts(context.Background(), "backend")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, result.ToppedUpPRs, 1)
+		testutil.AssertEqual(t, result.StillShort, 1)
+		testutil.AssertEqual(t, prRepo.PRs["pr-empty"].AssignedReviewers, []string{"r1"}, "Only candidate assigned")
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		svc := newTestService(testutil.NewMockPRRepository(), testutil.NewMockUserRepository(), AssignmentConfig{})
+
+		_, err := svc.ReconcileReviewerCounts(context.Background(), "missing")
+
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestPullRequestService_HandoffTeam tests that assignments of one team move to the least
+// loaded eligible members of another, and that assignments without an eligible target are skipped
+func TestPullRequestService_HandoffTeam(t *testing.T) {
+	setup := func() (*testutil.MockPRRepository, *PullRequestService) {
+		prRepo, userRepo := newTeamRepos("product", "author")
+		userRepo.AddTeamMembers("legacy", "old1", "old2")
+		userRepo.AddTeamMembers("platform", "new1", "new2")
+		userRepo.Users["new3"] = &domain.User{UserID: "new3", TeamName: "platform", IsActive: false}
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+		return prRepo, svc
+	}
+
+	t.Run("moves to least loaded target members", func(t *testing.T) {
+		prRepo, svc := setup()
+		prRepo.AddOpenPR("pr-1", "author", "old1")
+		// new1 already has an open review elsewhere, so new2 is preferred
+		prRepo.AddOpenPR("pr-busy", "author", "new1")
+		prRepo.PRs["pr-merged"] = &domain.PullRequest{
+			PullRequestID: "pr-merged", AuthorID: "author", Status: domain.PRStatusMerged,
+			AssignedReviewers: []string{"old2"},
+		}
+
+		result, err := svc.HandoffTeam(context.Background(), "legacy", "platform")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, *result, HandoffResult{FromTeam: "legacy", ToTeam: "platform", Moved: 1}, "Handoff result")
+		testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"new2"}, "Moved to the least loaded member")
+		testutil.AssertEqual(t, prRepo.PRs["pr-merged"].AssignedReviewers, []string{"old2"}, "Merged PR untouched")
+		testutil.AssertLen(t, prRepo.ReviewerHistory, 1, "Move recorded in history")
+	})
+
+	t.Run("skips when every target is already assigned", func(t *testing.T) {
+		prRepo, svc := setup()
+		prRepo.AddOpenPR("pr-1", "author", "old1", "old2", "new1")
+
+		result, err := svc.HandoffTeam(context.Background(), "legacy", "platform")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, result.Moved, 1)
+		testutil.AssertEqual(t, result.Skipped, 1)
+		testutil.AssertLen(t, prRepo.PRs["pr-1"].AssignedReviewers, 3, "Reviewer count unchanged")
+		testutil.AssertTrue(t, prRepo.PRs["pr-1"].HasReviewer("new2"), "Free target member assigned")
+		testutil.AssertTrue(t, !prRepo.PRs["pr-1"].HasReviewer("new3"), "Inactive target member not assigned")
+	})
+
+	t.Run("rejects handoff to the same team", func(t *testing.T) {
+		_, svc := setup()
+
+		_, err := svc.HandoffTeam(context.Background(), "legacy", "legacy")
+
+		testutil.AssertTrue(t, errors.Is(err, domain.ErrInvalidInput), "Invalid input")
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		_, svc := setup()
+
+		_, err := svc.HandoffTeam(context.Background(), "legacy", "missing")
+
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestPullRequestService_RebalanceTeam tests that open reviews move from the most loaded
+// team member to the least loaded eligible ones until the gap is within the threshold
+func TestPullRequestService_RebalanceTeam(t *testing.T) {
+	setup := func() (*testutil.MockPRRepository, *testutil.MockUserRepository, *PullRequestService) {
+		prRepo, userRepo := newTeamRepos("product", "author")
+		userRepo.AddTeamMembers("backend", "u1", "u2", "u3", "u4", "u5")
+		userRepo.Users["u5"].IsActive = false
+		// u1 reviews every open PR, the rest of the team has nothing
+		for i := 1; i <= 5; i++ {
+			prRepo.AddOpenPR(fmt.Sprintf("pr-%d", i), "author", "u1")
+		}
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+		return prRepo, userRepo, svc
+	}
+
+	t.Run("redistributes a skewed team", func(t *testing.T) {
+		prRepo, _, svc := setup()
+
+		result, err := svc.RebalanceTeam(context.Background(), "backend", DefaultRebalanceMaxGap)
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, result.LoadBefore, map[string]int{"u1": 5, "u2": 0, "u3": 0, "u4": 0}, "Load before")
+		testutil.AssertEqual(t, result.LoadAfter, map[string]int{"u1": 2, "u2": 1, "u3": 1, "u4": 1}, "Load after")
+		testutil.AssertLen(t, result.Moves, 3, "Moves")
+		for _, move := range result.Moves {
+			testutil.AssertEqual(t, move.FromUserID, "u1", "Moved from the most loaded reviewer")
+			testutil.AssertEqual(t, prRepo.PRs[move.PullRequestID].AssignedReviewers, []string{move.ToUserID}, "Reviewer replaced")
+		}
+		testutil.AssertLen(t, prRepo.ReviewerHistory, 3, "Moves recorded in history")
+	})
+
+	t.Run("wider gap moves less", func(t *testing.T) {
+		_, _, svc := setup()
+
+		result, err := svc.RebalanceTeam(context.Background(), "backend", 3)
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, result.Moves, 2, "Moves")
+		testutil.AssertEqual(t, result.LoadAfter["u1"], 3, "Most loaded after")
+	})
+
+	t.Run("keeps reviewers without an eligible target", func(t *testing.T) {
+		prRepo, userRepo, svc := setup()
+		for _, id := range []string{"u2", "u3", "u4"} {
+			userRepo.Conflicts = append(userRepo.Conflicts, domain.ReviewConflict{UserA: "author", UserB: id})
+		}
+
+		result, err := svc.RebalanceTeam(context.Background(), "backend", DefaultRebalanceMaxGap)
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, result.Moves, 0, "No moves")
+		testutil.AssertEqual(t, prRepo.PRs["pr-1"].AssignedReviewers, []string{"u1"}, "Reviewer kept")
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		_, _, svc := setup()
+
+		_, err := svc.RebalanceTeam(context.Background(), "missing", DefaultRebalanceMaxGap)
+
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestPullRequestService_CreatePullRequest_AvoidRepeatPairs tests that with AvoidRepeatPairs
+// a duo often paired on the author's recent PRs is replaced by a novel pairing, and that the
+// least frequent pair is used when every pairing has already occurred
+func TestPullRequestService_CreatePullRequest_AvoidRepeatPairs(t *testing.T) {
+	newRepos := func(history map[string][]string) (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "u1", "u2", "u3", "u4")
+		for prID, reviewers := range history {
+			prRepo.PRs[prID] = &domain.PullRequest{PullRequestID: prID, AuthorID: "author", Status: domain.PRStatusMerged, AssignedReviewers: reviewers}
+		}
+		return prRepo, userRepo
+	}
+	// Without the rule the alphabetical strategy would pick u1 and u2
+	cfg := AssignmentConfig{Selector: alphabeticalSelector{}, Features: FeatureFlags{AvoidRepeatPairs: true}}
+
+	t.Run("frequent duo avoided", func(t *testing.T) {
+		prRepo, userRepo := newRepos(map[string][]string{
+			"old-1": {"u1", "u2"},
+			"old-2": {"u2", "u1"},
+			"old-3": {"u1", "u2"},
+			"old-4": {"u1", "u3"},
+			"old-5": {"u1", "u4"},
+			"old-6": {"u2", "u4"},
+			"old-7": {"u3", "u4"},
+		})
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-new", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u2", "u3"}, "The only pair never assigned together")
+	})
+
+	t.Run("least frequent pair when all repeat", func(t *testing.T) {
+		prRepo, userRepo := newRepos(map[string][]string{
+			"old-1": {"u1", "u2"},
+			"old-2": {"u1", "u2"},
+			"old-3": {"u1", "u3", "u4"},
+			"old-4": {"u1", "u3", "u4"},
+			"old-5": {"u2", "u3", "u4"},
+			"old-6": {"u2", "u4"},
+		})
+		userRepo.Users["u4"].IsActive = false
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-new", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u2", "u3"}, "u2+u3 paired once, the others twice")
+	})
+
+	t.Run("other authors' PRs ignored", func(t *testing.T) {
+		prRepo, userRepo := newRepos(nil)
+		prRepo.PRs["other"] = &domain.PullRequest{PullRequestID: "other", AuthorID: "u4", AssignedReviewers: []string{"u1", "u2"}}
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-new", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u1", "u2"}, "Pairing on another author's PR does not count")
+	})
+}
+
+// TestPullRequestService_CreatePullRequest_AvoidReciprocal tests that with AvoidReciprocal
+// a candidate whose latest PR the author reviewed is passed over when alternatives exist,
+// that only the latest PR counts, and that the candidate is still used when needed
+func TestPullRequestService_CreatePullRequest_AvoidReciprocal(t *testing.T) {
+	at := func(day int) *time.Time {
+		t := time.Date(2025, 1, day, 12, 0, 0, 0, time.UTC)
+		return &t
+	}
+	newRepos := func() (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "u1", "u2", "u3")
+		// u1's latest PR was reviewed by the author
+		prRepo.PRs["u1-old"] = &domain.PullRequest{PullRequestID: "u1-old", AuthorID: "u1", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u2"}, CreatedAt: at(1)}
+		prRepo.PRs["u1-new"] = &domain.PullRequest{PullRequestID: "u1-new", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"author", "u3"}, CreatedAt: at(2)}
+		return prRepo, userRepo
+	}
+	// Without the rule the alphabetical strategy would pick u1 and u2
+	cfg := AssignmentConfig{Selector: alphabeticalSelector{}, Features: FeatureFlags{AvoidReciprocal: true}}
+
+	t.Run("reciprocal candidate passed over", func(t *testing.T) {
+		prRepo, userRepo := newRepos()
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-new", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u2", "u3"}, "u1 deprioritized")
+	})
+
+	t.Run("older PRs ignored", func(t *testing.T) {
+		prRepo, userRepo := newRepos()
+		prRepo.PRs["u1-old"].AssignedReviewers = []string{"author"}
+		prRepo.PRs["u1-new"].AssignedReviewers = []string{"u3"}
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-new", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u1", "u2"}, "Only u1's latest PR counts")
+	})
+
+	t.Run("used when no alternatives", func(t *testing.T) {
+		prRepo, userRepo := newRepos()
+		userRepo.Users["u3"].IsActive = false
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-new", "Feature", "author", nil, nil, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"u1", "u2"}, "u1 fills the remaining slot")
+	})
+}
+
+// TestPullRequestService_TeamHealth tests the composite backlog view of a seeded team:
+// only PRs of the team's authors count, understaffing follows the team reviewer count
+func TestPullRequestService_TeamHealth(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	at := func(hoursAgo int) *time.Time {
+		t := now.Add(-time.Duration(hoursAgo) * time.Hour)
+		return &t
+	}
+
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["b1"] = &domain.User{UserID: "b1", Username: "b1", TeamName: "backend", IsActive: true}
+	userRepo.Users["b2"] = &domain.User{UserID: "b2", Username: "b2", TeamName: "backend", IsActive: true}
+	userRepo.Users["b3"] = &domain.User{UserID: "b3", Username: "b3", TeamName: "backend", IsActive: false}
+	userRepo.Users["bot"] = &domain.User{UserID: "bot", Username: "ci-bot", TeamName: "backend", IsActive: true}
+	userRepo.Users["f1"] = &domain.User{UserID: "f1", Username: "f1", TeamName: "frontend", IsActive: true}
+
+	prRepo := testutil.NewMockPRRepository()
+	prRepo.AuthorTeams = map[string]string{"b1": "backend", "b2": "backend", "b3": "backend", "bot": "backend", "f1": "frontend"}
+	prRepo.AddOpenPR("open-full", "b1", "b2", "f1")
+	prRepo.AddOpenPR("open-short", "b2", "b1")
+	prRepo.AddOpenPR("open-empty", "b1")
+	prRepo.PRs["merged-1"] = &domain.PullRequest{PullRequestID: "merged-1", AuthorID: "b1", Status: domain.PRStatusMerged, CreatedAt: at(30), MergedAt: at(20)}
+	prRepo.PRs["merged-2"] = &domain.PullRequest{PullRequestID: "merged-2", AuthorID: "b2", Status: domain.PRStatusMerged, CreatedAt: at(50), MergedAt: at(45)}
+	prRepo.AddOpenPR("frontend", "f1")
+	prRepo.GetOpenAssignedBeforeFunc = func(ctx context.Context, before time.Time) ([]domain.SLABreach, error) {
+		return []domain.SLABreach{
+			{PullRequestID: "open-full", AuthorID: "b1", OldestAssignedAt: now.Add(-48 * time.Hour)},
+			{PullRequestID: "frontend", AuthorID: "f1", OldestAssignedAt: now.Add(-48 * time.Hour)},
+		}, nil
+	}
+
+	cfg := AssignmentConfig{ReviewSLA: 24 * time.Hour, ExcludeUsername: regexp.MustCompile(`-bot$`)}
+	svc := newTestService(prRepo, userRepo, cfg)
+	svc.now = func() time.Time { return now }
+
+	health, err := svc.TeamHealth(context.Background(), "backend")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, *health, TeamHealth{
+		TeamName:            "backend",
+		OpenPRs:             3,
+		UnderstaffedPRs:     2,
+		ReviewersPerPR:      2,
+		SLABreaches:         1,
+		MergedPRs:           2,
+		AvgTimeToMergeHours: 7.5,
+		ActiveReviewers:     2,
+	}, "Backend health")
+
+	t.Run("unknown team", func(t *testing.T) {
+		_, err := svc.TeamHealth(context.Background(), "mobile")
+
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestTeamMembers tests that an empty member list is only ErrNotFound when the team is unknown
+func TestTeamMembers(t *testing.T) {
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.AddTeamMembers("backend", "b1", "b2")
+	teamRepo := testutil.NewMockTeamRepository()
+	teamRepo.Teams["imported"] = &domain.Team{TeamName: "imported"}
+
+	tests := []struct {
+		name     string
+		team     string
+		expected int
+		err      error
+	}{
+		{name: "team with members", team: "backend", expected: 2},
+		{name: "imported team without members", team: "imported", expected: 0},
+		{name: "unknown team", team: "mobile", err: domain.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members, err := teamMembers(context.Background(), userRepo, teamRepo, tt.team)
+
+			testutil.AssertErrorIs(t, err, tt.err)
+			testutil.AssertEqual(t, len(members), tt.expected, "Members count")
+		})
+	}
+}
+
+// TestPullRequestService_CreatePullRequest_CandidatePool tests that an explicit candidate pool
+// replaces the team pool, is still filtered by eligibility and must contain existing users
+func TestPullRequestService_CreatePullRequest_CandidatePool(t *testing.T) {
+	setup := func() (*testutil.MockPRRepository, *testutil.MockUserRepository) {
+		prRepo, userRepo := newTeamRepos("backend", "author", "b1", "b2", "b3")
+		userRepo.AddTeamMembers("frontend", "f1")
+		userRepo.Users["f2"] = &domain.User{UserID: "f2", TeamName: "frontend", IsActive: false}
+		return prRepo, userRepo
+	}
+
+	t.Run("pool constrains selection", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			prRepo, userRepo := setup()
+			svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+			pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil,
+				[]string{"b3", "f1", "f2", "author", "f1"}, "")
+
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, pr.AssignedReviewers, []string{"b3", "f1"}, "Only eligible pool members, outside the team too")
+			testutil.AssertLen(t, pr.Warnings, 0, "Pool filled every slot")
+		}
+	})
+
+	t.Run("empty after intersection", func(t *testing.T) {
+		prRepo, userRepo := setup()
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, []string{"author", "f2"}, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, pr.AssignedReviewers, 0, "No eligible pool members")
+		testutil.AssertEqual(t, pr.Warnings, []string{"no reviewers available"}, "Shortage reported")
+		testutil.AssertLen(t, prRepo.PRs["pr-1"].AssignedReviewers, 0, "Team members are not used as a fallback")
+	})
+
+	t.Run("unknown pool member", func(t *testing.T) {
+		prRepo, userRepo := setup()
+		svc := newTestService(prRepo, userRepo, AssignmentConfig{})
+
+		_, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author", nil, []string{"b1", "ghost"}, "")
+
+		testutil.AssertTrue(t, errors.Is(err, domain.ErrNotFound), "Unknown pool member not found")
+		testutil.AssertLen(t, prRepo.PRs, 0, "PR not created")
+	})
+
+	t.Run("code owners outside the pool skipped", func(t *testing.T) {
+		prRepo, userRepo := setup()
+		cfg := AssignmentConfig{CodeOwners: map[string][]string{"api/": {"b1", "b2"}}}
+		svc := newTestService(prRepo, userRepo, cfg)
+
+		pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Feature", "author",
+			[]string{"api/handler.go"}, []string{"b2", "f1"}, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, pr.AssignedReviewers, []string{"b2", "f1"}, "Owner b2 first, b1 is outside the pool")
+	})
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+)
+
+// DefaultRebalanceMaxGap - допустимая разница числа открытых ревью самого и наименее
+// загруженного ревьювера команды, если порог не задан
+const DefaultRebalanceMaxGap = 1
+
+// RebalanceMove описывает одну замену ревьювера при выравнивании нагрузки
+type RebalanceMove struct {
+	PullRequestID string `json:"pull_request_id"`
+	FromUserID    string `json:"from_user_id"`
+	ToUserID      string `json:"to_user_id"`
+}
+
+// RebalanceResult содержит сделанные замены и нагрузку ревьюверов команды до и после них
+type RebalanceResult struct {
+	TeamName   string          `json:"team_name"`
+	MaxGap     int             `json:"max_gap"`
+	Moves      []RebalanceMove `json:"moves"`
+	LoadBefore map[string]int  `json:"load_before"`
+	LoadAfter  map[string]int  `json:"load_after"`
+}
+
+// RebalanceTeam выравнивает нагрузку активных ревьюверов команды: открытые PR по одному
+// переназначаются с самого загруженного ревьювера на наименее загруженного, пока разница
+// между ними больше maxGap. Замена допустима только по правилам переназначения (активный,
+// не автор, не текущий ревьювер PR, не служебный аккаунт, без конфликта с автором) и только
+// если уменьшает разрыв; когда таких замен нет, выравнивание останавливается раньше
+func (s *PullRequestService) RebalanceTeam(ctx context.Context, teamName string, maxGap int) (*RebalanceResult, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"team_name", &teamName}); err != nil {
+		return nil, err
+	}
+	if maxGap < 1 {
+		return nil, fmt.Errorf("max gap %d must be at least 1: %w", maxGap, domain.ErrInvalidInput)
+	}
+
+	members, err := teamMembers(ctx, s.userRepo, s.teamRepo, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviewers []string
+	for _, member := range members {
+		if member.IsActive && !s.cfg.excludesUsername(member.Username) {
+			reviewers = append(reviewers, member.UserID)
+		}
+	}
+
+	counts, err := s.prRepo.CountOpenByUsers(ctx, reviewers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open reviews: %w", err)
+	}
+	load := make(map[string]int, len(reviewers))
+	for _, userID := range reviewers {
+		load[userID] = counts[userID]
+	}
+
+	result := &RebalanceResult{
+		TeamName:   teamName,
+		MaxGap:     maxGap,
+		Moves:      []RebalanceMove{},
+		LoadBefore: maps.Clone(load),
+	}
+
+	for len(reviewers) > 1 {
+		// При равной нагрузке порядок по ID, чтобы результат был детерминированным
+		slices.SortFunc(reviewers, func(a, b string) int {
+			if load[a] != load[b] {
+				return load[b] - load[a]
+			}
+			return strings.Compare(a, b)
+		})
+		if load[reviewers[0]]-load[reviewers[len(reviewers)-1]] <= maxGap {
+			break
+		}
+
+		move, err := s.findRebalanceMove(ctx, members, reviewers, load)
+		if err != nil {
+			return nil, err
+		}
+		if move == nil {
+			s.logger.Info("no eligible rebalance move left", zap.String("team_name", teamName))
+			break
+		}
+
+		if err := s.prRepo.ReassignReviewer(ctx, move.PullRequestID, move.FromUserID, move.ToUserID); err != nil {
+			s.logger.Error("failed to rebalance reviewer", zap.Error(err), zap.String("pr_id", move.PullRequestID))
+			return nil, fmt.Errorf("failed to reassign reviewer: %w", err)
+		}
+		recordReviewerHistory(ctx, s.prRepo, s.logger, reassignEntry(move.PullRequestID, move.FromUserID, move.ToUserID))
+
+		load[move.FromUserID]--
+		load[move.ToUserID]++
+		result.Moves = append(result.Moves, *move)
+	}
+
+	result.LoadAfter = load
+
+	s.logger.Info("team rebalanced",
+		zap.String("team_name", teamName),
+		zap.Int("moves", len(result.Moves)))
+
+	return result, nil
+}
+
+// findRebalanceMove ищет замену, уменьшающую разрыв нагрузки: PR самого загруженного
+// ревьювера, на который можно назначить наименее загруженного. reviewers упорядочены
+// по убыванию нагрузки. Возвращает nil, если такой замены нет
+func (s *PullRequestService) findRebalanceMove(ctx context.Context, members []domain.User, reviewers []string, load map[string]int) (*RebalanceMove, error) {
+	lightest := load[reviewers[len(reviewers)-1]]
+
+	for _, fromID := range reviewers {
+		// Замена уменьшает разрыв, только если разница нагрузки не меньше двух
+		if load[fromID]-lightest < 2 {
+			break
+		}
+
+		openPRs, err := s.prRepo.GetOpenByReviewer(ctx, fromID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get open PRs for user %s: %w", fromID, err)
+		}
+		slices.Sort(openPRs)
+
+		for _, prID := range openPRs {
+			pr, err := s.prRepo.Get(ctx, prID)
+			if err != nil {
+				return nil, err
+			}
+			conflicts, err := s.userRepo.GetReviewConflicts(ctx, pr.AuthorID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get review conflicts: %w", err)
+			}
+
+			candidates := s.cfg.filterReassignCandidates(members, pr.AuthorID, pr.AssignedReviewers, conflicts, fromID)
+			for i := len(reviewers) - 1; i >= 0 && load[fromID]-load[reviewers[i]] >= 2; i-- {
+				if slices.Contains(candidates, reviewers[i]) {
+					return &RebalanceMove{PullRequestID: prID, FromUserID: fromID, ToUserID: reviewers[i]}, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+
+	"reviewservice/internal/domain"
+)
+
+// Стратегии выбора ревьюверов среди подходящих кандидатов (REVIEWER_STRATEGY)
+const (
+	StrategyRandom       = "random"
+	StrategyAlphabetical = "alphabetical"
+	StrategyThroughput   = "throughput"
+	StrategyLeastLoaded  = "least_loaded"
+)
+
+// ReviewerSelector задаёт порядок, в котором подходящие кандидаты занимают слоты ревьюверов
+type ReviewerSelector interface {
+	// Order переупорядочивает кандидатов (user_id) на месте и возвращает их так, что первые n
+	// идут в порядке приоритета назначения; порядок остальных не гарантируется.
+	// members - пользователи, среди которых есть кандидаты (для доступа к username)
+	Order(ctx context.Context, candidates []string, members []domain.User, n int) []string
+
+	// Name возвращает имя стратегии (как в REVIEWER_STRATEGY)
+	Name() string
+}
+
+// randomSelector перемешивает кандидатов: каждый имеет равные шансы быть выбранным
+type randomSelector struct{}
+
+func (randomSelector) Name() string { return StrategyRandom }
+
+func (randomSelector) Order(_ context.Context, candidates []string, _ []domain.User, n int) []string {
+	partialShuffle(candidates, n)
+	return candidates
+}
+
+// partialShuffle перемешивает только первые n позиций алгоритмом Fisher-Yates: на позицию i
+// ставится случайный элемент из ещё не выбранных. Первые n элементов - равновероятная выборка
+// без повторений, а работа пропорциональна n, а не размеру пула (важно для пула всей организации)
+func partialShuffle(candidates []string, n int) {
+	for i := 0; i < n && i < len(candidates)-1; i++ {
+		j := i + rand.IntN(len(candidates)-i)
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	}
+}
+
+// alphabeticalSelector упорядочивает кандидатов по username (при равенстве - по user_id),
+// делая выбор полностью детерминированным
+type alphabeticalSelector struct{}
+
+func (alphabeticalSelector) Name() string { return StrategyAlphabetical }
+
+func (alphabeticalSelector) Order(_ context.Context, candidates []string, members []domain.User, _ int) []string {
+	usernames := make(map[string]string, len(members))
+	for _, member := range members {
+		usernames[member.UserID] = member.Username
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		left, right := usernames[candidates[i]], usernames[candidates[j]]
+		if left != right {
+			return left < right
+		}
+		return candidates[i] < candidates[j]
+	})
+	return candidates
+}
+
+// throughputSelector выбирает кандидатов случайно, но с весом, растущим с числом смердженных
+// PR, которые они ревьюили: вес равен merged + 1, поэтому кандидаты без истории тоже
+// могут быть выбраны. Если статистику получить не удалось, выбор равновероятный
+type throughputSelector struct {
+	prRepo domain.PullRequestRepository
+	// float64 возвращает число из [0, 1); подменяется в тестах
+	float64 func() float64
+}
+
+func (throughputSelector) Name() string { return StrategyThroughput }
+
+func (s throughputSelector) Order(ctx context.Context, candidates []string, _ []domain.User, n int) []string {
+	merged := make(map[string]int)
+	if stats, err := s.prRepo.GetUserAssignmentStats(ctx); err == nil {
+		for userID, userStats := range stats {
+			merged[userID] = userStats.MergedPRs
+		}
+	}
+
+	total := 0
+	for _, userID := range candidates {
+		total += merged[userID] + 1
+	}
+
+	// Взвешенная выборка без возвращения: на позицию i ставится кандидат из оставшихся
+	// с вероятностью, пропорциональной его весу
+	for i := 0; i < n && i < len(candidates)-1; i++ {
+		target := int(s.float64() * float64(total))
+		j := i
+		for ; j < len(candidates)-1; j++ {
+			target -= merged[candidates[j]] + 1
+			if target < 0 {
+				break
+			}
+		}
+		total -= merged[candidates[j]] + 1
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	}
+	return candidates
+}
+
+// leastLoadedSelector ставит первыми кандидатов с наименьшим числом открытых ревью; при
+// равной нагрузке порядок случайный. Смердженные ревью не учитываются, чтобы разобравший
+// свою очередь ревьювер не проигрывал тем, кто ревьюил меньше. Если нагрузку получить
+// не удалось, выбор равновероятный
+type leastLoadedSelector struct {
+	prRepo domain.PullRequestRepository
+}
+
+func (leastLoadedSelector) Name() string { return StrategyLeastLoaded }
+
+func (s leastLoadedSelector) Order(ctx context.Context, candidates []string, _ []domain.User, n int) []string {
+	open, err := s.prRepo.CountOpenByUsers(ctx, candidates)
+	if err != nil {
+		partialShuffle(candidates, n)
+		return candidates
+	}
+
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return open[candidates[i]] < open[candidates[j]]
+	})
+	return candidates
+}
+
+// NewReviewerSelector возвращает стратегию выбора по имени (например, из REVIEWER_STRATEGY).
+// Пустое имя означает случайный выбор; неизвестная стратегия - ошибка.
+// prRepo нужен стратегиям throughput и least_loaded для статистики ревью
+func NewReviewerSelector(strategy string, prRepo domain.PullRequestRepository) (ReviewerSelector, error) {
+	switch strings.TrimSpace(strategy) {
+	case "", StrategyRandom:
+		return randomSelector{}, nil
+	case StrategyAlphabetical:
+		return alphabeticalSelector{}, nil
+	case StrategyThroughput:
+		return throughputSelector{prRepo: prRepo, float64: rand.Float64}, nil
+	case StrategyLeastLoaded:
+		return leastLoadedSelector{prRepo: prRepo}, nil
+	default:
+		return nil, fmt.Errorf("unknown reviewer strategy %q", strategy)
+	}
+}
+
+// selector возвращает стратегию выбора ревьюверов; nil - случайный выбор
+func (c AssignmentConfig) selector() ReviewerSelector {
+	if c.Selector == nil {
+		return randomSelector{}
+	}
+	return c.Selector
+}
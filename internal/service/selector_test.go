@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+	"reviewservice/internal/testutil"
+)
+
+// largePool builds an org-wide pool of active members plus the author
+func largePool(size int) []domain.User {
+	members := make([]domain.User, 0, size+1)
+	members = append(members, domain.User{UserID: "author", IsActive: true})
+	for i := 0; i < size; i++ {
+		members = append(members, domain.User{UserID: fmt.Sprintf("u%05d", i), IsActive: true})
+	}
+	return members
+}
+
+// TestSelectReviewers_LargePool tests that the partial shuffle still returns the requested
+// number of distinct eligible reviewers, never the author or an inactive member
+func TestSelectReviewers_LargePool(t *testing.T) {
+	members := largePool(5000)
+	members[10].IsActive = false
+	svc := NewPullRequestService(testutil.NewMockPRRepository(), testutil.NewMockUserRepository(),
+		testutil.NewMockTeamRepository(), nil, ReviewerPolicy{}, AssignmentConfig{}, zap.NewNop())
+
+	for i := 0; i < 50; i++ {
+		excluded := map[string]ExclusionReason{"author": ExclusionAuthor}
+		selected := svc.selectReviewers(context.Background(), members, excluded, 3, nil)
+
+		testutil.AssertLen(t, selected, 3, "Requested reviewer count")
+		testutil.AssertNotContains(t, selected, "author", "Author never selected")
+		testutil.AssertNotContains(t, selected, members[10].UserID, "Inactive member never selected")
+		seen := make(map[string]bool)
+		for _, userID := range selected {
+			testutil.AssertFalse(t, seen[userID], "Reviewer selected twice: %s", userID)
+			seen[userID] = true
+		}
+	}
+}
+
+// TestPartialShuffle tests that every candidate can reach the first slot
+func TestPartialShuffle(t *testing.T) {
+	hits := make(map[string]bool)
+	for i := 0; i < 500; i++ {
+		candidates := []string{"a", "b", "c", "d"}
+		partialShuffle(candidates, 1)
+		hits[candidates[0]] = true
+	}
+
+	testutil.AssertLen(t, hits, 4, "All candidates reach the first slot")
+}
+
+// BenchmarkShuffle compares shuffling the whole pool with shuffling only the needed slots
+func BenchmarkShuffle(b *testing.B) {
+	members := largePool(10000)
+	ids := make([]string, len(members))
+	for i, member := range members {
+		ids[i] = member.UserID
+	}
+
+	b.Run("full", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+		}
+	})
+
+	b.Run("partial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			partialShuffle(ids, 2)
+		}
+	})
+}
+
+// throughputRepo returns a mock whose stats report the given merged review counts
+func throughputRepo(merged map[string]int) *testutil.MockPRRepository {
+	repo := testutil.NewMockPRRepository()
+	repo.GetUserAssignmentStatsFunc = func(ctx context.Context) (map[string]*domain.UserAssignmentStats, error) {
+		stats := make(map[string]*domain.UserAssignmentStats, len(merged))
+		for userID, count := range merged {
+			stats[userID] = &domain.UserAssignmentStats{UserID: userID, MergedPRs: count}
+		}
+		return stats, nil
+	}
+	return repo
+}
+
+// TestThroughputSelector_WeightedDraw tests that the draw maps onto cumulative weights
+// (merged + 1): with weights 10 and 1 only the top ~9% of the range picks the newcomer
+func TestThroughputSelector_WeightedDraw(t *testing.T) {
+	repo := throughputRepo(map[string]int{"busy": 9})
+
+	tests := []struct {
+		name     string
+		draw     float64
+		expected string
+	}{
+		{"low draw picks high throughput", 0.0, "busy"},
+		{"mid draw picks high throughput", 0.85, "busy"},
+		{"top draw picks newcomer", 0.95, "new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := throughputSelector{prRepo: repo, float64: func() float64 { return tt.draw }}
+			ordered := selector.Order(context.Background(), []string{"busy", "new"}, nil, 1)
+			testutil.AssertEqual(t, ordered[0], tt.expected, "First reviewer")
+		})
+	}
+}
+
+// TestThroughputSelector_FavorsHighThroughput tests that over many seeded draws the
+// high-throughput candidate wins most often while others are still chosen sometimes
+func TestThroughputSelector_FavorsHighThroughput(t *testing.T) {
+	repo := throughputRepo(map[string]int{"busy": 20, "mid": 4})
+	rng := rand.New(rand.NewPCG(1, 2))
+	selector := throughputSelector{prRepo: repo, float64: rng.Float64}
+
+	wins := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		ordered := selector.Order(context.Background(), []string{"new", "mid", "busy"}, nil, 1)
+		wins[ordered[0]]++
+	}
+
+	testutil.AssertTrue(t, wins["busy"] > wins["mid"], "High throughput chosen more often than mid")
+	testutil.AssertTrue(t, wins["mid"] > wins["new"], "Mid throughput chosen more often than newcomer")
+	testutil.AssertTrue(t, wins["new"] > 0, "Newcomer still gets a chance")
+}
+
+// TestThroughputSelector_StatsError tests that a stats failure falls back to a uniform
+// draw over all candidates instead of failing the assignment
+func TestThroughputSelector_StatsError(t *testing.T) {
+	repo := testutil.NewMockPRRepository()
+	repo.GetUserAssignmentStatsFunc = func(ctx context.Context) (map[string]*domain.UserAssignmentStats, error) {
+		return nil, fmt.Errorf("db down")
+	}
+	selector := throughputSelector{prRepo: repo, float64: func() float64 { return 0.5 }}
+
+	ordered := selector.Order(context.Background(), []string{"a", "b", "c", "d"}, nil, 2)
+	testutil.AssertEqual(t, ordered[0], "c", "Uniform weights map the draw to the middle candidate")
+	testutil.AssertLen(t, ordered, 4, "Candidates are preserved")
+}
+
+// TestLeastLoadedSelector_IgnoresMergedHistory tests that a reviewer with a long merged
+// history but an empty queue is preferred over one with fewer reviews still open
+func TestLeastLoadedSelector_IgnoresMergedHistory(t *testing.T) {
+	repo := testutil.NewMockPRRepository()
+	for i := 0; i < 5; i++ {
+		prID := fmt.Sprintf("pr-merged-%d", i)
+		repo.PRs[prID] = &domain.PullRequest{PullRequestID: prID, Status: domain.PRStatusMerged, AssignedReviewers: []string{"veteran"}}
+	}
+	repo.PRs["pr-open"] = &domain.PullRequest{PullRequestID: "pr-open", Status: domain.PRStatusOpen, AssignedReviewers: []string{"busy"}}
+	selector := leastLoadedSelector{prRepo: repo}
+
+	for i := 0; i < 20; i++ {
+		ordered := selector.Order(context.Background(), []string{"busy", "veteran"}, nil, 1)
+		testutil.AssertEqual(t, ordered[0], "veteran", "Reviewer with no open reviews goes first")
+	}
+}
+
+// TestLeastLoadedSelector_RandomTieBreak tests that candidates with equal load all reach
+// the first slot, while a busier candidate never does
+func TestLeastLoadedSelector_RandomTieBreak(t *testing.T) {
+	repo := testutil.NewMockPRRepository()
+	repo.PRs["pr-open"] = &domain.PullRequest{PullRequestID: "pr-open", Status: domain.PRStatusOpen, AssignedReviewers: []string{"busy"}}
+	selector := leastLoadedSelector{prRepo: repo}
+
+	first := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		ordered := selector.Order(context.Background(), []string{"busy", "a", "b", "c"}, nil, 1)
+		first[ordered[0]]++
+	}
+
+	testutil.AssertEqual(t, first["busy"], 0, "Busier candidate never goes first")
+	for _, userID := range []string{"a", "b", "c"} {
+		testutil.AssertTrue(t, first[userID] > 0, "Tied candidate "+userID+" goes first at least once")
+	}
+}
+
+// TestLeastLoadedSelector_StatsError tests that a load lookup failure still returns
+// every candidate instead of failing the assignment
+func TestLeastLoadedSelector_StatsError(t *testing.T) {
+	repo := testutil.NewMockPRRepository()
+	repo.CountOpenByUsersFunc = func(ctx context.Context, userIDs []string) (map[string]int, error) {
+		return nil, fmt.Errorf("db down")
+	}
+	selector := leastLoadedSelector{prRepo: repo}
+
+	ordered := selector.Order(context.Background(), []string{"a", "b", "c"}, nil, 2)
+	testutil.AssertLen(t, ordered, 3, "Candidates are preserved")
+}
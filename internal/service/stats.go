@@ -3,7 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
-	"math/rand/v2"
+	"math"
+	"sync"
 	"time"
 
 	"reviewservice/internal/domain"
@@ -15,6 +16,8 @@ import (
 type StatsService struct {
 	prRepo   domain.PullRequestRepository
 	userRepo domain.UserRepository
+	teamRepo domain.TeamRepository
+	cfg      AssignmentConfig
 	logger   *zap.Logger
 }
 
@@ -22,11 +25,15 @@ type StatsService struct {
 func NewStatsService(
 	prRepo domain.PullRequestRepository,
 	userRepo domain.UserRepository,
+	teamRepo domain.TeamRepository,
+	cfg AssignmentConfig,
 	logger *zap.Logger,
 ) *StatsService {
 	return &StatsService{
 		prRepo:   prRepo,
 		userRepo: userRepo,
+		teamRepo: teamRepo,
+		cfg:      cfg,
 		logger:   logger,
 	}
 }
@@ -42,27 +49,55 @@ type PRStats struct {
 	AvgReviewersPerPR float64 `json:"avg_reviewers_per_pr"`
 }
 
-// GlobalStats представляет общую статистику сервиса
+// GlobalStats представляет общую статистику сервиса. В частичном ответе (Partial) раздел,
+// который не удалось получить, равен nil, а Errors перечисляет причины
 type GlobalStats struct {
-	PRStats   PRStats                         `json:"pr_stats"`
+	PRStats   *PRStats                        `json:"pr_stats"`
 	UserStats map[string]*UserAssignmentStats `json:"user_stats"`
+	Partial   bool                            `json:"partial,omitempty"`
+	Errors    []string                        `json:"errors,omitempty"`
 }
 
-// GetStats возвращает статистику по назначениям ревьюверов
-func (s *StatsService) GetStats(ctx context.Context) (*GlobalStats, error) {
+// GetStats возвращает статистику по назначениям ревьюверов. По умолчанию ошибка любого
+// из разделов прерывает запрос; с allowPartial раздел, который не удалось получить,
+// пропускается, а ошибка возвращается, только если не удалось получить ни один
+func (s *StatsService) GetStats(ctx context.Context, allowPartial bool) (*GlobalStats, error) {
 	s.logger.Info("calculating assignment statistics")
 
+	result := &GlobalStats{}
+
 	// Получаем статистику по PR через репозиторий
-	prStats, err := s.prRepo.GetPRStats(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get PR stats: %w", err)
+	prStats, prErr := s.prRepo.GetPRStats(ctx)
+	if prErr != nil {
+		prErr = fmt.Errorf("failed to get PR stats: %w", prErr)
+		if !allowPartial {
+			return nil, prErr
+		}
+		s.logger.Warn("returning stats without PR stats", zap.Error(prErr))
+		// Клиенту уходит только общий текст: исходная ошибка может содержать детали БД
+		result.Errors = append(result.Errors, "failed to get PR stats")
+	} else {
+		result.PRStats = &PRStats{
+			TotalPRs:          prStats["total"],
+			OpenPRs:           prStats["open"],
+			MergedPRs:         prStats["merged"],
+			AvgReviewersPerPR: float64(prStats["avg_reviewers"]) / 100.0, // Делим на 100 обратно
+		}
 	}
 
 	// Получаем статистику по пользователям
-	userStatsMap, err := s.prRepo.GetUserAssignmentStats(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user assignment stats: %w", err)
+	userStatsMap, userErr := s.prRepo.GetUserAssignmentStats(ctx)
+	if userErr != nil {
+		userErr = fmt.Errorf("failed to get user assignment stats: %w", userErr)
+		if !allowPartial || prErr != nil {
+			return nil, userErr
+		}
+		s.logger.Warn("returning stats without user stats", zap.Error(userErr))
+		result.Errors = append(result.Errors, "failed to get user assignment stats")
+		result.Partial = true
+		return result, nil
 	}
+	result.Partial = prErr != nil
 
 	// Обогащаем данными о пользователях (username)
 	enrichedUserStats := make(map[string]*UserAssignmentStats)
@@ -79,19 +114,12 @@ func (s *StatsService) GetStats(ctx context.Context) (*GlobalStats, error) {
 		enrichedUserStats[userID] = stats
 	}
 
-	result := &GlobalStats{
-		PRStats: PRStats{
-			TotalPRs:          prStats["total"],
-			OpenPRs:           prStats["open"],
-			MergedPRs:         prStats["merged"],
-			AvgReviewersPerPR: float64(prStats["avg_reviewers"]) / 100.0, // Делим на 100 обратно
-		},
-		UserStats: enrichedUserStats,
-	}
+	result.UserStats = enrichedUserStats
 
 	s.logger.Info("statistics calculated",
-		zap.Int("total_prs", result.PRStats.TotalPRs),
-		zap.Int("users_with_assignments", len(enrichedUserStats)))
+		zap.Int("total_prs", prStats["total"]),
+		zap.Int("users_with_assignments", len(enrichedUserStats)),
+		zap.Bool("partial", result.Partial))
 
 	return result, nil
 }
@@ -100,12 +128,17 @@ func (s *StatsService) GetStats(ctx context.Context) (*GlobalStats, error) {
 // и переназначает их открытые PR на активных членов команды.
 //
 // Деактивация пользователей выполняется атомарно одним запросом.
-// Переназначение PR происходит последовательно (best-effort) - если часть
-// переназначений не удалась, операция продолжается, а ошибки возвращаются
-// в результате для анализа.
+// Переназначение PR выполняется best-effort - если часть переназначений
+// не удалась, операция продолжается, а ошибки возвращаются в результате
+// для анализа. Разные PR обрабатываются параллельно до BulkReassignConcurrency
+// штук (по умолчанию последовательно); замены внутри одного PR - всегда по очереди.
 //
 // Оптимизировано для выполнения <100мс на средних объёмах.
 func (s *StatsService) BulkDeactivateTeam(ctx context.Context, teamName string) (*BulkDeactivateResult, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"team_name", &teamName}); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 	s.logger.Info("bulk deactivating team members", zap.String("team_name", teamName))
 
@@ -152,8 +185,27 @@ func (s *StatsService) BulkDeactivateTeam(ctx context.Context, teamName string)
 		zap.Int("count", len(deactivatedIDs)),
 		zap.Strings("user_ids", deactivatedIDs))
 
-	// Собираем все открытые PR деактивированных пользователей
-	totalReassigned := 0
+	// Составы команд запрашиваются один раз на всю операцию. Состав деактивированной
+	// команды уже известен: после пакетной деактивации все её участники неактивны
+	teams := newTeamCache(s.userRepo)
+	deactivatedMembers := make([]domain.User, len(allMembers))
+	for i, member := range allMembers {
+		member.IsActive = false
+		deactivatedMembers[i] = member
+	}
+	teams.put(teamName, deactivatedMembers)
+	finder := &fallbackFinder{
+		order:    s.cfg.fallbackOrder(),
+		teams:    teams,
+		userRepo: s.userRepo,
+		logger:   s.logger,
+	}
+
+	// Собираем открытые PR деактивированных пользователей, группируя их по PR: все замены
+	// в одном PR выполняет один воркер последовательно, иначе параллельные замены
+	// могли бы выбрать одного и того же нового ревьювера
+	var prOrder []string
+	prReviewers := make(map[string][]string)
 	reassignErrors := 0
 
 	for _, userID := range deactivatedIDs {
@@ -174,132 +226,55 @@ func (s *StatsService) BulkDeactivateTeam(ctx context.Context, teamName string)
 			zap.String("user_id", userID),
 			zap.Int("count", len(openPRs)))
 
-		// Для каждого PR пытаемся переназначить
 		for _, prID := range openPRs {
-			// Получаем текущих ревьюверов
-			currentReviewers, err := s.prRepo.GetReviewers(ctx, prID)
-			if err != nil {
-				s.logger.Error("failed to get reviewers",
-					zap.Error(err),
-					zap.String("pr_id", prID))
-				reassignErrors++
-				continue
-			}
-
-			// Получаем PR для информации об авторе (чтобы исключить его из кандидатов)
-			pr, err := s.prRepo.Get(ctx, prID)
-			if err != nil {
-				s.logger.Error("failed to get PR for reassignment",
-					zap.Error(err),
-					zap.String("pr_id", prID))
-				reassignErrors++
-				continue
-			}
-
-			// Получаем команду деактивируемого пользователя для поиска замены
-			user, err := s.userRepo.Get(ctx, userID)
-			if err != nil {
-				s.logger.Error("failed to get deactivated user",
-					zap.Error(err),
-					zap.String("user_id", userID))
-				reassignErrors++
-				continue
+			if _, ok := prReviewers[prID]; !ok {
+				prOrder = append(prOrder, prID)
 			}
+			prReviewers[prID] = append(prReviewers[prID], userID)
+		}
+	}
 
-			teamMembers, err := s.userRepo.GetByTeam(ctx, user.TeamName)
-			if err != nil {
-				s.logger.Error("failed to get team members for reassignment",
-					zap.Error(err),
-					zap.String("team", user.TeamName))
-				reassignErrors++
-				continue
-			}
+	// Независимые PR переназначаются пулом из BulkReassignConcurrency воркеров
+	workers := s.cfg.BulkReassignConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(prOrder) {
+		workers = len(prOrder)
+	}
 
-			// Шаг 1: Ищем кандидатов в команде деактивируемого пользователя
-			candidates := filterCandidates(teamMembers, pr.AuthorID, currentReviewers, userID)
-
-			// Шаг 2: Если не нашли, ищем в команде автора PR (если это другая команда)
-			if len(candidates) == 0 {
-				author, err := s.userRepo.Get(ctx, pr.AuthorID)
-				if err != nil {
-					s.logger.Error("failed to get author",
-						zap.Error(err),
-						zap.String("author_id", pr.AuthorID))
-				} else if author.TeamName != user.TeamName {
-					s.logger.Info("no candidates in reviewer team, searching in author team",
-						zap.String("pr_id", prID),
-						zap.String("reviewer_team", user.TeamName),
-						zap.String("author_team", author.TeamName))
-
-					authorTeamMembers, err := s.userRepo.GetByTeam(ctx, author.TeamName)
-					if err != nil {
-						s.logger.Error("failed to get author team members", zap.Error(err))
+	var (
+		mu              sync.Mutex
+		wg              sync.WaitGroup
+		totalReassigned int
+	)
+	jobs := make(chan string)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for prID := range jobs {
+				for _, userID := range prReviewers[prID] {
+					ok := s.reassignDeactivatedReviewer(ctx, teams, finder, prID, userID)
+
+					mu.Lock()
+					if ok {
+						totalReassigned++
 					} else {
-						candidates = filterCandidates(authorTeamMembers, pr.AuthorID, currentReviewers, userID)
+						reassignErrors++
 					}
+					mu.Unlock()
 				}
 			}
+		}()
+	}
 
-			// Шаг 3: Если всё ещё не нашли, ищем в других командах
-			if len(candidates) == 0 {
-				s.logger.Info("no candidates in author team, searching in other teams",
-					zap.String("pr_id", prID),
-					zap.String("exclude_team", user.TeamName))
-
-				otherUsers, err := s.userRepo.GetActiveUsersExcludingTeam(ctx, user.TeamName)
-				if err != nil {
-					s.logger.Error("failed to get users from other teams", zap.Error(err))
-				} else {
-					candidates = filterCandidates(otherUsers, pr.AuthorID, currentReviewers, userID)
-				}
-			}
-
-			if len(candidates) == 0 {
-				s.logger.Warn("no candidates for reassignment, removing reviewer without replacement",
-					zap.String("pr_id", prID),
-					zap.String("old_reviewer", userID),
-					zap.String("team", user.TeamName),
-					zap.Int("team_members_total", len(teamMembers)))
-
-				// Просто удаляем ревьювера без замены, т.к. нет активных кандидатов
-				err = s.prRepo.RemoveReviewer(ctx, prID, userID)
-				if err != nil {
-					s.logger.Error("failed to remove reviewer",
-						zap.Error(err),
-						zap.String("pr_id", prID),
-						zap.String("user_id", userID))
-					reassignErrors++
-				} else {
-					totalReassigned++ // Считаем как успешное "переназначение" (удаление)
-					s.logger.Info("reviewer removed (no replacement available)",
-						zap.String("pr_id", prID),
-						zap.String("removed_reviewer", userID))
-				}
-				continue
-			}
-
-			// Выбираем случайного кандидата
-			newReviewer := selectRandomCandidate(candidates)
-
-			// Переназначаем
-			err = s.prRepo.ReassignReviewer(ctx, prID, userID, newReviewer)
-			if err != nil {
-				s.logger.Error("failed to reassign reviewer",
-					zap.Error(err),
-					zap.String("pr_id", prID),
-					zap.String("old", userID),
-					zap.String("new", newReviewer))
-				reassignErrors++
-				continue
-			}
-
-			totalReassigned++
-			s.logger.Info("reviewer reassigned",
-				zap.String("pr_id", prID),
-				zap.String("old_reviewer", userID),
-				zap.String("new_reviewer", newReviewer))
-		}
+	for _, prID := range prOrder {
+		jobs <- prID
 	}
+	close(jobs)
+	wg.Wait()
 
 	elapsed := time.Since(start)
 	s.logger.Info("bulk deactivation completed",
@@ -316,39 +291,260 @@ func (s *StatsService) BulkDeactivateTeam(ctx context.Context, teamName string)
 	}, nil
 }
 
-// BulkDeactivateResult содержит результаты массовой деактивации
-type BulkDeactivateResult struct {
-	DeactivatedUsers []string `json:"deactivated_users"`
-	ReassignedPRs    int      `json:"reassigned_prs"`
-	Errors           int      `json:"errors,omitempty"`
+// reassignDeactivatedReviewer заменяет деактивированного ревьювера userID в PR prID
+// (или удаляет его, если замены нет). Возвращает false, если операция не удалась.
+// Вызывается конкурентно для разных PR, поэтому teams и finder должны быть общими
+func (s *StatsService) reassignDeactivatedReviewer(
+	ctx context.Context,
+	teams *teamCache,
+	finder *fallbackFinder,
+	prID, userID string,
+) bool {
+	// Получаем текущих ревьюверов
+	currentReviewers, err := s.prRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get reviewers",
+			zap.Error(err),
+			zap.String("pr_id", prID))
+		return false
+	}
+
+	// Получаем PR для информации об авторе (чтобы исключить его из кандидатов)
+	pr, err := s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR for reassignment",
+			zap.Error(err),
+			zap.String("pr_id", prID))
+		return false
+	}
+
+	// Получаем команду деактивируемого пользователя для поиска замены
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get deactivated user",
+			zap.Error(err),
+			zap.String("user_id", userID))
+		return false
+	}
+
+	teamMembers, err := teams.get(ctx, user.TeamName)
+	if err != nil {
+		s.logger.Error("failed to get team members for reassignment",
+			zap.Error(err),
+			zap.String("team", user.TeamName))
+		return false
+	}
+
+	conflicts, err := s.userRepo.GetReviewConflicts(ctx, pr.AuthorID)
+	if err != nil {
+		s.logger.Error("failed to get review conflicts",
+			zap.Error(err),
+			zap.String("pr_id", prID))
+		return false
+	}
+
+	// Ищем кандидатов по источникам в порядке REASSIGN_FALLBACK_ORDER
+	candidates, _ := finder.find(ctx, pr, user.TeamName, func(users []domain.User) []string {
+		return s.cfg.filterReassignCandidates(users, pr.AuthorID, currentReviewers, conflicts, userID)
+	})
+
+	if len(candidates) == 0 {
+		s.logger.Warn("no candidates for reassignment, removing reviewer without replacement",
+			zap.String("pr_id", prID),
+			zap.String("old_reviewer", userID),
+			zap.String("team", user.TeamName),
+			zap.Int("team_members_total", len(teamMembers)))
+
+		// Просто удаляем ревьювера без замены, т.к. нет активных кандидатов
+		if err := s.prRepo.RemoveReviewer(ctx, prID, userID); err != nil {
+			s.logger.Error("failed to remove reviewer",
+				zap.Error(err),
+				zap.String("pr_id", prID),
+				zap.String("user_id", userID))
+			return false
+		}
+		recordReviewerHistory(ctx, s.prRepo, s.logger, removeEntry(prID, userID))
+
+		// Считаем как успешное "переназначение" (удаление)
+		s.logger.Info("reviewer removed (no replacement available)",
+			zap.String("pr_id", prID),
+			zap.String("removed_reviewer", userID))
+		return true
+	}
+
+	// Выбираем случайного кандидата, перепроверяя его активность
+	newReviewer, err := pickActiveCandidate(ctx, s.userRepo, candidates, s.logger)
+	if err != nil {
+		s.logger.Error("failed to pick replacement",
+			zap.Error(err),
+			zap.String("pr_id", prID),
+			zap.String("old_reviewer", userID))
+		return false
+	}
+	if newReviewer == "" {
+		s.logger.Warn("all candidates became inactive, skipping reassignment",
+			zap.String("pr_id", prID),
+			zap.String("old_reviewer", userID))
+		return false
+	}
+
+	// Переназначаем
+	if err := s.prRepo.ReassignReviewer(ctx, prID, userID, newReviewer); err != nil {
+		s.logger.Error("failed to reassign reviewer",
+			zap.Error(err),
+			zap.String("pr_id", prID),
+			zap.String("old", userID),
+			zap.String("new", newReviewer))
+		return false
+	}
+	recordReviewerHistory(ctx, s.prRepo, s.logger, reassignEntry(prID, userID, newReviewer))
+
+	s.logger.Info("reviewer reassigned",
+		zap.String("pr_id", prID),
+		zap.String("old_reviewer", userID),
+		zap.String("new_reviewer", newReviewer))
+	return true
+}
+
+// maxTrendRange - максимальная длина запрашиваемого периода трендов
+const maxTrendRange = 366 * 24 * time.Hour
+
+// GetTrends возвращает временной ряд созданных и смердженных PR между from и to
+// (включительно) с шагом interval. Интервалы без активности содержат нули.
+// Непустой teamName ограничивает ряд PR авторов из этой команды (ErrNotFound для неизвестной)
+func (s *StatsService) GetTrends(
+	ctx context.Context,
+	from, to time.Time,
+	interval domain.TrendInterval,
+	teamName string,
+) ([]domain.TrendBucket, error) {
+	if !interval.IsValid() {
+		return nil, fmt.Errorf("unsupported interval %q: %w", interval, domain.ErrInvalidInput)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("range end is before start: %w", domain.ErrInvalidInput)
+	}
+	if to.Sub(from) > maxTrendRange {
+		return nil, fmt.Errorf("range exceeds %d days: %w", int(maxTrendRange.Hours()/24), domain.ErrInvalidInput)
+	}
+
+	teamName, err := s.teamFilter(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := s.prRepo.GetTrends(ctx, from, to, interval, teamName)
+	if err != nil {
+		s.logger.Error("failed to get trends", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get trends: %w", err)
+	}
+
+	return buckets, nil
 }
 
-// filterCandidates фильтрует кандидатов для замены ревьювера
-func filterCandidates(teamMembers []domain.User, authorID string, currentReviewers []string, excludeUserID string) []string {
-	excluded := make(map[string]bool)
-	excluded[authorID] = true
-	excluded[excludeUserID] = true
-	for _, reviewerID := range currentReviewers {
-		excluded[reviewerID] = true
+// GetAuthorStats возвращает число PR каждого автора (всего, открытых, смердженных)
+// от авторов с наибольшим числом PR. Непустой teamName оставляет только авторов
+// этой команды (ErrNotFound для неизвестной)
+func (s *StatsService) GetAuthorStats(ctx context.Context, teamName string) ([]domain.AuthorStats, error) {
+	teamName, err := s.teamFilter(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.prRepo.GetAuthorStats(ctx, teamName)
+	if err != nil {
+		s.logger.Error("failed to get author stats", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get author stats: %w", err)
 	}
 
-	var candidates []string
-	for _, member := range teamMembers {
-		if member.IsActive && !excluded[member.UserID] {
-			candidates = append(candidates, member.UserID)
+	return stats, nil
+}
+
+// GetTeamLoadStats возвращает по каждой команде число назначений на открытые PR и число
+// активных ревьюверов, от самых загруженных команд
+func (s *StatsService) GetTeamLoadStats(ctx context.Context) ([]domain.TeamLoadStats, error) {
+	stats, err := s.prRepo.GetTeamLoadStats(ctx)
+	if err != nil {
+		s.logger.Error("failed to get team load stats", zap.Error(err))
+		return nil, fmt.Errorf("failed to get team load stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// TeamCapacity описывает нагрузку ревью на активных участников команды
+type TeamCapacity struct {
+	TeamName         string  `json:"team_name"`
+	ActiveMembers    int     `json:"active_members"`
+	OpenAssignments  int     `json:"open_assignments"`
+	AvgOpenPerMember float64 `json:"avg_open_per_member"`
+	MaxOpenPerMember int     `json:"max_open_per_member"`
+}
+
+// GetTeamCapacity возвращает число активных участников команды, их суммарное число
+// назначений на открытые PR и среднее/максимальное число таких назначений на участника.
+// Неактивные участники не учитываются: новые PR им не назначаются
+func (s *StatsService) GetTeamCapacity(ctx context.Context, teamName string) (*TeamCapacity, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"team_name", &teamName}); err != nil {
+		return nil, err
+	}
+
+	members, err := teamMembers(ctx, s.userRepo, s.teamRepo, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	userStats, err := s.prRepo.GetUserAssignmentStats(ctx)
+	if err != nil {
+		s.logger.Error("failed to get user assignment stats", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get user assignment stats: %w", err)
+	}
+
+	capacity := &TeamCapacity{TeamName: teamName}
+	for _, member := range members {
+		if !member.IsActive {
+			continue
 		}
+		capacity.ActiveMembers++
+
+		open := 0
+		if stats, ok := userStats[member.UserID]; ok {
+			open = stats.OpenPRs
+		}
+		capacity.OpenAssignments += open
+		if open > capacity.MaxOpenPerMember {
+			capacity.MaxOpenPerMember = open
+		}
+	}
+
+	if capacity.ActiveMembers > 0 {
+		avg := float64(capacity.OpenAssignments) / float64(capacity.ActiveMembers)
+		capacity.AvgOpenPerMember = math.Round(avg*100) / 100
 	}
 
-	return candidates
+	return capacity, nil
 }
 
-// selectRandomCandidate выбирает случайного кандидата из списка
-func selectRandomCandidate(candidates []string) string {
-	if len(candidates) == 0 {
-		return ""
+// teamFilter нормализует необязательный фильтр по команде и проверяет, что команда существует.
+// Пустой teamName означает отсутствие фильтра
+func (s *StatsService) teamFilter(ctx context.Context, teamName string) (string, error) {
+	if teamName == "" {
+		return "", nil
+	}
+	if err := s.cfg.IDs.normalizeFields(idField{"team_name", &teamName}); err != nil {
+		return "", err
+	}
+
+	if _, err := teamMembers(ctx, s.userRepo, s.teamRepo, teamName); err != nil {
+		return "", err
 	}
 
-	// Используем crypto/rand для production, но math/rand быстрее для нагрузки
-	idx := rand.IntN(len(candidates))
-	return candidates[idx]
+	return teamName, nil
+}
+
+// BulkDeactivateResult содержит результаты массовой деактивации
+type BulkDeactivateResult struct {
+	DeactivatedUsers []string `json:"deactivated_users"`
+	ReassignedPRs    int      `json:"reassigned_prs"`
+	Errors           int      `json:"errors,omitempty"`
 }
@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"reviewservice/internal/domain"
 
@@ -92,10 +96,10 @@ func TestStatsService_GetStats(t *testing.T) {
 			tt.setupMocks(prRepo, userRepo)
 
 			logger := zap.NewNop()
-			svc := NewStatsService(prRepo, userRepo, logger)
+			svc := NewStatsService(prRepo, userRepo, testutil.NewMockTeamRepository(), AssignmentConfig{}, logger)
 
 			// Act
-			stats, err := svc.GetStats(context.Background())
+			stats, err := svc.GetStats(context.Background(), false)
 
 			// Assert
 			testutil.AssertNoError(t, err)
@@ -108,6 +112,39 @@ func TestStatsService_GetStats(t *testing.T) {
 	}
 }
 
+// TestStatsService_GetStats_Partial tests that a failing sub-query fails strict requests
+// but is skipped in partial mode
+func TestStatsService_GetStats_Partial(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	prRepo.PRs["pr-1"] = &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2"},
+	}
+	dbErr := errors.New("connection reset")
+	prRepo.GetUserAssignmentStatsFunc = func(ctx context.Context) (map[string]*domain.UserAssignmentStats, error) {
+		return nil, dbErr
+	}
+	svc := NewStatsService(prRepo, testutil.NewMockUserRepository(), testutil.NewMockTeamRepository(), AssignmentConfig{}, zap.NewNop())
+
+	_, err := svc.GetStats(context.Background(), false)
+	testutil.AssertTrue(t, errors.Is(err, dbErr), "Strict mode returns the sub-query error")
+
+	stats, err := svc.GetStats(context.Background(), true)
+	testutil.AssertNoError(t, err)
+	testutil.AssertTrue(t, stats.Partial, "Response is partial")
+	testutil.AssertEqual(t, stats.PRStats.TotalPRs, 1, "PR stats still returned")
+	testutil.AssertTrue(t, stats.UserStats == nil, "User stats are missing")
+	testutil.AssertEqual(t, stats.Errors, []string{"failed to get user assignment stats"}, "Errors")
+
+	// When every section fails there is nothing to return
+	prRepo.GetPRStatsFunc = func(ctx context.Context) (map[string]int, error) {
+		return nil, dbErr
+	}
+	_, err = svc.GetStats(context.Background(), true)
+	testutil.AssertTrue(t, errors.Is(err, dbErr), "Partial mode fails when all sections fail")
+}
+
 // TestStatsService_BulkDeactivateTeam tests bulk team deactivation
 func TestStatsService_BulkDeactivateTeam(t *testing.T) {
 	tests := []struct {
@@ -226,7 +263,7 @@ func TestStatsService_BulkDeactivateTeam(t *testing.T) {
 			tt.setupMocks(prRepo, userRepo)
 
 			logger := zap.NewNop()
-			svc := NewStatsService(prRepo, userRepo, logger)
+			svc := NewStatsService(prRepo, userRepo, testutil.NewMockTeamRepository(), AssignmentConfig{}, logger)
 
 			// Act
 			result, err := svc.BulkDeactivateTeam(context.Background(), tt.teamName)
@@ -246,3 +283,342 @@ func TestStatsService_BulkDeactivateTeam(t *testing.T) {
 		})
 	}
 }
+
+// TestStatsService_BulkDeactivateTeam_FetchesEachTeamOnce tests that team membership
+// is looked up once per distinct team during a bulk operation
+func TestStatsService_BulkDeactivateTeam_FetchesEachTeamOnce(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+
+	userRepo.Users["b1"] = &domain.User{UserID: "b1", TeamName: "backend", IsActive: true}
+	userRepo.Users["b2"] = &domain.User{UserID: "b2", TeamName: "backend", IsActive: true}
+	userRepo.Users["f1"] = &domain.User{UserID: "f1", TeamName: "frontend", IsActive: true}
+	userRepo.Users["f2"] = &domain.User{UserID: "f2", TeamName: "frontend", IsActive: true}
+	userRepo.Users["f3"] = &domain.User{UserID: "f3", TeamName: "frontend", IsActive: true}
+
+	// Every PR falls back to the author team, which used to be re-fetched per PR
+	for _, id := range []string{"pr-1", "pr-2", "pr-3", "pr-4"} {
+		prRepo.PRs[id] = &domain.PullRequest{
+			PullRequestID:     id,
+			AuthorID:          "f1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"b1", "b2"},
+		}
+	}
+
+	calls := make(map[string]int)
+	userRepo.GetByTeamFunc = func(ctx context.Context, teamName string) ([]domain.User, error) {
+		calls[teamName]++
+		users := make([]domain.User, 0)
+		for _, user := range userRepo.Users {
+			if user.TeamName == teamName {
+				users = append(users, *user)
+			}
+		}
+		return users, nil
+	}
+
+	svc := NewStatsService(prRepo, userRepo, testutil.NewMockTeamRepository(), AssignmentConfig{}, zap.NewNop())
+
+	result, err := svc.BulkDeactivateTeam(context.Background(), "backend")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, result.Errors, 0, "Should have no errors")
+	testutil.AssertEqual(t, calls["backend"], 1, "Deactivated team fetched once")
+	testutil.AssertEqual(t, calls["frontend"], 1, "Author team fetched once")
+}
+
+// TestStatsService_BulkDeactivateTeam_Concurrent tests that a worker pool reassigns
+// independent PRs in parallel, never runs two reassignments of one PR at once
+// and aggregates the error count across workers
+func TestStatsService_BulkDeactivateTeam_Concurrent(t *testing.T) {
+	prRepo := testutil.NewMockPRRepository()
+	userRepo := testutil.NewMockUserRepository()
+
+	userRepo.Users["b1"] = &domain.User{UserID: "b1", TeamName: "backend", IsActive: true}
+	userRepo.Users["b2"] = &domain.User{UserID: "b2", TeamName: "backend", IsActive: true}
+	for _, id := range []string{"f1", "f2", "f3", "f4"} {
+		userRepo.Users[id] = &domain.User{UserID: id, TeamName: "frontend", IsActive: true}
+	}
+
+	failing := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		id := fmt.Sprintf("pr-%02d", i)
+		prRepo.PRs[id] = &domain.PullRequest{
+			PullRequestID:     id,
+			AuthorID:          "f1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"b1", "b2"},
+		}
+		if i%5 == 0 {
+			failing[id] = true
+		}
+	}
+
+	var (
+		mu          sync.Mutex
+		inFlight    int
+		maxInFlight int
+		overlapped  bool
+	)
+	busy := make(map[string]bool)
+	prRepo.ReassignReviewerFunc = func(ctx context.Context, prID, oldID, newID string) error {
+		mu.Lock()
+		if busy[prID] {
+			overlapped = true
+		}
+		busy[prID] = true
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		busy[prID] = false
+		inFlight--
+		if failing[prID] {
+			return errors.New("reassign failed")
+		}
+		reviewers := prRepo.PRs[prID].AssignedReviewers
+		for i, r := range reviewers {
+			if r == oldID {
+				reviewers[i] = newID
+			}
+		}
+		return nil
+	}
+
+	svc := NewStatsService(prRepo, userRepo, testutil.NewMockTeamRepository(), AssignmentConfig{BulkReassignConcurrency: 4}, zap.NewNop())
+
+	result, err := svc.BulkDeactivateTeam(context.Background(), "backend")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, result.Errors, 8, "Both reviewers of 4 failing PRs counted")
+	testutil.AssertEqual(t, result.ReassignedPRs, 32, "Remaining replacements counted")
+	testutil.AssertTrue(t, maxInFlight > 1, "PRs reassigned in parallel")
+	testutil.AssertTrue(t, maxInFlight <= 4, "Concurrency limit respected")
+	testutil.AssertFalse(t, overlapped, "One PR never reassigned concurrently")
+
+	for id, pr := range prRepo.PRs {
+		if failing[id] {
+			continue
+		}
+		testutil.AssertLen(t, pr.AssignedReviewers, 2, "Reviewer count preserved for %s", id)
+		testutil.AssertTrue(t, pr.AssignedReviewers[0] != pr.AssignedReviewers[1], "Distinct reviewers for %s", id)
+		testutil.AssertNotContains(t, pr.AssignedReviewers, "f1", "Author never assigned to %s", id)
+	}
+}
+
+// TestStatsService_GetTrends tests trend range/interval validation and zero-filled buckets
+func TestStatsService_GetTrends(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	at := func(d, h int) *time.Time { ts := time.Date(2024, 5, d, h, 0, 0, 0, time.UTC); return &ts }
+
+	prRepo := testutil.NewMockPRRepository()
+	prRepo.PRs["pr-1"] = &domain.PullRequest{PullRequestID: "pr-1", CreatedAt: at(10, 9), MergedAt: at(12, 15)}
+	prRepo.PRs["pr-2"] = &domain.PullRequest{PullRequestID: "pr-2", CreatedAt: at(10, 18)}
+
+	svc := NewStatsService(prRepo, testutil.NewMockUserRepository(), testutil.NewMockTeamRepository(), AssignmentConfig{}, zap.NewNop())
+
+	t.Run("returns contiguous daily buckets", func(t *testing.T) {
+		buckets, err := svc.GetTrends(context.Background(), day(10), day(12), domain.TrendIntervalDay, "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, buckets, []domain.TrendBucket{
+			{Date: "2024-05-10", Created: 2},
+			{Date: "2024-05-11"},
+			{Date: "2024-05-12", Merged: 1},
+		}, "Buckets")
+	})
+
+	t.Run("rejects unknown interval", func(t *testing.T) {
+		_, err := svc.GetTrends(context.Background(), day(10), day(12), domain.TrendInterval("month"), "")
+
+		testutil.AssertTrue(t, errors.Is(err, domain.ErrInvalidInput), "Invalid interval error")
+	})
+
+	t.Run("rejects inverted range", func(t *testing.T) {
+		_, err := svc.GetTrends(context.Background(), day(12), day(10), domain.TrendIntervalDay, "")
+
+		testutil.AssertTrue(t, errors.Is(err, domain.ErrInvalidInput), "Inverted range error")
+	})
+
+	t.Run("rejects too long range", func(t *testing.T) {
+		_, err := svc.GetTrends(context.Background(), day(1), day(1).AddDate(2, 0, 0), domain.TrendIntervalWeek, "")
+
+		testutil.AssertTrue(t, errors.Is(err, domain.ErrInvalidInput), "Too long range error")
+	})
+}
+
+// TestStatsService_GetTrends_Team tests that team_name limits the series to PRs authored by that team
+func TestStatsService_GetTrends_Team(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	at := func(d, h int) *time.Time { ts := time.Date(2024, 5, d, h, 0, 0, 0, time.UTC); return &ts }
+
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["b1"] = &domain.User{UserID: "b1", TeamName: "backend", IsActive: true}
+	userRepo.Users["f1"] = &domain.User{UserID: "f1", TeamName: "frontend", IsActive: true}
+
+	prRepo := testutil.NewMockPRRepository()
+	prRepo.AuthorTeams = map[string]string{"b1": "backend", "f1": "frontend"}
+	prRepo.PRs["pr-b1"] = &domain.PullRequest{PullRequestID: "pr-b1", AuthorID: "b1", CreatedAt: at(10, 9), MergedAt: at(11, 10)}
+	prRepo.PRs["pr-b2"] = &domain.PullRequest{PullRequestID: "pr-b2", AuthorID: "b1", CreatedAt: at(11, 12)}
+	prRepo.PRs["pr-f1"] = &domain.PullRequest{PullRequestID: "pr-f1", AuthorID: "f1", CreatedAt: at(10, 14), MergedAt: at(10, 16)}
+
+	svc := NewStatsService(prRepo, userRepo, testutil.NewMockTeamRepository(), AssignmentConfig{}, zap.NewNop())
+
+	t.Run("filters by author team", func(t *testing.T) {
+		buckets, err := svc.GetTrends(context.Background(), day(10), day(11), domain.TrendIntervalDay, "backend")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, buckets, []domain.TrendBucket{
+			{Date: "2024-05-10", Created: 1},
+			{Date: "2024-05-11", Created: 1, Merged: 1},
+		}, "Backend buckets")
+	})
+
+	t.Run("other team gets its own series", func(t *testing.T) {
+		buckets, err := svc.GetTrends(context.Background(), day(10), day(11), domain.TrendIntervalDay, "frontend")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, buckets, []domain.TrendBucket{
+			{Date: "2024-05-10", Created: 1, Merged: 1},
+			{Date: "2024-05-11"},
+		}, "Frontend buckets")
+	})
+
+	t.Run("returns not found for unknown team", func(t *testing.T) {
+		_, err := svc.GetTrends(context.Background(), day(10), day(11), domain.TrendIntervalDay, "mobile")
+
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestStatsService_GetAuthorStats tests per-author PR buckets, ordering and the team filter
+func TestStatsService_GetAuthorStats(t *testing.T) {
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["b1"] = &domain.User{UserID: "b1", TeamName: "backend", IsActive: true}
+	userRepo.Users["b2"] = &domain.User{UserID: "b2", TeamName: "backend", IsActive: true}
+	userRepo.Users["f1"] = &domain.User{UserID: "f1", TeamName: "frontend", IsActive: true}
+
+	prRepo := testutil.NewMockPRRepository()
+	prRepo.AuthorTeams = map[string]string{"b1": "backend", "b2": "backend", "f1": "frontend"}
+	prRepo.PRs["pr-b1-1"] = &domain.PullRequest{PullRequestID: "pr-b1-1", AuthorID: "b1", Status: domain.PRStatusOpen}
+	prRepo.PRs["pr-b2-1"] = &domain.PullRequest{PullRequestID: "pr-b2-1", AuthorID: "b2", Status: domain.PRStatusOpen}
+	prRepo.PRs["pr-b2-2"] = &domain.PullRequest{PullRequestID: "pr-b2-2", AuthorID: "b2", Status: domain.PRStatusMerged}
+	prRepo.PRs["pr-b2-3"] = &domain.PullRequest{PullRequestID: "pr-b2-3", AuthorID: "b2", Status: domain.PRStatusMerged}
+	prRepo.PRs["pr-f1-1"] = &domain.PullRequest{PullRequestID: "pr-f1-1", AuthorID: "f1", Status: domain.PRStatusMerged}
+
+	svc := NewStatsService(prRepo, userRepo, testutil.NewMockTeamRepository(), AssignmentConfig{}, zap.NewNop())
+
+	t.Run("all authors ordered by total", func(t *testing.T) {
+		stats, err := svc.GetAuthorStats(context.Background(), "")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, stats, []domain.AuthorStats{
+			{AuthorID: "b2", TotalPRs: 3, OpenPRs: 1, MergedPRs: 2},
+			{AuthorID: "b1", TotalPRs: 1, OpenPRs: 1},
+			{AuthorID: "f1", TotalPRs: 1, MergedPRs: 1},
+		}, "Author buckets")
+	})
+
+	t.Run("filters by author team", func(t *testing.T) {
+		stats, err := svc.GetAuthorStats(context.Background(), "frontend")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, stats, []domain.AuthorStats{
+			{AuthorID: "f1", TotalPRs: 1, MergedPRs: 1},
+		}, "Frontend authors")
+	})
+
+	t.Run("returns not found for unknown team", func(t *testing.T) {
+		_, err := svc.GetAuthorStats(context.Background(), "mobile")
+
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestStatsService_GetTeamLoadStats tests that per-team load is passed through in repository order
+// and that repository failures are wrapped
+func TestStatsService_GetTeamLoadStats(t *testing.T) {
+	t.Run("returns teams ordered by open assignments", func(t *testing.T) {
+		prRepo := testutil.NewMockPRRepository()
+		prRepo.GetTeamLoadStatsFunc = func(ctx context.Context) ([]domain.TeamLoadStats, error) {
+			return []domain.TeamLoadStats{
+				{TeamName: "backend", OpenAssignments: 5, ActiveReviewers: 2},
+				{TeamName: "frontend", OpenAssignments: 0, ActiveReviewers: 3},
+			}, nil
+		}
+		svc := NewStatsService(prRepo, testutil.NewMockUserRepository(), testutil.NewMockTeamRepository(), AssignmentConfig{}, zap.NewNop())
+
+		stats, err := svc.GetTeamLoadStats(context.Background())
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, stats, []domain.TeamLoadStats{
+			{TeamName: "backend", OpenAssignments: 5, ActiveReviewers: 2},
+			{TeamName: "frontend", OpenAssignments: 0, ActiveReviewers: 3},
+		}, "Team load")
+	})
+
+	t.Run("wraps repository error", func(t *testing.T) {
+		dbErr := errors.New("db down")
+		prRepo := testutil.NewMockPRRepository()
+		prRepo.GetTeamLoadStatsFunc = func(ctx context.Context) ([]domain.TeamLoadStats, error) {
+			return nil, dbErr
+		}
+		svc := NewStatsService(prRepo, testutil.NewMockUserRepository(), testutil.NewMockTeamRepository(), AssignmentConfig{}, zap.NewNop())
+
+		_, err := svc.GetTeamLoadStats(context.Background())
+
+		testutil.AssertTrue(t, errors.Is(err, dbErr), "Repository error is wrapped")
+	})
+}
+
+// TestStatsService_GetTeamCapacity tests per-member open assignment average and max
+// for a loaded team, ignoring inactive members and other teams
+func TestStatsService_GetTeamCapacity(t *testing.T) {
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["b1"] = &domain.User{UserID: "b1", TeamName: "backend", IsActive: true}
+	userRepo.Users["b2"] = &domain.User{UserID: "b2", TeamName: "backend", IsActive: true}
+	userRepo.Users["b3"] = &domain.User{UserID: "b3", TeamName: "backend", IsActive: true}
+	userRepo.Users["b4"] = &domain.User{UserID: "b4", TeamName: "backend", IsActive: false}
+	userRepo.Users["f1"] = &domain.User{UserID: "f1", TeamName: "frontend", IsActive: true}
+
+	prRepo := testutil.NewMockPRRepository()
+	open := func(id string, reviewers ...string) {
+		prRepo.PRs[id] = &domain.PullRequest{PullRequestID: id, Status: domain.PRStatusOpen, AssignedReviewers: reviewers}
+	}
+	open("pr-1", "b1", "b2")
+	open("pr-2", "b1", "f1")
+	open("pr-3", "b1", "b4")
+	prRepo.PRs["pr-4"] = &domain.PullRequest{PullRequestID: "pr-4", Status: domain.PRStatusMerged, AssignedReviewers: []string{"b2", "b3"}}
+
+	svc := NewStatsService(prRepo, userRepo, testutil.NewMockTeamRepository(), AssignmentConfig{}, zap.NewNop())
+
+	t.Run("loaded team", func(t *testing.T) {
+		capacity, err := svc.GetTeamCapacity(context.Background(), "backend")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, *capacity, TeamCapacity{
+			TeamName:         "backend",
+			ActiveMembers:    3,
+			OpenAssignments:  4,
+			AvgOpenPerMember: 1.33,
+			MaxOpenPerMember: 3,
+		}, "Backend capacity")
+	})
+
+	t.Run("returns not found for unknown team", func(t *testing.T) {
+		_, err := svc.GetTeamCapacity(context.Background(), "mobile")
+
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("requires team name", func(t *testing.T) {
+		_, err := svc.GetTeamCapacity(context.Background(), " ")
+
+		testutil.AssertTrue(t, errors.Is(err, domain.ErrInvalidInput), "Blank team name error")
+	})
+}
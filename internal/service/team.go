@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"go.uber.org/zap"
 	"reviewservice/internal/domain"
@@ -14,6 +17,7 @@ import (
 type TeamService struct {
 	teamRepo  domain.TeamRepository
 	userRepo  domain.UserRepository
+	users     *UserService
 	txManager *postgres.TxManager
 	logger    *zap.Logger
 }
@@ -22,12 +26,14 @@ type TeamService struct {
 func NewTeamService(
 	teamRepo domain.TeamRepository,
 	userRepo domain.UserRepository,
+	users *UserService,
 	txManager *postgres.TxManager,
 	logger *zap.Logger,
 ) *TeamService {
 	return &TeamService{
 		teamRepo:  teamRepo,
 		userRepo:  userRepo,
+		users:     users,
 		txManager: txManager,
 		logger:    logger,
 	}
@@ -36,6 +42,10 @@ func NewTeamService(
 // CreateTeam создаёт команду и добавляет/обновляет её участников
 // Операция выполняется в транзакции для атомарности
 func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+	if err := s.normalizeTeamIDs(&team.TeamName, team.Members); err != nil {
+		return nil, err
+	}
+
 	// Проверяем, существует ли команда
 	exists, err := s.teamRepo.Exists(ctx, team.TeamName)
 	if err != nil {
@@ -47,12 +57,17 @@ func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) (*domai
 		return nil, domain.ErrTeamExists
 	}
 
+	if team.BackupTeamName != "" {
+		if err := s.checkBackupTeam(ctx, team.TeamName, &team.BackupTeamName); err != nil {
+			return nil, err
+		}
+	}
+
 	// Выполняем всю операцию в транзакции
-	err = s.txManager.WithinTransaction(ctx, func(tx *sql.Tx) error {
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		// Создаём команду
-		query := `INSERT INTO teams (team_name) VALUES ($1)`
-		if _, err := tx.ExecContext(ctx, query, team.TeamName); err != nil {
-			return fmt.Errorf("failed to create team: %w", err)
+		if err := s.teamRepo.Create(ctx, team); err != nil {
+			return err
 		}
 
 		s.logger.Info("team created in transaction", zap.String("team_name", team.TeamName))
@@ -69,10 +84,13 @@ func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) (*domai
 			if !exists {
 				// Создаём нового пользователя
 				insertQuery := `
-					INSERT INTO users (user_id, username, team_name, is_active)
-					VALUES ($1, $2, $3, $4)
+					INSERT INTO users (user_id, username, team_name, is_active, review_group, seniority, max_daily_assignments,
+					                   available_from, available_to)
+					VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, NULLIF($7, 0), $8, $9)
 				`
-				if _, err := tx.ExecContext(ctx, insertQuery, member.UserID, member.Username, team.TeamName, member.IsActive); err != nil {
+				if _, err := tx.ExecContext(ctx, insertQuery,
+					member.UserID, member.Username, team.TeamName, member.IsActive, member.ReviewGroup, member.Seniority, member.MaxDailyAssignments,
+					member.AvailableFrom, member.AvailableTo); err != nil {
 					return fmt.Errorf("failed to create user %s: %w", member.UserID, err)
 				}
 				s.logger.Info("user created in transaction",
@@ -82,10 +100,13 @@ func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) (*domai
 				// Обновляем существующего пользователя
 				updateQuery := `
 					UPDATE users
-					SET username = $2, team_name = $3, is_active = $4
+					SET username = $2, team_name = $3, is_active = $4, review_group = NULLIF($5, ''), seniority = $6,
+					    max_daily_assignments = NULLIF($7, 0), available_from = $8, available_to = $9
 					WHERE user_id = $1
 				`
-				if _, err := tx.ExecContext(ctx, updateQuery, member.UserID, member.Username, team.TeamName, member.IsActive); err != nil {
+				if _, err := tx.ExecContext(ctx, updateQuery,
+					member.UserID, member.Username, team.TeamName, member.IsActive, member.ReviewGroup, member.Seniority, member.MaxDailyAssignments,
+					member.AvailableFrom, member.AvailableTo); err != nil {
 					return fmt.Errorf("failed to update user %s: %w", member.UserID, err)
 				}
 				s.logger.Info("user updated in transaction",
@@ -110,6 +131,10 @@ func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) (*domai
 
 // GetTeam получает команду с участниками
 func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	if err := s.ids().normalizeFields(idField{"team_name", &teamName}); err != nil {
+		return nil, err
+	}
+
 	team, err := s.teamRepo.Get(ctx, teamName)
 	if err != nil {
 		s.logger.Error("failed to get team", zap.Error(err), zap.String("team_name", teamName))
@@ -118,3 +143,214 @@ func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*domain.Tea
 
 	return team, nil
 }
+
+// PatchTeam применяет к команде частичное изменение в одной транзакции: добавляет
+// участников, исключает участников и меняет число ревьюверов на PR.
+// Исключённые участники деактивируются (их авторские PR остаются в истории),
+// а их открытые ревью переназначаются так же, как при деактивации
+func (s *TeamService) PatchTeam(ctx context.Context, patch *domain.TeamPatch) (*domain.Team, error) {
+	if err := s.normalizeTeamIDs(&patch.TeamName, patch.AddMembers); err != nil {
+		return nil, err
+	}
+	if err := s.ids().normalizeAll("user_id", patch.RemoveMembers); err != nil {
+		return nil, err
+	}
+	if err := validateTeamPatch(patch); err != nil {
+		return nil, err
+	}
+	if patch.SetBackupTeamName != nil && *patch.SetBackupTeamName != "" {
+		if err := s.checkBackupTeam(ctx, patch.TeamName, patch.SetBackupTeamName); err != nil {
+			return nil, err
+		}
+	}
+
+	exists, err := s.teamRepo.Exists(ctx, patch.TeamName)
+	if err != nil {
+		s.logger.Error("failed to check team existence", zap.Error(err), zap.String("team_name", patch.TeamName))
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context, _ *sql.Tx) error {
+		for _, member := range patch.AddMembers {
+			if _, err := s.upsertMember(ctx, patch.TeamName, member); err != nil {
+				return err
+			}
+		}
+
+		// Сначала деактивируем всех исключаемых, чтобы они не стали заменой друг другу
+		for _, userID := range patch.RemoveMembers {
+			user, err := s.userRepo.Get(ctx, userID)
+			if err != nil {
+				return err
+			}
+			if user.TeamName != patch.TeamName {
+				return fmt.Errorf("user %s is not a member of team %s: %w", userID, patch.TeamName, domain.ErrInvalidInput)
+			}
+			if err := s.userRepo.SetIsActive(ctx, userID, false); err != nil {
+				return fmt.Errorf("failed to deactivate user %s: %w", userID, err)
+			}
+		}
+		for _, userID := range patch.RemoveMembers {
+			if err := s.users.reassignUserPRs(ctx, userID, patch.TeamName); err != nil {
+				return fmt.Errorf("failed to reassign PRs of user %s: %w", userID, err)
+			}
+		}
+
+		if patch.SetReviewerCount != nil {
+			if err := s.teamRepo.SetReviewerCount(ctx, patch.TeamName, *patch.SetReviewerCount); err != nil {
+				return err
+			}
+		}
+
+		if patch.SetBackupTeamName != nil {
+			if err := s.teamRepo.SetBackupTeam(ctx, patch.TeamName, *patch.SetBackupTeamName); err != nil {
+				return err
+			}
+		}
+
+		if patch.SetCalendar != nil {
+			if err := s.teamRepo.SetCalendar(ctx, patch.TeamName, patch.SetCalendar); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to patch team", zap.Error(err), zap.String("team_name", patch.TeamName))
+		return nil, err
+	}
+
+	s.logger.Info("team patched",
+		zap.String("team_name", patch.TeamName),
+		zap.Int("added", len(patch.AddMembers)),
+		zap.Int("removed", len(patch.RemoveMembers)))
+
+	return s.teamRepo.Get(ctx, patch.TeamName)
+}
+
+// ids возвращает нормализатор идентификаторов, общий с UserService
+func (s *TeamService) ids() IDNormalizer {
+	return s.users.cfg.IDs
+}
+
+// normalizeTeamIDs нормализует на месте имя команды и user_id её участников
+func (s *TeamService) normalizeTeamIDs(teamName *string, members []domain.TeamMember) error {
+	if err := s.ids().normalizeFields(idField{"team_name", teamName}); err != nil {
+		return err
+	}
+	for i := range members {
+		if err := s.ids().normalizeFields(idField{"user_id", &members[i].UserID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkBackupTeam нормализует имя резервной команды и проверяет, что она существует
+// и не совпадает с самой командой
+func (s *TeamService) checkBackupTeam(ctx context.Context, teamName string, backupTeamName *string) error {
+	if err := s.ids().normalizeFields(idField{"backup_team_name", backupTeamName}); err != nil {
+		return err
+	}
+	if *backupTeamName == teamName {
+		return fmt.Errorf("team cannot be its own backup: %w", domain.ErrInvalidInput)
+	}
+
+	exists, err := s.teamRepo.Exists(ctx, *backupTeamName)
+	if err != nil {
+		return fmt.Errorf("failed to check backup team existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("backup team %s not found: %w", *backupTeamName, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// upsertMember создаёт участника команды либо переводит в неё существующего пользователя.
+// Возвращает true, если пользователь создан
+func (s *TeamService) upsertMember(ctx context.Context, teamName string, member domain.TeamMember) (bool, error) {
+	user := &domain.User{
+		UserID:              member.UserID,
+		Username:            member.Username,
+		TeamName:            teamName,
+		IsActive:            member.IsActive,
+		ReviewGroup:         member.ReviewGroup,
+		Seniority:           member.Seniority,
+		MaxDailyAssignments: member.MaxDailyAssignments,
+		AvailableFrom:       member.AvailableFrom,
+		AvailableTo:         member.AvailableTo,
+	}
+
+	_, err := s.userRepo.Get(ctx, member.UserID)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return false, fmt.Errorf("failed to create user %s: %w", member.UserID, err)
+		}
+		return true, nil
+	case err != nil:
+		return false, err
+	default:
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return false, fmt.Errorf("failed to update user %s: %w", member.UserID, err)
+		}
+		return false, nil
+	}
+}
+
+// validateTeamPatch проверяет корректность частичного изменения команды
+func validateTeamPatch(patch *domain.TeamPatch) error {
+	if patch.SetReviewerCount != nil && *patch.SetReviewerCount < 1 {
+		return fmt.Errorf("reviewer count must be positive: %w", domain.ErrInvalidInput)
+	}
+	if patch.SetCalendar != nil {
+		if err := normalizeCalendar(patch.SetCalendar); err != nil {
+			return err
+		}
+	}
+
+	added := make(map[string]bool, len(patch.AddMembers))
+	for _, member := range patch.AddMembers {
+		if member.UserID == "" || member.Username == "" {
+			return fmt.Errorf("member user_id and username are required: %w", domain.ErrInvalidInput)
+		}
+		added[member.UserID] = true
+	}
+
+	for _, userID := range patch.RemoveMembers {
+		if added[userID] {
+			return fmt.Errorf("user %s is both added and removed: %w", userID, domain.ErrInvalidInput)
+		}
+	}
+
+	return nil
+}
+
+// normalizeCalendar проверяет календарь команды и приводит его к каноническому виду:
+// дни недели 1-7 и даты YYYY-MM-DD, отсортированные и без повторов
+func normalizeCalendar(calendar *domain.TeamCalendar) error {
+	for _, day := range calendar.WorkingDays {
+		if day < 1 || day > 7 {
+			return fmt.Errorf("working day %d is out of range 1-7: %w", day, domain.ErrInvalidInput)
+		}
+	}
+	slices.Sort(calendar.WorkingDays)
+	calendar.WorkingDays = slices.Compact(calendar.WorkingDays)
+
+	for i, holiday := range calendar.Holidays {
+		date, err := time.Parse(time.DateOnly, holiday)
+		if err != nil {
+			return fmt.Errorf("holiday %q is not a YYYY-MM-DD date: %w", holiday, domain.ErrInvalidInput)
+		}
+		calendar.Holidays[i] = date.Format(time.DateOnly)
+	}
+	slices.Sort(calendar.Holidays)
+	calendar.Holidays = slices.Compact(calendar.Holidays)
+
+	return nil
+}
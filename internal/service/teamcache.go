@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"reviewservice/internal/domain"
+)
+
+// teamCache запоминает состав команд в пределах одной операции, чтобы каждая команда
+// запрашивалась из БД не более одного раза. Создаётся на операцию; безопасен для
+// конкурентного использования (при одновременном промахе команда может быть запрошена дважды).
+// Активность участников может устареть, поэтому перед назначением её нужно
+// перепроверять (см. pickActiveCandidate)
+type teamCache struct {
+	userRepo domain.UserRepository
+
+	mu      sync.Mutex
+	members map[string][]domain.User
+}
+
+// newTeamCache создаёт пустой кэш составов команд
+func newTeamCache(userRepo domain.UserRepository) *teamCache {
+	return &teamCache{
+		userRepo: userRepo,
+		members:  make(map[string][]domain.User),
+	}
+}
+
+// get возвращает участников команды, запрашивая их из репозитория только при первом обращении.
+// Ошибки не кэшируются
+func (c *teamCache) get(ctx context.Context, teamName string) ([]domain.User, error) {
+	c.mu.Lock()
+	members, ok := c.members[teamName]
+	c.mu.Unlock()
+	if ok {
+		return members, nil
+	}
+
+	members, err := c.userRepo.GetByTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	c.put(teamName, members)
+	return members, nil
+}
+
+// put сохраняет уже известный состав команды
+func (c *teamCache) put(teamName string, members []domain.User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.members[teamName] = members
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"go.uber.org/zap"
+)
+
+// TeamHealth - сводное состояние очереди ревью команды для дашбордов тимлида
+type TeamHealth struct {
+	TeamName            string  `json:"team_name"`
+	OpenPRs             int     `json:"open_prs"`
+	UnderstaffedPRs     int     `json:"understaffed_prs"`
+	ReviewersPerPR      int     `json:"reviewers_per_pr"`
+	SLABreaches         int     `json:"sla_breaches"`
+	MergedPRs           int     `json:"merged_prs"`
+	AvgTimeToMergeHours float64 `json:"avg_time_to_merge_hours"`
+	ActiveReviewers     int     `json:"active_reviewers"`
+}
+
+// TeamHealth собирает в один ответ показатели PR авторов команды: открытые PR, открытые PR
+// с числом ревьюверов меньше числа команды, нарушения SLA ревью, среднее время до мерджа
+// и число активных участников, которых можно назначить ревьюверами
+func (s *PullRequestService) TeamHealth(ctx context.Context, teamName string) (*TeamHealth, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"team_name", &teamName}); err != nil {
+		return nil, err
+	}
+
+	members, err := teamMembers(ctx, s.userRepo, s.teamRepo, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewerCount, err := s.reviewerCount(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.prRepo.GetTeamPRSummary(ctx, teamName, reviewerCount)
+	if err != nil {
+		s.logger.Error("failed to get team PR summary", zap.Error(err), zap.String("team_name", teamName))
+		return nil, fmt.Errorf("failed to get team PR summary: %w", err)
+	}
+
+	breaches, err := s.SLABreaches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SLA breaches: %w", err)
+	}
+
+	authors := make(map[string]bool, len(members))
+	health := &TeamHealth{
+		TeamName:            teamName,
+		OpenPRs:             summary.OpenPRs,
+		UnderstaffedPRs:     summary.UnderstaffedPRs,
+		MergedPRs:           summary.MergedPRs,
+		AvgTimeToMergeHours: math.Round(summary.AvgTimeToMerge.Hours()*100) / 100,
+		ReviewersPerPR:      reviewerCount,
+	}
+	for _, member := range members {
+		authors[member.UserID] = true
+		if member.IsActive && !s.cfg.excludesUsername(member.Username) {
+			health.ActiveReviewers++
+		}
+	}
+	for _, breach := range breaches {
+		if authors[breach.AuthorID] {
+			health.SLABreaches++
+		}
+	}
+
+	return health, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+)
+
+// maxImportMembers - сколько участников (суммарно по всем командам) принимает один импорт
+const maxImportMembers = 5000
+
+// ImportedTeam - результат импорта одной команды
+type ImportedTeam struct {
+	TeamName     string `json:"team_name"`
+	Created      bool   `json:"created"`
+	CreatedUsers int    `json:"created_users"`
+	UpdatedUsers int    `json:"updated_users"`
+}
+
+// ImportTeams создаёт недостающие команды и создаёт/обновляет их участников в одной транзакции:
+// ошибка в любой команде откатывает весь импорт. Операция идемпотентна - существующие команды
+// не считаются ошибкой, а участники, не упомянутые в импорте, не затрагиваются. Пользователь,
+// указанный в нескольких командах, остаётся в последней из них
+func (s *TeamService) ImportTeams(ctx context.Context, teams []domain.Team) ([]ImportedTeam, error) {
+	if err := s.validateImport(teams); err != nil {
+		return nil, err
+	}
+
+	results := make([]ImportedTeam, len(teams))
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context, _ *sql.Tx) error {
+		for i, team := range teams {
+			results[i].TeamName = team.TeamName
+
+			exists, err := s.teamRepo.Exists(ctx, team.TeamName)
+			if err != nil {
+				return fmt.Errorf("failed to check team existence: %w", err)
+			}
+			if !exists {
+				if err := s.teamRepo.Create(ctx, &domain.Team{TeamName: team.TeamName}); err != nil {
+					return err
+				}
+				results[i].Created = true
+			}
+
+			for _, member := range team.Members {
+				created, err := s.upsertMember(ctx, team.TeamName, member)
+				if err != nil {
+					return err
+				}
+				if created {
+					results[i].CreatedUsers++
+				} else {
+					results[i].UpdatedUsers++
+				}
+			}
+
+			if team.ReviewerCount > 0 {
+				if err := s.teamRepo.SetReviewerCount(ctx, team.TeamName, team.ReviewerCount); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Резервные команды задаются после создания всех команд импорта: резервной
+		// может быть команда, объявленная в нём позже
+		for _, team := range teams {
+			if team.BackupTeamName == "" {
+				continue
+			}
+			if err := s.teamRepo.SetBackupTeam(ctx, team.TeamName, team.BackupTeamName); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to import teams", zap.Error(err), zap.Int("teams", len(teams)))
+		return nil, err
+	}
+
+	s.logger.Info("teams imported", zap.Int("teams", len(teams)))
+
+	return results, nil
+}
+
+// validateImport нормализует идентификаторы импорта на месте и проверяет его до начала транзакции
+func (s *TeamService) validateImport(teams []domain.Team) error {
+	if len(teams) == 0 {
+		return fmt.Errorf("no teams to import: %w", domain.ErrInvalidInput)
+	}
+
+	total := 0
+	for _, team := range teams {
+		total += len(team.Members)
+	}
+	if total > maxImportMembers {
+		return fmt.Errorf("import exceeds %d members: %w", maxImportMembers, domain.ErrInvalidInput)
+	}
+
+	seen := make(map[string]bool, len(teams))
+	for i := range teams {
+		team := &teams[i]
+		if err := s.normalizeTeamIDs(&team.TeamName, team.Members); err != nil {
+			return err
+		}
+		if seen[team.TeamName] {
+			return fmt.Errorf("team %s is listed twice: %w", team.TeamName, domain.ErrInvalidInput)
+		}
+		seen[team.TeamName] = true
+
+		for _, member := range team.Members {
+			if member.Username == "" {
+				return fmt.Errorf("member user_id and username are required: %w", domain.ErrInvalidInput)
+			}
+		}
+		if team.ReviewerCount < 0 {
+			return fmt.Errorf("reviewer count must be positive: %w", domain.ErrInvalidInput)
+		}
+		if team.BackupTeamName != "" {
+			if err := s.ids().normalizeFields(idField{"backup_team_name", &team.BackupTeamName}); err != nil {
+				return err
+			}
+			if team.BackupTeamName == team.TeamName {
+				return fmt.Errorf("team cannot be its own backup: %w", domain.ErrInvalidInput)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"reviewservice/internal/domain"
+)
+
+// UndoResult - результат отмены последнего назначения: PR после отмены и отменённые события
+type UndoResult struct {
+	PR     *domain.PullRequest
+	Undone []domain.ReviewerHistoryEntry
+}
+
+// UndoAssignment отменяет последнее назначение ревьюверов PR по истории ревьюверов, если оно
+// сделано не раньше UndoWindow назад. Последнее изменение - события одной записи истории
+// (с общим временем): назначения (например, все автоназначения при создании PR) снимаются,
+// замена возвращает прежнего ревьювера. Если последнее изменение - не назначение и не замена
+// (удаление, сброс, уже выполненная отмена), отменять нечего: ErrNotFound.
+// Просроченное назначение - ErrUndoExpired
+func (s *PullRequestService) UndoAssignment(ctx context.Context, prID string) (*UndoResult, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"pull_request_id", &prID}); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	if err := guardNotMerged(pr); err != nil {
+		return nil, err
+	}
+
+	history, err := s.prRepo.GetReviewerHistory(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get reviewer history", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	last := lastHistoryBatch(history)
+	if !isUndoable(last) {
+		return nil, fmt.Errorf("no assignment to undo for PR %s: %w", prID, domain.ErrNotFound)
+	}
+
+	if s.cfg.UndoWindow <= 0 || s.now().Sub(last[0].CreatedAt) > s.cfg.UndoWindow {
+		return nil, domain.ErrUndoExpired
+	}
+
+	// Снятие ревьюверов и запись истории выполняются в одной транзакции: при частичной отмене
+	// история продолжала бы показывать назначение, и повторная отмена падала бы на уже
+	// снятом ревьювере
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		entries := make([]domain.ReviewerHistoryEntry, 0, len(last))
+		for _, event := range last {
+			if event.Action == domain.ReviewerActionReassign {
+				if err := s.prRepo.ReassignReviewer(ctx, prID, event.ReplacedBy, event.UserID); err != nil {
+					return fmt.Errorf("failed to undo reassignment: %w", err)
+				}
+				entries = append(entries, undoEntry(prID, event.ReplacedBy, event.UserID))
+				continue
+			}
+
+			if err := s.prRepo.RemoveReviewer(ctx, prID, event.UserID); err != nil {
+				return fmt.Errorf("failed to undo assignment: %w", err)
+			}
+			entries = append(entries, undoEntry(prID, event.UserID, ""))
+		}
+
+		if err := s.prRepo.RecordReviewerHistory(ctx, entries); err != nil {
+			return fmt.Errorf("failed to record reviewer history: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("failed to undo assignment", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	s.logger.Info("assignment undone",
+		zap.String("pr_id", prID),
+		zap.Int("events", len(last)))
+
+	pr, err = s.prRepo.Get(ctx, prID)
+	if err != nil {
+		s.logger.Error("failed to get PR", zap.Error(err), zap.String("pr_id", prID))
+		return nil, err
+	}
+
+	return &UndoResult{PR: pr, Undone: last}, nil
+}
+
+// lastHistoryBatch возвращает события последнего изменения ревьюверов: хвост истории
+// с тем же временем, что и последнее событие
+func lastHistoryBatch(history []domain.ReviewerHistoryEntry) []domain.ReviewerHistoryEntry {
+	if len(history) == 0 {
+		return nil
+	}
+
+	last := history[len(history)-1].CreatedAt
+	start := len(history) - 1
+	for start > 0 && history[start-1].CreatedAt.Equal(last) {
+		start--
+	}
+	return history[start:]
+}
+
+// isUndoable проверяет, что изменение - назначения ревьюверов или одна замена
+func isUndoable(batch []domain.ReviewerHistoryEntry) bool {
+	if len(batch) == 0 {
+		return false
+	}
+	if len(batch) == 1 && batch[0].Action == domain.ReviewerActionReassign {
+		return true
+	}
+	for _, event := range batch {
+		if event.Action != domain.ReviewerActionAssign {
+			return false
+		}
+	}
+	return true
+}
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"math/rand/v2"
 
 	"reviewservice/internal/domain"
 
@@ -13,6 +12,7 @@ import (
 type UserService struct {
 	userRepo domain.UserRepository
 	prRepo   domain.PullRequestRepository
+	cfg      AssignmentConfig
 	logger   *zap.Logger
 }
 
@@ -20,11 +20,13 @@ type UserService struct {
 func NewUserService(
 	userRepo domain.UserRepository,
 	prRepo domain.PullRequestRepository,
+	cfg AssignmentConfig,
 	logger *zap.Logger,
 ) *UserService {
 	return &UserService{
 		userRepo: userRepo,
 		prRepo:   prRepo,
+		cfg:      cfg,
 		logger:   logger,
 	}
 }
@@ -33,6 +35,10 @@ func NewUserService(
 // При деактивации (isActive=false) переназначает все открытые PR пользователя
 // на активных членов его команды
 func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"user_id", &userID}); err != nil {
+		return nil, err
+	}
+
 	// Проверяем существование пользователя
 	user, err := s.userRepo.Get(ctx, userID)
 	if err != nil {
@@ -78,10 +84,12 @@ func (s *UserService) reassignUserPRs(ctx context.Context, userID string, teamNa
 		zap.String("user_id", userID),
 		zap.Int("count", len(openPRs)))
 
-	// Получаем членов команды для поиска замены
-	teamMembers, err := s.userRepo.GetByTeam(ctx, teamName)
-	if err != nil {
-		return err
+	// Составы команд кэшируются на всю операцию
+	finder := &fallbackFinder{
+		order:    s.cfg.fallbackOrder(),
+		teams:    newTeamCache(s.userRepo),
+		userRepo: s.userRepo,
+		logger:   s.logger,
 	}
 
 	// Для каждого PR пытаемся переназначить ревьювера
@@ -100,52 +108,16 @@ func (s *UserService) reassignUserPRs(ctx context.Context, userID string, teamNa
 			continue
 		}
 
-		// Шаг 1: Ищем кандидатов в команде деактивируемого пользователя
-		candidates := s.filterReassignCandidates(teamMembers, pr.AuthorID, currentReviewers, userID)
-
-		// Шаг 2: Если не нашли, ищем в команде автора PR (если это другая команда)
-		if len(candidates) == 0 {
-			author, err := s.userRepo.Get(ctx, pr.AuthorID)
-			if err != nil {
-				s.logger.Error("failed to get author", zap.Error(err), zap.String("author_id", pr.AuthorID))
-			} else if author.TeamName != teamName {
-				s.logger.Info("no candidates in reviewer team, searching in author team",
-					zap.String("pr_id", prID),
-					zap.String("reviewer_team", teamName),
-					zap.String("author_team", author.TeamName))
-
-				authorTeamMembers, err := s.userRepo.GetByTeam(ctx, author.TeamName)
-				if err != nil {
-					s.logger.Error("failed to get author team members", zap.Error(err))
-				} else {
-					candidates = s.filterReassignCandidates(authorTeamMembers, pr.AuthorID, currentReviewers, userID)
-				}
-			}
+		conflicts, err := s.userRepo.GetReviewConflicts(ctx, pr.AuthorID)
+		if err != nil {
+			s.logger.Error("failed to get review conflicts", zap.Error(err), zap.String("pr_id", prID))
+			continue
 		}
 
-		// Шаг 3: Если всё ещё не нашли, ищем в других командах
-		if len(candidates) == 0 {
-			// Получаем автора для определения его команды (чтобы исключить её)
-			author, err := s.userRepo.Get(ctx, pr.AuthorID)
-			excludeTeams := teamName // команда деактивируемого
-			if err == nil && author.TeamName != teamName {
-				// Исключаем и команду автора, т.к. там уже искали
-				s.logger.Info("no candidates in author team, searching in other teams",
-					zap.String("pr_id", prID),
-					zap.String("exclude_teams", teamName+","+author.TeamName))
-			} else {
-				s.logger.Info("no candidates in team, searching in other teams",
-					zap.String("pr_id", prID),
-					zap.String("exclude_team", excludeTeams))
-			}
-
-			otherUsers, err := s.userRepo.GetActiveUsersExcludingTeam(ctx, excludeTeams)
-			if err != nil {
-				s.logger.Error("failed to get users from other teams", zap.Error(err))
-			} else {
-				candidates = s.filterReassignCandidates(otherUsers, pr.AuthorID, currentReviewers, userID)
-			}
-		}
+		// Ищем кандидатов по источникам в порядке REASSIGN_FALLBACK_ORDER
+		candidates, source := finder.find(ctx, pr, teamName, func(users []domain.User) []string {
+			return s.cfg.filterReassignCandidates(users, pr.AuthorID, currentReviewers, conflicts, userID)
+		})
 
 		if len(candidates) == 0 {
 			s.logger.Warn("no candidates for reassignment, removing reviewer",
@@ -155,12 +127,24 @@ func (s *UserService) reassignUserPRs(ctx context.Context, userID string, teamNa
 			// Просто удаляем ревьювера без замены
 			if err := s.prRepo.RemoveReviewer(ctx, prID, userID); err != nil {
 				s.logger.Error("failed to remove reviewer", zap.Error(err), zap.String("pr_id", prID))
+				continue
 			}
+			recordReviewerHistory(ctx, s.prRepo, s.logger, removeEntry(prID, userID))
 			continue
 		}
 
-		// Выбираем случайного кандидата
-		newReviewer := selectRandomReassignCandidate(candidates)
+		// Выбираем случайного кандидата, перепроверяя его активность
+		newReviewer, err := pickActiveCandidate(ctx, s.userRepo, candidates, s.logger)
+		if err != nil {
+			s.logger.Error("failed to pick replacement", zap.Error(err), zap.String("pr_id", prID))
+			continue
+		}
+		if newReviewer == "" {
+			s.logger.Warn("all candidates became inactive, skipping reassignment",
+				zap.String("pr_id", prID),
+				zap.String("user_id", userID))
+			continue
+		}
 
 		// Переназначаем
 		if err := s.prRepo.ReassignReviewer(ctx, prID, userID, newReviewer); err != nil {
@@ -171,45 +155,65 @@ func (s *UserService) reassignUserPRs(ctx context.Context, userID string, teamNa
 				zap.String("new", newReviewer))
 			continue
 		}
+		recordReviewerHistory(ctx, s.prRepo, s.logger, reassignEntry(prID, userID, newReviewer))
 
 		s.logger.Info("reviewer reassigned",
 			zap.String("pr_id", prID),
 			zap.String("old_reviewer", userID),
-			zap.String("new_reviewer", newReviewer))
+			zap.String("new_reviewer", newReviewer),
+			zap.String("source", string(source)))
 	}
 
 	return nil
 }
 
-// filterReassignCandidates фильтрует кандидатов для замены ревьювера
-func (s *UserService) filterReassignCandidates(teamMembers []domain.User, authorID string, currentReviewers []string, excludeUserID string) []string {
-	excluded := make(map[string]bool)
-	excluded[authorID] = true
-	excluded[excludeUserID] = true
-	for _, reviewerID := range currentReviewers {
-		excluded[reviewerID] = true
+// UserWorkload представляет текущую нагрузку ревьювера
+type UserWorkload struct {
+	UserID       string   `json:"user_id"`
+	OpenReviews  int      `json:"open_reviews"`
+	TotalReviews int      `json:"total_reviews"`
+	OpenPRIDs    []string `json:"open_pr_ids"`
+}
+
+// GetWorkload возвращает агрегированную нагрузку ревьювера по всем командам
+func (s *UserService) GetWorkload(ctx context.Context, userID string) (*UserWorkload, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"user_id", &userID}); err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.Get(ctx, userID); err != nil {
+		s.logger.Error("failed to get user", zap.Error(err), zap.String("user_id", userID))
+		return nil, err
 	}
 
-	var candidates []string
-	for _, member := range teamMembers {
-		if member.IsActive && !excluded[member.UserID] {
-			candidates = append(candidates, member.UserID)
-		}
+	stats, err := s.prRepo.GetUserAssignmentStatsByUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get user assignment stats", zap.Error(err), zap.String("user_id", userID))
+		return nil, err
 	}
 
-	return candidates
-}
+	openPRs, err := s.prRepo.GetOpenByReviewer(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get open PRs", zap.Error(err), zap.String("user_id", userID))
+		return nil, err
+	}
 
-// selectRandomReassignCandidate выбирает случайного кандидата
-func selectRandomReassignCandidate(candidates []string) string {
-	if len(candidates) == 0 {
-		return ""
+	if openPRs == nil {
+		openPRs = []string{}
 	}
-	idx := rand.IntN(len(candidates))
-	return candidates[idx]
+
+	return &UserWorkload{
+		UserID:       userID,
+		OpenReviews:  stats.OpenPRs,
+		TotalReviews: stats.TotalAssignments,
+		OpenPRIDs:    openPRs,
+	}, nil
 }
 
 // GetUser получает пользователя по ID
 func (s *UserService) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	if err := s.cfg.IDs.normalizeFields(idField{"user_id", &userID}); err != nil {
+		return nil, err
+	}
+
 	return s.userRepo.Get(ctx, userID)
 }
@@ -38,7 +38,7 @@ func TestUserService_SetIsActive_DeactivateFallsBackToOtherTeams(t *testing.T) {
 		},
 	}
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, AssignmentConfig{}, logger)
 
 	// Деактивируем u1 (единственного активного в backend)
 	user, err := svc.SetIsActive(context.Background(), "u1", false)
@@ -86,7 +86,7 @@ func TestUserService_SetIsActive_PrioritizesAuthorTeam(t *testing.T) {
 		},
 	}
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, AssignmentConfig{}, logger)
 
 	// Деактивируем b1
 	user, err := svc.SetIsActive(context.Background(), "b1", false)
@@ -137,7 +137,7 @@ func TestStatsService_BulkDeactivateTeam_FallsBackToOtherTeams(t *testing.T) {
 		},
 	}
 
-	svc := NewStatsService(prRepo, userRepo, logger)
+	svc := NewStatsService(prRepo, userRepo, testutil.NewMockTeamRepository(), AssignmentConfig{}, logger)
 
 	// Деактивируем всю команду backend
 	result, err := svc.BulkDeactivateTeam(context.Background(), "backend")
@@ -189,7 +189,7 @@ func TestStatsService_BulkDeactivateTeam_NoOtherTeams(t *testing.T) {
 		},
 	}
 
-	svc := NewStatsService(prRepo, userRepo, logger)
+	svc := NewStatsService(prRepo, userRepo, testutil.NewMockTeamRepository(), AssignmentConfig{}, logger)
 
 	// Деактивируем всю команду backend (других команд нет, автор тоже в backend)
 	result, err := svc.BulkDeactivateTeam(context.Background(), "backend")
@@ -201,3 +201,151 @@ func TestStatsService_BulkDeactivateTeam_NoOtherTeams(t *testing.T) {
 	pr1 := prRepo.PRs["pr1"]
 	testutil.AssertEqual(t, len(pr1.AssignedReviewers), 0, "pr1 should have no reviewers")
 }
+
+// TestUserService_SetIsActive_CustomFallbackOrder проверяет, что порядок источников
+// замены из FallbackOrder меняет команду, из которой берётся новый ревьювер
+func TestUserService_SetIsActive_CustomFallbackOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		order []FallbackSource
+		want  string
+	}{
+		{name: "default order uses reviewer team", order: nil, want: "b2"},
+		{name: "author team first", order: []FallbackSource{FallbackAuthorTeam, FallbackReviewerTeam, FallbackAny}, want: "f1"},
+		{name: "any first", order: []FallbackSource{FallbackAny, FallbackReviewerTeam}, want: "d1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+
+			userRepo := &testutil.MockUserRepository{
+				Users: map[string]*domain.User{
+					"b1":     {UserID: "b1", Username: "Backend1", TeamName: "backend", IsActive: true},
+					"b2":     {UserID: "b2", Username: "Backend2", TeamName: "backend", IsActive: true},
+					"f1":     {UserID: "f1", Username: "Frontend1", TeamName: "frontend", IsActive: true},
+					"author": {UserID: "author", Username: "Author", TeamName: "frontend", IsActive: true},
+					"d1":     {UserID: "d1", Username: "Design1", TeamName: "design", IsActive: true},
+				},
+			}
+
+			prRepo := &testutil.MockPRRepository{
+				PRs: map[string]*domain.PullRequest{
+					"pr1": {
+						PullRequestID:     "pr1",
+						PullRequestName:   "Test PR",
+						AuthorID:          "author",
+						Status:            domain.PRStatusOpen,
+						AssignedReviewers: []string{"b1"},
+					},
+				},
+			}
+
+			// "any" включает и команду автора: чтобы результат был детерминированным,
+			// оставляем вне backend единственного кандидата d1
+			if tt.order != nil && tt.order[0] == FallbackAny {
+				userRepo.Users["f1"].IsActive = false
+			}
+
+			svc := NewUserService(userRepo, prRepo, AssignmentConfig{FallbackOrder: tt.order}, logger)
+
+			_, err := svc.SetIsActive(context.Background(), "b1", false)
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, prRepo.PRs["pr1"].AssignedReviewers, []string{tt.want}, "replacement source")
+		})
+	}
+}
+
+// TestStatsService_BulkDeactivateTeam_CustomFallbackOrder проверяет, что при массовой
+// деактивации "any" перед командой автора берёт замену из других команд
+func TestStatsService_BulkDeactivateTeam_CustomFallbackOrder(t *testing.T) {
+	logger := zap.NewNop()
+
+	userRepo := &testutil.MockUserRepository{
+		Users: map[string]*domain.User{
+			"b1":     {UserID: "b1", Username: "Backend1", TeamName: "backend", IsActive: true},
+			"f1":     {UserID: "f1", Username: "Frontend1", TeamName: "frontend", IsActive: false},
+			"author": {UserID: "author", Username: "Author", TeamName: "frontend", IsActive: true},
+			"d1":     {UserID: "d1", Username: "Design1", TeamName: "design", IsActive: true},
+		},
+	}
+
+	prRepo := &testutil.MockPRRepository{
+		PRs: map[string]*domain.PullRequest{
+			"pr1": {
+				PullRequestID:     "pr1",
+				PullRequestName:   "Test PR",
+				AuthorID:          "author",
+				Status:            domain.PRStatusOpen,
+				AssignedReviewers: []string{"b1"},
+			},
+		},
+	}
+
+	cfg := AssignmentConfig{FallbackOrder: []FallbackSource{FallbackAny, FallbackAuthorTeam}}
+	svc := NewStatsService(prRepo, userRepo, testutil.NewMockTeamRepository(), cfg, logger)
+
+	_, err := svc.BulkDeactivateTeam(context.Background(), "backend")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, prRepo.PRs["pr1"].AssignedReviewers, []string{"d1"}, "replacement from other teams")
+}
+
+// TestParseFallbackOrder проверяет разбор REASSIGN_FALLBACK_ORDER
+func TestParseFallbackOrder(t *testing.T) {
+	order, err := ParseFallbackOrder(nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, order, DefaultFallbackOrder, "empty order falls back to default")
+
+	order, err = ParseFallbackOrder([]string{"author_team", " any "})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, order, []FallbackSource{FallbackAuthorTeam, FallbackAny}, "parsed order")
+
+	_, err = ParseFallbackOrder([]string{"reviewer_team", "nobody"})
+	testutil.AssertTrue(t, err != nil, "unknown source must be rejected")
+
+	_, err = ParseFallbackOrder([]string{"any", "any"})
+	testutil.AssertTrue(t, err != nil, "duplicate source must be rejected")
+}
+
+// TestUserService_SetIsActive_AuthorTeamOnly проверяет, что при REASSIGN_SAME_TEAM_AS_AUTHOR_ONLY
+// замена ищется только в команде автора, а при её исчерпании ревьювер удаляется без замены
+func TestUserService_SetIsActive_AuthorTeamOnly(t *testing.T) {
+	newRepos := func() (*testutil.MockUserRepository, *testutil.MockPRRepository) {
+		userRepo := &testutil.MockUserRepository{
+			Users: map[string]*domain.User{
+				"b1":     {UserID: "b1", TeamName: "backend", IsActive: true},
+				"b2":     {UserID: "b2", TeamName: "backend", IsActive: true}, // коллега ревьювера
+				"f1":     {UserID: "f1", TeamName: "frontend", IsActive: true},
+				"author": {UserID: "author", TeamName: "frontend", IsActive: true},
+			},
+		}
+		prRepo := &testutil.MockPRRepository{
+			PRs: map[string]*domain.PullRequest{
+				"pr1": {PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusOpen, AssignedReviewers: []string{"b1"}},
+			},
+		}
+		return userRepo, prRepo
+	}
+	cfg := AssignmentConfig{Features: FeatureFlags{ReassignAuthorTeamOnly: true}}
+
+	t.Run("замена из команды автора, а не ревьювера", func(t *testing.T) {
+		userRepo, prRepo := newRepos()
+		svc := NewUserService(userRepo, prRepo, cfg, zap.NewNop())
+
+		_, err := svc.SetIsActive(context.Background(), "b1", false)
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, prRepo.PRs["pr1"].AssignedReviewers, []string{"f1"}, "Замена из frontend")
+	})
+
+	t.Run("команда автора исчерпана - ревьювер удалён", func(t *testing.T) {
+		userRepo, prRepo := newRepos()
+		userRepo.Users["f1"].IsActive = false
+		svc := NewUserService(userRepo, prRepo, cfg, zap.NewNop())
+
+		_, err := svc.SetIsActive(context.Background(), "b1", false)
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertLen(t, prRepo.PRs["pr1"].AssignedReviewers, 0, "b2 из команды ревьювера не используется")
+	})
+}
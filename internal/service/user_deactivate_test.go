@@ -42,7 +42,7 @@ func TestUserService_SetIsActive_DeactivateReassignsReviewers(t *testing.T) {
 		},
 	}
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, AssignmentConfig{}, logger)
 
 	// Деактивируем пользователя u1
 	user, err := svc.SetIsActive(context.Background(), "u1", false)
@@ -105,7 +105,7 @@ func TestUserService_SetIsActive_DeactivateWithNoCandidates(t *testing.T) {
 		},
 	}
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, AssignmentConfig{}, logger)
 
 	// Деактивируем u1 (единственного активного в команде)
 	user, err := svc.SetIsActive(context.Background(), "u1", false)
@@ -133,7 +133,7 @@ func TestUserService_SetIsActive_ActivateDoesNotReassign(t *testing.T) {
 		PRs: make(map[string]*domain.PullRequest),
 	}
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, AssignmentConfig{}, logger)
 
 	// Активируем пользователя
 	user, err := svc.SetIsActive(context.Background(), "u1", true)
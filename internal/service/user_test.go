@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"go.uber.org/zap"
@@ -23,7 +24,7 @@ func TestUserService_GetUser(t *testing.T) {
 		PRs: make(map[string]*domain.PullRequest),
 	}
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, AssignmentConfig{}, logger)
 
 	tests := []struct {
 		name    string
@@ -93,3 +94,83 @@ func TestPRStatus_IsValid(t *testing.T) {
 		})
 	}
 }
+
+// TestUserService_GetWorkload tests reviewer workload aggregation
+func TestUserService_GetWorkload(t *testing.T) {
+	logger := zap.NewNop()
+
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["u1"] = &domain.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	userRepo.Users["u2"] = &domain.User{UserID: "u2", TeamName: "frontend", IsActive: true}
+	userRepo.Users["idle"] = &domain.User{UserID: "idle", TeamName: "backend", IsActive: true}
+
+	prRepo := testutil.NewMockPRRepository()
+	prRepo.PRs["pr-1"] = &domain.PullRequest{
+		PullRequestID: "pr-1", AuthorID: "u2", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u1"},
+	}
+	prRepo.PRs["pr-2"] = &domain.PullRequest{
+		PullRequestID: "pr-2", AuthorID: "u2", Status: domain.PRStatusMerged, AssignedReviewers: []string{"u1"},
+	}
+	prRepo.PRs["pr-3"] = &domain.PullRequest{
+		PullRequestID: "pr-3", AuthorID: "u2", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u1"},
+	}
+
+	svc := NewUserService(userRepo, prRepo, AssignmentConfig{}, logger)
+
+	t.Run("aggregates open and total reviews", func(t *testing.T) {
+		workload, err := svc.GetWorkload(context.Background(), "u1")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, workload.OpenReviews, 2, "Open reviews")
+		testutil.AssertEqual(t, workload.TotalReviews, 3, "Total reviews")
+		testutil.AssertLen(t, workload.OpenPRIDs, 2, "Open PR IDs")
+		testutil.AssertContains(t, workload.OpenPRIDs, "pr-1")
+		testutil.AssertContains(t, workload.OpenPRIDs, "pr-3")
+	})
+
+	t.Run("returns zeros for user without assignments", func(t *testing.T) {
+		workload, err := svc.GetWorkload(context.Background(), "idle")
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertEqual(t, workload.OpenReviews, 0, "Open reviews")
+		testutil.AssertEqual(t, workload.TotalReviews, 0, "Total reviews")
+		testutil.AssertNotNil(t, workload.OpenPRIDs, "Open PR IDs should be empty, not nil")
+		testutil.AssertLen(t, workload.OpenPRIDs, 0)
+	})
+
+	t.Run("returns error for unknown user", func(t *testing.T) {
+		_, err := svc.GetWorkload(context.Background(), "ghost")
+
+		testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestUserService_ReviewConflicts tests adding, listing and removing review conflicts
+func TestUserService_ReviewConflicts(t *testing.T) {
+	userRepo := testutil.NewMockUserRepository()
+	userRepo.Users["u1"] = &domain.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	userRepo.Users["u2"] = &domain.User{UserID: "u2", TeamName: "backend", IsActive: true}
+	svc := NewUserService(userRepo, testutil.NewMockPRRepository(), AssignmentConfig{}, zap.NewNop())
+	ctx := context.Background()
+
+	conflict, err := svc.AddReviewConflict(ctx, "u2", "u1")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, *conflict, domain.ReviewConflict{UserA: "u1", UserB: "u2"}, "Pair is stored in canonical order")
+
+	// Adding the same pair in either order is a no-op
+	_, err = svc.AddReviewConflict(ctx, "u1", "u2")
+	testutil.AssertNoError(t, err)
+	conflicts, err := svc.ListReviewConflicts(ctx)
+	testutil.AssertNoError(t, err)
+	testutil.AssertLen(t, conflicts, 1, "Single conflict stored")
+
+	_, err = svc.AddReviewConflict(ctx, "u1", "u1")
+	testutil.AssertTrue(t, errors.Is(err, domain.ErrInvalidInput), "Self-conflict is rejected")
+	_, err = svc.AddReviewConflict(ctx, "u1", "ghost")
+	testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = svc.RemoveReviewConflict(ctx, "u2", "u1")
+	testutil.AssertNoError(t, err)
+	_, err = svc.RemoveReviewConflict(ctx, "u1", "u2")
+	testutil.AssertErrorIs(t, err, domain.ErrNotFound)
+}
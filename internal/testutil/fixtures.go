@@ -0,0 +1,23 @@
+package testutil
+
+import "reviewservice/internal/domain"
+
+// AddTeamMembers adds active members of teamName to the mock
+func (m *MockUserRepository) AddTeamMembers(teamName string, userIDs ...string) {
+	for _, userID := range userIDs {
+		m.Users[userID] = &domain.User{UserID: userID, TeamName: teamName, IsActive: true}
+	}
+}
+
+// AddOpenPR adds an open PR of authorID with the given reviewers to the mock and returns it
+// so the caller can adjust the remaining fields
+func (m *MockPRRepository) AddOpenPR(prID, authorID string, reviewerIDs ...string) *domain.PullRequest {
+	pr := &domain.PullRequest{
+		PullRequestID:     prID,
+		AuthorID:          authorID,
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: reviewerIDs,
+	}
+	m.PRs[prID] = pr
+	return pr
+}
@@ -3,6 +3,11 @@ package testutil
 
 import (
 	"context"
+	"database/sql"
+	"slices"
+	"sort"
+	"sync"
+	"time"
 
 	"reviewservice/internal/domain"
 )
@@ -11,15 +16,43 @@ import (
 type MockPRRepository struct {
 	PRs map[string]*domain.PullRequest
 
+	// AuditEntries collects entries passed to RecordAssignmentAudit
+	AuditEntries []domain.AssignmentAuditEntry
+
+	// ReviewerHistory collects entries passed to RecordReviewerHistory. Guarded by
+	// historyMu because bulk deactivation records history from several workers
+	ReviewerHistory []domain.ReviewerHistoryEntry
+	historyMu       sync.Mutex
+
+	// SelectionDecisions collects decisions passed to RecordSelectionDecision. Guarded by
+	// decisionsMu because decisions are written in the background
+	SelectionDecisions []domain.SelectionDecision
+	decisionsMu        sync.Mutex
+
+	// AuthorTeams maps author user_id to team_name for team-scoped queries (GetTrends, GetOpenByAuthorTeam)
+	AuthorTeams map[string]string
+
+	// FreezeUntil holds the end of the auto-assignment freeze (nil when none is set)
+	FreezeUntil *time.Time
+
 	// Hooks for custom behavior
-	CreateFunc                 func(ctx context.Context, pr *domain.PullRequest) error
-	GetFunc                    func(ctx context.Context, prID string) (*domain.PullRequest, error)
-	MergeFunc                  func(ctx context.Context, prID string) (*domain.PullRequest, error)
-	ReassignReviewerFunc       func(ctx context.Context, prID, oldID, newID string) error
-	GetPRStatsFunc             func(ctx context.Context) (map[string]int, error)
-	GetUserAssignmentStatsFunc func(ctx context.Context) (map[string]*domain.UserAssignmentStats, error)
-	GetByReviewerFunc          func(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
-	ListFunc                   func(ctx context.Context, status string) ([]*domain.PullRequest, error)
+	CreateFunc                   func(ctx context.Context, pr *domain.PullRequest) error
+	AssignReviewersFunc          func(ctx context.Context, prID string, reviewerIDs []string) error
+	GetFunc                      func(ctx context.Context, prID string) (*domain.PullRequest, error)
+	MergeFunc                    func(ctx context.Context, prID string) (*domain.PullRequest, error)
+	DeleteFunc                   func(ctx context.Context, prID string) error
+	RemoveReviewerFunc           func(ctx context.Context, prID, reviewerID string) error
+	ReassignReviewerFunc         func(ctx context.Context, prID, oldID, newID string) error
+	GetPRStatsFunc               func(ctx context.Context) (map[string]int, error)
+	GetUserAssignmentStatsFunc   func(ctx context.Context) (map[string]*domain.UserAssignmentStats, error)
+	CountOpenByUsersFunc         func(ctx context.Context, userIDs []string) (map[string]int, error)
+	GetByReviewerFunc            func(ctx context.Context, userID string, page domain.Page) ([]domain.PullRequestShort, error)
+	ListFunc                     func(ctx context.Context, statuses []string, page domain.Page) ([]*domain.PullRequest, error)
+	GetAssignmentCountsSinceFunc func(ctx context.Context, since time.Time) (map[string]int, error)
+	GetOpenAssignedBeforeFunc    func(ctx context.Context, before time.Time) ([]domain.SLABreach, error)
+	GetOrphanedReviewersFunc     func(ctx context.Context) ([]domain.OrphanedReviewer, error)
+	GetTeamLoadStatsFunc         func(ctx context.Context) ([]domain.TeamLoadStats, error)
+	RecordSelectionDecisionFunc  func(ctx context.Context, decision *domain.SelectionDecision) error
 }
 
 // NewMockPRRepository creates a new mock PR repository
@@ -76,53 +109,168 @@ func (m *MockPRRepository) Merge(ctx context.Context, prID string) (*domain.Pull
 	return pr, nil
 }
 
-func (m *MockPRRepository) AssignReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
+func (m *MockPRRepository) Delete(ctx context.Context, prID string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, prID)
+	}
+	if _, ok := m.PRs[prID]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(m.PRs, prID)
+	return nil
+}
+
+// openPR returns the PR for a reviewer mutation, rejecting merged PRs like the postgres repository
+func (m *MockPRRepository) openPR(prID string) (*domain.PullRequest, error) {
 	pr, ok := m.PRs[prID]
 	if !ok {
-		return domain.ErrNotFound
+		return nil, domain.ErrNotFound
+	}
+	if pr.Status == domain.PRStatusMerged {
+		return nil, domain.ErrPRMerged
+	}
+	return pr, nil
+}
+
+func (m *MockPRRepository) AssignReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
+	if m.AssignReviewersFunc != nil {
+		return m.AssignReviewersFunc(ctx, prID, reviewerIDs)
+	}
+	pr, err := m.openPR(prID)
+	if err != nil {
+		return err
+	}
+	// Like the pr_reviewers unique constraint, an already assigned reviewer is skipped
+	for _, reviewerID := range reviewerIDs {
+		if !pr.HasReviewer(reviewerID) {
+			pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+		}
+	}
+	if pr.PrimaryReviewer == "" && len(pr.AssignedReviewers) > 0 {
+		pr.PrimaryReviewer = pr.AssignedReviewers[0]
+	}
+	return nil
+}
+
+func (m *MockPRRepository) ReplaceReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
+	pr, err := m.openPR(prID)
+	if err != nil {
+		return err
+	}
+	pr.AssignedReviewers = []string{}
+	for _, reviewerID := range reviewerIDs {
+		if !pr.HasReviewer(reviewerID) {
+			pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+		}
+	}
+	pr.PrimaryReviewer = ""
+	if len(pr.AssignedReviewers) > 0 {
+		pr.PrimaryReviewer = pr.AssignedReviewers[0]
 	}
-	pr.AssignedReviewers = reviewerIDs
 	return nil
 }
 
-func (m *MockPRRepository) GetByReviewer(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+func (m *MockPRRepository) GetByReviewer(ctx context.Context, userID string, page domain.Page) ([]domain.PullRequestShort, error) {
 	if m.GetByReviewerFunc != nil {
-		return m.GetByReviewerFunc(ctx, userID)
+		return m.GetByReviewerFunc(ctx, userID, page)
 	}
 	var result []domain.PullRequestShort
 	for _, pr := range m.PRs {
-		for _, reviewer := range pr.AssignedReviewers {
-			if reviewer == userID {
-				result = append(result, domain.PullRequestShort{
-					PullRequestID:   pr.PullRequestID,
-					PullRequestName: pr.PullRequestName,
-					AuthorID:        pr.AuthorID,
-					Status:          pr.Status,
-				})
-				break
-			}
+		if pr.HasReviewer(userID) {
+			result = append(result, domain.PullRequestShort{
+				PullRequestID:   pr.PullRequestID,
+				PullRequestName: pr.PullRequestName,
+				AuthorID:        pr.AuthorID,
+				Status:          pr.Status,
+				CreatedAt:       pr.CreatedAt,
+				MergedAt:        pr.MergedAt,
+			})
+		}
+	}
+	// Stable order so consecutive pages never overlap
+	sort.Slice(result, func(i, j int) bool { return result[i].PullRequestID < result[j].PullRequestID })
+	return paginate(result, page), nil
+}
+
+func (m *MockPRRepository) GetByPrimaryReviewer(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+	result := make([]domain.PullRequestShort, 0)
+	for _, pr := range m.PRs {
+		if pr.PrimaryReviewer == userID && pr.HasReviewer(userID) {
+			result = append(result, domain.PullRequestShort{
+				PullRequestID:   pr.PullRequestID,
+				PullRequestName: pr.PullRequestName,
+				AuthorID:        pr.AuthorID,
+				Status:          pr.Status,
+				CreatedAt:       pr.CreatedAt,
+				MergedAt:        pr.MergedAt,
+			})
 		}
 	}
+	// Open PRs first, then by ID in place of the creation time
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Status != result[j].Status {
+			return result[i].Status == domain.PRStatusOpen
+		}
+		return result[i].PullRequestID < result[j].PullRequestID
+	})
 	return result, nil
 }
 
+func (m *MockPRRepository) CountByReviewer(ctx context.Context, userID string) (int, error) {
+	total := 0
+	for _, pr := range m.PRs {
+		if pr.HasReviewer(userID) {
+			total++
+		}
+	}
+	return total, nil
+}
+
+func (m *MockPRRepository) CountOpenByAuthor(ctx context.Context, authorID string) (int, error) {
+	total := 0
+	for _, pr := range m.PRs {
+		if pr.AuthorID == authorID && pr.Status == domain.PRStatusOpen {
+			total++
+		}
+	}
+	return total, nil
+}
+
 func (m *MockPRRepository) GetOpenByReviewer(ctx context.Context, userID string) ([]string, error) {
 	var result []string
 	for _, pr := range m.PRs {
-		if pr.Status != domain.PRStatusOpen {
-			continue
+		if pr.Status == domain.PRStatusOpen && pr.HasReviewer(userID) {
+			result = append(result, pr.PullRequestID)
 		}
-		for _, reviewer := range pr.AssignedReviewers {
-			if reviewer == userID {
-				result = append(result, pr.PullRequestID)
-				break
-			}
+	}
+	return result, nil
+}
+
+// GetOpenByAuthorTeam returns open PRs whose author is mapped to teamName in AuthorTeams,
+// ordered by PR ID
+func (m *MockPRRepository) GetOpenByAuthorTeam(ctx context.Context, teamName string) ([]*domain.PullRequest, error) {
+	result := make([]*domain.PullRequest, 0)
+	for _, pr := range m.PRs {
+		if pr.Status == domain.PRStatusOpen && m.AuthorTeams[pr.AuthorID] == teamName {
+			result = append(result, pr)
 		}
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PullRequestID < result[j].PullRequestID })
 	return result, nil
 }
 
 func (m *MockPRRepository) RemoveReviewer(ctx context.Context, prID string, reviewerID string) error {
+	if m.RemoveReviewerFunc != nil {
+		return m.RemoveReviewerFunc(ctx, prID, reviewerID)
+	}
+	if _, err := m.openPR(prID); err != nil {
+		return err
+	}
+	return m.DeleteOrphanedReviewer(ctx, prID, reviewerID)
+}
+
+// DeleteOrphanedReviewer removes the reviewer regardless of the PR status
+func (m *MockPRRepository) DeleteOrphanedReviewer(ctx context.Context, prID, reviewerID string) error {
 	pr, ok := m.PRs[prID]
 	if !ok {
 		return domain.ErrNotFound
@@ -135,15 +283,98 @@ func (m *MockPRRepository) RemoveReviewer(ctx context.Context, prID string, revi
 		}
 	}
 	pr.AssignedReviewers = newReviewers
+
+	// The earliest remaining reviewer inherits the primary flag
+	if pr.PrimaryReviewer == reviewerID {
+		pr.PrimaryReviewer = ""
+		if len(newReviewers) > 0 {
+			pr.PrimaryReviewer = newReviewers[0]
+		}
+	}
+	return nil
+}
+
+func (m *MockPRRepository) SetPrimaryReviewer(ctx context.Context, prID, reviewerID string) error {
+	pr, err := m.openPR(prID)
+	if err != nil {
+		return err
+	}
+	if !pr.HasReviewer(reviewerID) {
+		return domain.ErrNotAssigned
+	}
+	pr.PrimaryReviewer = reviewerID
 	return nil
 }
 
+func (m *MockPRRepository) RecordAssignmentAudit(ctx context.Context, entry *domain.AssignmentAuditEntry) error {
+	entry.CreatedAt = time.Now()
+	m.AuditEntries = append(m.AuditEntries, *entry)
+	return nil
+}
+
+func (m *MockPRRepository) RecordReviewerHistory(ctx context.Context, entries []domain.ReviewerHistoryEntry) error {
+	m.historyMu.Lock()
+	defer m.historyMu.Unlock()
+	// Like NOW() in a transaction, all entries of one call share the timestamp
+	now := time.Now()
+	for _, entry := range entries {
+		entry.CreatedAt = now
+		m.ReviewerHistory = append(m.ReviewerHistory, entry)
+	}
+	return nil
+}
+
+// RecordSelectionDecision stores a copy of the decision
+func (m *MockPRRepository) RecordSelectionDecision(ctx context.Context, decision *domain.SelectionDecision) error {
+	if m.RecordSelectionDecisionFunc != nil {
+		return m.RecordSelectionDecisionFunc(ctx, decision)
+	}
+	m.decisionsMu.Lock()
+	defer m.decisionsMu.Unlock()
+	decision.CreatedAt = time.Now()
+	m.SelectionDecisions = append(m.SelectionDecisions, *decision)
+	return nil
+}
+
+// GetSelectionDecisions returns recorded decisions of the PR in recording order
+func (m *MockPRRepository) GetSelectionDecisions(ctx context.Context, prID string) ([]domain.SelectionDecision, error) {
+	m.decisionsMu.Lock()
+	defer m.decisionsMu.Unlock()
+	decisions := make([]domain.SelectionDecision, 0)
+	for _, decision := range m.SelectionDecisions {
+		if decision.PullRequestID == prID {
+			decisions = append(decisions, decision)
+		}
+	}
+	return decisions, nil
+}
+
+// GetReviewerHistory returns recorded history of the PR in recording order
+func (m *MockPRRepository) GetReviewerHistory(ctx context.Context, prID string) ([]domain.ReviewerHistoryEntry, error) {
+	m.historyMu.Lock()
+	defer m.historyMu.Unlock()
+	history := make([]domain.ReviewerHistoryEntry, 0)
+	for _, entry := range m.ReviewerHistory {
+		if entry.PullRequestID == prID {
+			history = append(history, entry)
+		}
+	}
+	return history, nil
+}
+
 func (m *MockPRRepository) AddReviewer(ctx context.Context, prID string, reviewerID string) error {
-	pr, ok := m.PRs[prID]
-	if !ok {
-		return domain.ErrNotFound
+	pr, err := m.openPR(prID)
+	if err != nil {
+		return err
+	}
+	// Like the pr_reviewers unique constraint, adding an assigned reviewer is rejected
+	if pr.HasReviewer(reviewerID) {
+		return domain.ErrAlreadyAssigned
 	}
 	pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+	if pr.PrimaryReviewer == "" {
+		pr.PrimaryReviewer = reviewerID
+	}
 	return nil
 }
 
@@ -160,9 +391,12 @@ func (m *MockPRRepository) ReassignReviewer(ctx context.Context, prID, oldReview
 		return m.ReassignReviewerFunc(ctx, prID, oldReviewerID, newReviewerID)
 	}
 
-	pr, ok := m.PRs[prID]
-	if !ok {
-		return domain.ErrNotFound
+	pr, err := m.openPR(prID)
+	if err != nil {
+		return err
+	}
+	if pr.HasReviewer(newReviewerID) {
+		return domain.ErrAlreadyAssigned
 	}
 
 	found := false
@@ -178,9 +412,56 @@ func (m *MockPRRepository) ReassignReviewer(ctx context.Context, prID, oldReview
 		return domain.ErrNotFound
 	}
 
+	if pr.PrimaryReviewer == oldReviewerID {
+		pr.PrimaryReviewer = newReviewerID
+	}
+
 	return nil
 }
 
+// GetTrends buckets mock PRs by CreatedAt/MergedAt the same way date_trunc does,
+// keeping only PRs whose author is mapped to teamName in AuthorTeams when it is set
+func (m *MockPRRepository) GetTrends(ctx context.Context, from, to time.Time, interval domain.TrendInterval, teamName string) ([]domain.TrendBucket, error) {
+	created := make(map[time.Time]int)
+	merged := make(map[time.Time]int)
+	for _, pr := range m.PRs {
+		if teamName != "" && m.AuthorTeams[pr.AuthorID] != teamName {
+			continue
+		}
+		if pr.CreatedAt != nil {
+			created[truncateTrend(*pr.CreatedAt, interval)]++
+		}
+		if pr.MergedAt != nil {
+			merged[truncateTrend(*pr.MergedAt, interval)]++
+		}
+	}
+
+	step := 24 * time.Hour
+	if interval == domain.TrendIntervalWeek {
+		step = 7 * 24 * time.Hour
+	}
+
+	buckets := make([]domain.TrendBucket, 0)
+	for b := truncateTrend(from, interval); !b.After(truncateTrend(to, interval)); b = b.Add(step) {
+		buckets = append(buckets, domain.TrendBucket{
+			Date:    b.Format(time.DateOnly),
+			Created: created[b],
+			Merged:  merged[b],
+		})
+	}
+	return buckets, nil
+}
+
+// truncateTrend truncates t to the start of its day or ISO week (Monday)
+func truncateTrend(t time.Time, interval domain.TrendInterval) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if interval == domain.TrendIntervalWeek {
+		offset := (int(day.Weekday()) + 6) % 7
+		day = day.AddDate(0, 0, -offset)
+	}
+	return day
+}
+
 func (m *MockPRRepository) GetPRStats(ctx context.Context) (map[string]int, error) {
 	if m.GetPRStatsFunc != nil {
 		return m.GetPRStatsFunc(ctx)
@@ -213,6 +494,122 @@ func (m *MockPRRepository) GetPRStats(ctx context.Context) (map[string]int, erro
 	}, nil
 }
 
+func (m *MockPRRepository) CountOpenByUsers(ctx context.Context, userIDs []string) (map[string]int, error) {
+	if m.CountOpenByUsersFunc != nil {
+		return m.CountOpenByUsersFunc(ctx, userIDs)
+	}
+
+	wanted := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		wanted[userID] = true
+	}
+
+	counts := make(map[string]int)
+	for _, pr := range m.PRs {
+		if pr.Status != domain.PRStatusOpen {
+			continue
+		}
+		for _, reviewerID := range pr.AssignedReviewers {
+			if wanted[reviewerID] {
+				counts[reviewerID]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// GetTeamPRSummary summarizes PRs whose author is mapped to teamName in AuthorTeams
+func (m *MockPRRepository) GetTeamPRSummary(ctx context.Context, teamName string, reviewerCount int) (*domain.TeamPRSummary, error) {
+	summary := &domain.TeamPRSummary{}
+	var mergeTime time.Duration
+	var timed int
+	for _, pr := range m.PRs {
+		if m.AuthorTeams[pr.AuthorID] != teamName {
+			continue
+		}
+		switch pr.Status {
+		case domain.PRStatusOpen:
+			summary.OpenPRs++
+			if len(pr.AssignedReviewers) < reviewerCount {
+				summary.UnderstaffedPRs++
+			}
+		case domain.PRStatusMerged:
+			summary.MergedPRs++
+			if pr.CreatedAt != nil && pr.MergedAt != nil {
+				mergeTime += pr.MergedAt.Sub(*pr.CreatedAt)
+				timed++
+			}
+		}
+	}
+	if timed > 0 {
+		summary.AvgTimeToMerge = mergeTime / time.Duration(timed)
+	}
+	return summary, nil
+}
+
+// GetReviewerPairCounts counts reviewer pairs on the author's most recent PRs; PRs without
+// CreatedAt are treated as the oldest, ties are broken by pull_request_id descending
+func (m *MockPRRepository) GetReviewerPairCounts(ctx context.Context, authorID string, recentPRs int) (map[domain.ReviewerPair]int, error) {
+	var prs []*domain.PullRequest
+	for _, pr := range m.PRs {
+		if pr.AuthorID == authorID {
+			prs = append(prs, pr)
+		}
+	}
+	sortNewestFirst(prs)
+	if len(prs) > recentPRs {
+		prs = prs[:recentPRs]
+	}
+
+	counts := make(map[domain.ReviewerPair]int)
+	for _, pr := range prs {
+		for i, first := range pr.AssignedReviewers {
+			for _, second := range pr.AssignedReviewers[i+1:] {
+				counts[domain.NewReviewerPair(first, second)]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// GetReciprocalReviewers returns the candidates whose most recent PR (ordered as in
+// GetReviewerPairCounts) has reviewerID among its reviewers
+func (m *MockPRRepository) GetReciprocalReviewers(ctx context.Context, reviewerID string, candidateIDs []string) ([]string, error) {
+	reciprocal := []string{}
+	for _, candidateID := range candidateIDs {
+		var prs []*domain.PullRequest
+		for _, pr := range m.PRs {
+			if pr.AuthorID == candidateID {
+				prs = append(prs, pr)
+			}
+		}
+		if len(prs) == 0 {
+			continue
+		}
+		sortNewestFirst(prs)
+		if slices.Contains(prs[0].AssignedReviewers, reviewerID) {
+			reciprocal = append(reciprocal, candidateID)
+		}
+	}
+	sort.Strings(reciprocal)
+	return reciprocal, nil
+}
+
+// sortNewestFirst orders PRs by CreatedAt descending; PRs without CreatedAt are treated
+// as the oldest, ties are broken by pull_request_id descending
+func sortNewestFirst(prs []*domain.PullRequest) {
+	sort.Slice(prs, func(i, j int) bool {
+		left, right := prs[i].CreatedAt, prs[j].CreatedAt
+		if (left == nil) != (right == nil) {
+			return right == nil
+		}
+		if left != nil && !left.Equal(*right) {
+			return left.After(*right)
+		}
+		return prs[i].PullRequestID > prs[j].PullRequestID
+	})
+}
+
 func (m *MockPRRepository) GetUserAssignmentStats(ctx context.Context) (map[string]*domain.UserAssignmentStats, error) {
 	if m.GetUserAssignmentStatsFunc != nil {
 		return m.GetUserAssignmentStatsFunc(ctx)
@@ -242,24 +639,176 @@ func (m *MockPRRepository) GetUserAssignmentStats(ctx context.Context) (map[stri
 	return stats, nil
 }
 
-func (m *MockPRRepository) List(ctx context.Context, status string) ([]*domain.PullRequest, error) {
+// GetTeamLoadStats returns no teams unless overridden: team membership is not known to the PR mock
+func (m *MockPRRepository) GetTeamLoadStats(ctx context.Context) ([]domain.TeamLoadStats, error) {
+	if m.GetTeamLoadStatsFunc != nil {
+		return m.GetTeamLoadStatsFunc(ctx)
+	}
+	return []domain.TeamLoadStats{}, nil
+}
+
+// GetAuthorStats groups mock PRs by author, keeping only authors mapped to teamName
+// in AuthorTeams when it is set. Usernames are not known to the mock and stay empty
+func (m *MockPRRepository) GetAuthorStats(ctx context.Context, teamName string) ([]domain.AuthorStats, error) {
+	byAuthor := make(map[string]*domain.AuthorStats)
+	for _, pr := range m.PRs {
+		if teamName != "" && m.AuthorTeams[pr.AuthorID] != teamName {
+			continue
+		}
+		s, ok := byAuthor[pr.AuthorID]
+		if !ok {
+			s = &domain.AuthorStats{AuthorID: pr.AuthorID}
+			byAuthor[pr.AuthorID] = s
+		}
+		s.TotalPRs++
+		if pr.Status == domain.PRStatusOpen {
+			s.OpenPRs++
+		} else if pr.Status == domain.PRStatusMerged {
+			s.MergedPRs++
+		}
+	}
+
+	stats := make([]domain.AuthorStats, 0, len(byAuthor))
+	for _, s := range byAuthor {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].TotalPRs != stats[j].TotalPRs {
+			return stats[i].TotalPRs > stats[j].TotalPRs
+		}
+		return stats[i].AuthorID < stats[j].AuthorID
+	})
+
+	return stats, nil
+}
+
+func (m *MockPRRepository) GetUserAssignmentStatsByUser(ctx context.Context, userID string) (*domain.UserAssignmentStats, error) {
+	all, err := m.GetUserAssignmentStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if stats, ok := all[userID]; ok {
+		return stats, nil
+	}
+	return &domain.UserAssignmentStats{UserID: userID}, nil
+}
+
+// GetOrphanedReviewers has no user data to join against, so orphans come only from the hook
+func (m *MockPRRepository) GetOrphanedReviewers(ctx context.Context) ([]domain.OrphanedReviewer, error) {
+	if m.GetOrphanedReviewersFunc != nil {
+		return m.GetOrphanedReviewersFunc(ctx)
+	}
+	return []domain.OrphanedReviewer{}, nil
+}
+
+// GetInvariantViolations reports authors assigned to their own PRs; the mock keeps neither
+// assignment times nor reviewers without a PR, so other violations never occur
+func (m *MockPRRepository) GetInvariantViolations(ctx context.Context) ([]domain.InvariantViolation, error) {
+	violations := []domain.InvariantViolation{}
+	for _, pr := range m.PRs {
+		if pr.HasReviewer(pr.AuthorID) {
+			violations = append(violations, domain.InvariantViolation{
+				Kind:          domain.ViolationAuthorIsReviewer,
+				PullRequestID: pr.PullRequestID,
+				UserID:        pr.AuthorID,
+			})
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].PullRequestID < violations[j].PullRequestID
+	})
+	return violations, nil
+}
+
+// GetFreezeUntil returns the stored freeze end
+func (m *MockPRRepository) GetFreezeUntil(ctx context.Context) (*time.Time, error) {
+	return m.FreezeUntil, nil
+}
+
+// SetFreezeUntil stores the freeze end
+func (m *MockPRRepository) SetFreezeUntil(ctx context.Context, until *time.Time) error {
+	m.FreezeUntil = until
+	return nil
+}
+
+// GetAssignmentCountsSince treats every assignment in the mock as made since the given time
+func (m *MockPRRepository) GetAssignmentCountsSince(ctx context.Context, since time.Time) (map[string]int, error) {
+	if m.GetAssignmentCountsSinceFunc != nil {
+		return m.GetAssignmentCountsSinceFunc(ctx, since)
+	}
+	counts := make(map[string]int)
+	for _, pr := range m.PRs {
+		for _, reviewerID := range pr.AssignedReviewers {
+			counts[reviewerID]++
+		}
+	}
+	return counts, nil
+}
+
+// GetOpenAssignedBefore has no assignment times to compare, so breaches come only from the hook
+func (m *MockPRRepository) GetOpenAssignedBefore(ctx context.Context, before time.Time) ([]domain.SLABreach, error) {
+	if m.GetOpenAssignedBeforeFunc != nil {
+		return m.GetOpenAssignedBeforeFunc(ctx, before)
+	}
+	return []domain.SLABreach{}, nil
+}
+
+func (m *MockPRRepository) List(ctx context.Context, statuses []string, page domain.Page) ([]*domain.PullRequest, error) {
 	if m.ListFunc != nil {
-		return m.ListFunc(ctx, status)
+		return m.ListFunc(ctx, statuses, page)
 	}
 	result := make([]*domain.PullRequest, 0, len(m.PRs))
 
 	for _, pr := range m.PRs {
-		if status == "" || string(pr.Status) == status {
+		if len(statuses) == 0 || slices.Contains(statuses, string(pr.Status)) {
 			result = append(result, pr)
 		}
 	}
 
-	return result, nil
+	// Same order as the repository: newest first, then by pull_request_id
+	sort.Slice(result, func(i, j int) bool {
+		left, right := result[i].CreatedAt, result[j].CreatedAt
+		if (left == nil) != (right == nil) {
+			return right == nil
+		}
+		if left != nil && !left.Equal(*right) {
+			return left.After(*right)
+		}
+		return result[i].PullRequestID < result[j].PullRequestID
+	})
+
+	return paginate(result, page), nil
+}
+
+func (m *MockPRRepository) StreamList(ctx context.Context, statuses []string, fn func(pr *domain.PullRequest) error) error {
+	prs, err := m.List(ctx, statuses, domain.Page{})
+	if err != nil {
+		return err
+	}
+	for _, pr := range prs {
+		if err := fn(pr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// paginate applies a page window to mock results the same way LIMIT/OFFSET would
+func paginate[T any](items []T, page domain.Page) []T {
+	if page.Offset >= len(items) {
+		return items[:0]
+	}
+	items = items[page.Offset:]
+	if page.Limit > 0 && page.Limit < len(items) {
+		items = items[:page.Limit]
+	}
+	return items
 }
 
 // MockUserRepository implements domain.UserRepository for testing
 type MockUserRepository struct {
-	Users map[string]*domain.User
+	Users     map[string]*domain.User
+	Conflicts []domain.ReviewConflict
 
 	// Hooks for custom behavior
 	GetFunc         func(ctx context.Context, userID string) (*domain.User, error)
@@ -316,6 +865,16 @@ func (m *MockUserRepository) GetByTeam(ctx context.Context, teamName string) ([]
 	return users, nil
 }
 
+func (m *MockUserRepository) GetByReviewGroup(ctx context.Context, teamName, reviewGroup string) ([]domain.User, error) {
+	users := make([]domain.User, 0)
+	for _, user := range m.Users {
+		if user.TeamName == teamName && user.ReviewGroup == reviewGroup {
+			users = append(users, *user)
+		}
+	}
+	return users, nil
+}
+
 func (m *MockUserRepository) GetActiveUsersExcludingTeam(ctx context.Context, excludeTeamName string) ([]domain.User, error) {
 	users := make([]domain.User, 0)
 	for _, user := range m.Users {
@@ -350,6 +909,66 @@ func (m *MockUserRepository) BulkDeactivateByTeam(ctx context.Context, teamName
 	return deactivated, nil
 }
 
+func (m *MockUserRepository) BulkActivateByTeam(ctx context.Context, teamName string) ([]string, error) {
+	var activated []string
+	for _, user := range m.Users {
+		if user.TeamName == teamName && !user.IsActive {
+			user.IsActive = true
+			activated = append(activated, user.UserID)
+		}
+	}
+	return activated, nil
+}
+
+func (m *MockUserRepository) AddReviewConflict(ctx context.Context, conflict domain.ReviewConflict) error {
+	for _, id := range []string{conflict.UserA, conflict.UserB} {
+		if _, ok := m.Users[id]; !ok {
+			return domain.ErrNotFound
+		}
+	}
+	for _, existing := range m.Conflicts {
+		if existing.UserA == conflict.UserA && existing.UserB == conflict.UserB {
+			return nil
+		}
+	}
+	m.Conflicts = append(m.Conflicts, conflict)
+	return nil
+}
+
+func (m *MockUserRepository) RemoveReviewConflict(ctx context.Context, conflict domain.ReviewConflict) error {
+	for i, existing := range m.Conflicts {
+		if existing.UserA == conflict.UserA && existing.UserB == conflict.UserB {
+			m.Conflicts = append(m.Conflicts[:i], m.Conflicts[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (m *MockUserRepository) ListReviewConflicts(ctx context.Context) ([]domain.ReviewConflict, error) {
+	conflicts := append([]domain.ReviewConflict{}, m.Conflicts...)
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].UserA != conflicts[j].UserA {
+			return conflicts[i].UserA < conflicts[j].UserA
+		}
+		return conflicts[i].UserB < conflicts[j].UserB
+	})
+	return conflicts, nil
+}
+
+func (m *MockUserRepository) GetReviewConflicts(ctx context.Context, userID string) ([]string, error) {
+	conflicts := make([]string, 0)
+	for _, conflict := range m.Conflicts {
+		switch userID {
+		case conflict.UserA:
+			conflicts = append(conflicts, conflict.UserB)
+		case conflict.UserB:
+			conflicts = append(conflicts, conflict.UserA)
+		}
+	}
+	return conflicts, nil
+}
+
 // MockTeamRepository implements domain.TeamRepository for testing
 type MockTeamRepository struct {
 	Teams map[string]*domain.Team
@@ -382,3 +1001,89 @@ func (m *MockTeamRepository) Exists(ctx context.Context, teamName string) (bool,
 	_, exists := m.Teams[teamName]
 	return exists, nil
 }
+
+// GetReviewerCount treats unknown teams as having no configured count
+func (m *MockTeamRepository) GetReviewerCount(ctx context.Context, teamName string) (int, error) {
+	if team, ok := m.Teams[teamName]; ok {
+		return team.ReviewerCount, nil
+	}
+	return 0, nil
+}
+
+func (m *MockTeamRepository) SetReviewerCount(ctx context.Context, teamName string, count int) error {
+	team, ok := m.Teams[teamName]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	team.ReviewerCount = count
+	return nil
+}
+
+// GetBackupTeam treats unknown teams as having no backup team
+func (m *MockTeamRepository) GetBackupTeam(ctx context.Context, teamName string) (string, error) {
+	if team, ok := m.Teams[teamName]; ok {
+		return team.BackupTeamName, nil
+	}
+	return "", nil
+}
+
+func (m *MockTeamRepository) SetBackupTeam(ctx context.Context, teamName, backupTeamName string) error {
+	team, ok := m.Teams[teamName]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	if _, ok := m.Teams[backupTeamName]; backupTeamName != "" && !ok {
+		return domain.ErrNotFound
+	}
+	team.BackupTeamName = backupTeamName
+	return nil
+}
+
+// GetCalendars returns the Calendar of each known team that has one
+func (m *MockTeamRepository) GetCalendars(ctx context.Context, teamNames []string) (map[string]*domain.TeamCalendar, error) {
+	calendars := make(map[string]*domain.TeamCalendar)
+	for _, teamName := range teamNames {
+		if team, ok := m.Teams[teamName]; ok && team.Calendar != nil && !team.Calendar.IsEmpty() {
+			calendars[teamName] = team.Calendar
+		}
+	}
+	return calendars, nil
+}
+
+func (m *MockTeamRepository) SetCalendar(ctx context.Context, teamName string, calendar *domain.TeamCalendar) error {
+	team, ok := m.Teams[teamName]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	team.Calendar = nil
+	if !calendar.IsEmpty() {
+		team.Calendar = calendar
+	}
+	return nil
+}
+
+// MockTxManager runs fn without a real transaction and records how each one ended
+type MockTxManager struct {
+	Commits   int
+	Rollbacks int
+}
+
+// mockTxKey marks contexts passed to fn by MockTxManager
+type mockTxKey struct{}
+
+// WithinTransaction calls fn with a nil *sql.Tx and a context marked by InMockTransaction;
+// an error from fn counts as a rollback
+func (m *MockTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	if err := fn(context.WithValue(ctx, mockTxKey{}, true), nil); err != nil {
+		m.Rollbacks++
+		return err
+	}
+	m.Commits++
+	return nil
+}
+
+// InMockTransaction reports whether ctx comes from MockTxManager.WithinTransaction
+func InMockTransaction(ctx context.Context) bool {
+	inTx, _ := ctx.Value(mockTxKey{}).(bool)
+	return inTx
+}
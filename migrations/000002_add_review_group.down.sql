@@ -0,0 +1,3 @@
+-- Откат миграции
+DROP INDEX IF EXISTS idx_users_team_review_group;
+ALTER TABLE users DROP COLUMN IF EXISTS review_group;
@@ -0,0 +1,5 @@
+-- Добавление группы ревью (подгруппы внутри команды)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS review_group VARCHAR(255);
+
+-- Индекс для поиска участников группы внутри команды
+CREATE INDEX IF NOT EXISTS idx_users_team_review_group ON users(team_name, review_group);
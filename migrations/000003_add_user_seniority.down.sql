@@ -0,0 +1,2 @@
+-- Откат миграции
+ALTER TABLE users DROP COLUMN IF EXISTS seniority;
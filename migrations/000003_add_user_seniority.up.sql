@@ -0,0 +1,2 @@
+-- Добавление уровня сеньорности пользователя (0 - не указан)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS seniority INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Откат миграции
+DROP INDEX IF EXISTS idx_pr_reviewers_assigned_at;
+ALTER TABLE users DROP COLUMN IF EXISTS max_daily_assignments;
@@ -0,0 +1,5 @@
+-- Персональный дневной лимит назначений (NULL - используется лимит из конфигурации)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_daily_assignments INTEGER;
+
+-- Индекс для подсчёта назначений за текущие сутки
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_assigned_at ON pr_reviewers(assigned_at);
@@ -0,0 +1,3 @@
+-- Откат миграции
+ALTER TABLE users DROP COLUMN IF EXISTS available_to;
+ALTER TABLE users DROP COLUMN IF EXISTS available_from;
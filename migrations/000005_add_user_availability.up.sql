@@ -0,0 +1,3 @@
+-- Часы доступности пользователя в течение суток (NULL - доступен всегда)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS available_from SMALLINT CHECK (available_from BETWEEN 0 AND 23);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS available_to SMALLINT CHECK (available_to BETWEEN 0 AND 23);
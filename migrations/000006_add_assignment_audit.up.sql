@@ -0,0 +1,11 @@
+-- Журнал ручных вмешательств в назначение ревьюверов
+CREATE TABLE IF NOT EXISTS assignment_audit (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    action VARCHAR(50) NOT NULL,
+    forced BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_audit_pr_id ON assignment_audit(pull_request_id);
@@ -0,0 +1,2 @@
+-- Откат миграции
+ALTER TABLE teams DROP COLUMN IF EXISTS reviewer_count;
@@ -0,0 +1,2 @@
+-- Число ревьюверов, назначаемых на PR команды (NULL - значение по умолчанию из REVIEWERS_PER_PR)
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS reviewer_count SMALLINT CHECK (reviewer_count > 0);
@@ -0,0 +1,3 @@
+-- Откат миграции
+DROP INDEX IF EXISTS idx_pr_reviewers_primary;
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS is_primary;
@@ -0,0 +1,15 @@
+-- Основной (ответственный) ревьювер PR; остальные ревьюверы - дополнительные
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS is_primary BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- У PR не больше одного основного ревьювера
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pr_reviewers_primary ON pr_reviewers(pull_request_id) WHERE is_primary;
+
+-- Существующим PR основным назначается самый ранний ревьювер
+UPDATE pr_reviewers prr
+SET is_primary = TRUE
+FROM (
+    SELECT DISTINCT ON (pull_request_id) pull_request_id, user_id
+    FROM pr_reviewers
+    ORDER BY pull_request_id, assigned_at, user_id
+) first
+WHERE prr.pull_request_id = first.pull_request_id AND prr.user_id = first.user_id;
@@ -0,0 +1,10 @@
+-- Откат миграции. Вернуть NOT NULL можно только без пользователей вне команд: удаление
+-- их каскадно удалило бы их PR и назначения, поэтому откат прерывается, пока они есть
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM users WHERE team_name IS NULL) THEN
+        RAISE EXCEPTION 'cannot roll back: users without a team exist, add them to a team first';
+    END IF;
+END
+$$;
+ALTER TABLE users ALTER COLUMN team_name SET NOT NULL;
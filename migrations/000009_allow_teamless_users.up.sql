@@ -0,0 +1,2 @@
+-- Внешние контрибьюторы могут не состоять ни в одной команде
+ALTER TABLE users ALTER COLUMN team_name DROP NOT NULL;
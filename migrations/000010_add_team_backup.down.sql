@@ -0,0 +1,2 @@
+-- Откат миграции
+ALTER TABLE teams DROP COLUMN IF EXISTS backup_team_name;
@@ -0,0 +1,4 @@
+-- Резервная команда, из которой назначаются ревьюверы, если своих не хватает
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS backup_team_name VARCHAR(255)
+    REFERENCES teams(team_name) ON DELETE SET NULL
+    CHECK (backup_team_name <> team_name);
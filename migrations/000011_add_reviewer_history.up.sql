@@ -0,0 +1,12 @@
+-- История ревьюверов PR: назначения, удаления и замены.
+-- user_id и replaced_by не ссылаются на users: история сохраняет и удалённых пользователей
+CREATE TABLE IF NOT EXISTS reviewer_history (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    replaced_by VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_history_pr_id ON reviewer_history(pull_request_id, id);
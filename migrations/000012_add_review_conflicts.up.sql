@@ -0,0 +1,11 @@
+-- Конфликты интересов: пользователи пары не назначаются ревьюверами PR друг друга.
+-- Пара хранится упорядоченной (user_a < user_b), поэтому (a, b) и (b, a) - одна запись
+CREATE TABLE IF NOT EXISTS review_conflicts (
+    user_a VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    user_b VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_a, user_b),
+    CHECK (user_a < user_b)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_conflicts_user_b ON review_conflicts(user_b);
@@ -0,0 +1,2 @@
+-- Откат миграции
+DROP TABLE IF EXISTS service_settings;
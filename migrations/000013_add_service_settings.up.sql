@@ -0,0 +1,8 @@
+-- Глобальные настройки сервиса: ровно одна строка (id = 1).
+-- freeze_until - окончание заморозки автоназначения ревьюверов (NULL - заморозки нет)
+CREATE TABLE IF NOT EXISTS service_settings (
+    id SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    freeze_until TIMESTAMPTZ
+);
+
+INSERT INTO service_settings (id) VALUES (1) ON CONFLICT DO NOTHING;
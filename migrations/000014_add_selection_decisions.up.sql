@@ -0,0 +1,12 @@
+-- Журнал решений автоотбора ревьюверов для анализа справедливости назначений:
+-- размер пула кандидатов, выбранные ревьюверы и стратегия на момент создания PR
+CREATE TABLE IF NOT EXISTS selection_decisions (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    pool_size INTEGER NOT NULL,
+    reviewers TEXT[] NOT NULL DEFAULT '{}',
+    strategy VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_selection_decisions_pr_id ON selection_decisions(pull_request_id, id);
@@ -0,0 +1,3 @@
+-- Откат миграции
+DROP TABLE IF EXISTS team_holidays;
+ALTER TABLE teams DROP COLUMN IF EXISTS working_days;
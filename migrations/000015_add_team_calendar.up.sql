@@ -0,0 +1,10 @@
+-- Рабочий календарь команды: рабочие дни недели (ISO, 1 - понедельник) и праздники.
+-- NULL в working_days - все дни рабочие
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS working_days INTEGER[]
+    CHECK (working_days <@ ARRAY[1, 2, 3, 4, 5, 6, 7]);
+
+CREATE TABLE IF NOT EXISTS team_holidays (
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    holiday DATE NOT NULL,
+    PRIMARY KEY (team_name, holiday)
+);
@@ -0,0 +1,2 @@
+-- Откат миграции
+ALTER TABLE reviewer_history DROP COLUMN IF EXISTS selection_trace;
@@ -0,0 +1,3 @@
+-- Трассировка автоотбора, при котором назначен ревьювер (для explainAssignment):
+-- пишется вместе с событиями assign/reassign, у ручных назначений пуста
+ALTER TABLE reviewer_history ADD COLUMN IF NOT EXISTS selection_trace JSONB;
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"reviewservice/internal/config"
 	"reviewservice/internal/domain"
@@ -163,22 +164,60 @@ func TestBulkDeactivation(t *testing.T) {
 		len(result.DeactivatedUsers), result.ReassignedPRs, result.Errors)
 }
 
+// TestExternalContributorPR проверяет, что PR автора без команды получает ревьюверов из общего пула
+func TestExternalContributorPR(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	router := setupTestServer(db, t)
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	makeRequest(t, ts, "POST", "/team/add", domain.Team{
+		TeamName: "core",
+		Members: []domain.TeamMember{
+			{UserID: "c1", Username: "Core1", IsActive: true},
+			{UserID: "c2", Username: "Core2", IsActive: true},
+		},
+	})
+
+	// Внешний контрибьютор заводится напрямую: через API команд пользователь без команды не создаётся
+	if _, err := db.ExecContext(context.Background(),
+		`INSERT INTO users (user_id, username, team_name) VALUES ('ext', 'External', NULL)`); err != nil {
+		t.Fatalf("failed to seed external contributor: %v", err)
+	}
+
+	resp := makeRequest(t, ts, "POST", "/pullRequest/create", map[string]string{
+		"pull_request_id":   "pr-ext",
+		"pull_request_name": "Fix typo",
+		"author_id":         "ext",
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	var prResp struct {
+		PR domain.PullRequest `json:"pr"`
+	}
+	decodeJSON(t, resp, &prResp)
+
+	if prResp.PR.ReviewerSource != domain.ReviewerSourceGlobalPool {
+		t.Errorf("expected reviewer source %q, got %q", domain.ReviewerSourceGlobalPool, prResp.PR.ReviewerSource)
+	}
+	if len(prResp.PR.AssignedReviewers) != 2 {
+		t.Errorf("expected 2 reviewers from the global pool, got %v", prResp.PR.AssignedReviewers)
+	}
+}
+
 // setupTestDB создаёт тестовую БД и применяет миграции
 func setupTestDB(t *testing.T) (*sql.DB, func()) {
 	t.Helper()
 
-	// Используем переменные окружения или дефолтные значения
-	cfg := config.DatabaseConfig{
-		Host:     getEnvOrDefault("TEST_DB_HOST", "localhost"),
-		Port:     5432,
-		User:     getEnvOrDefault("TEST_DB_USER", "reviewservice"),
-		Password: getEnvOrDefault("TEST_DB_PASSWORD", "password"),
-		Name:     getEnvOrDefault("TEST_DB_NAME", "reviewservice_test"),
-		SSLMode:  "disable",
-	}
-
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.SSLMode)
+	dsn := testDSN()
 
 	// Применяем миграции
 	migrationsPath := "file://../../migrations"
@@ -209,12 +248,29 @@ func setupTestDB(t *testing.T) (*sql.DB, func()) {
 	cleanup := func() {
 		// Очищаем данные после теста
 		db.ExecContext(context.Background(), "TRUNCATE teams, users, pull_requests, pr_reviewers CASCADE")
+		db.ExecContext(context.Background(), "UPDATE service_settings SET freeze_until = NULL")
 		db.Close()
 	}
 
 	return db, cleanup
 }
 
+// testDSN возвращает строку подключения к тестовой БД
+func testDSN() string {
+	// Используем переменные окружения или дефолтные значения
+	cfg := config.DatabaseConfig{
+		Host:     getEnvOrDefault("TEST_DB_HOST", "localhost"),
+		Port:     5432,
+		User:     getEnvOrDefault("TEST_DB_USER", "reviewservice"),
+		Password: getEnvOrDefault("TEST_DB_PASSWORD", "password"),
+		Name:     getEnvOrDefault("TEST_DB_NAME", "reviewservice_test"),
+		SSLMode:  "disable",
+	}
+
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
+		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.SSLMode)
+}
+
 // setupTestServer создаёт тестовый HTTP сервер
 func setupTestServer(db *sql.DB, t *testing.T) http.Handler {
 	t.Helper()
@@ -230,18 +286,19 @@ func setupTestServer(db *sql.DB, t *testing.T) http.Handler {
 	prRepo := postgres.NewPullRequestRepository(db)
 
 	// Services
-	teamService := service.NewTeamService(teamRepo, userRepo, txManager, logger)
-	userService := service.NewUserService(userRepo, prRepo, logger)
-	prService := service.NewPullRequestService(prRepo, userRepo, logger)
-	statsService := service.NewStatsService(prRepo, userRepo, logger)
+	userService := service.NewUserService(userRepo, prRepo, service.AssignmentConfig{}, logger)
+	teamService := service.NewTeamService(teamRepo, userRepo, userService, txManager, logger)
+	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo, txManager, service.ReviewerPolicy{}, service.AssignmentConfig{}, logger)
+	statsService := service.NewStatsService(prRepo, userRepo, teamRepo, service.AssignmentConfig{}, logger)
 
 	// Handlers
-	teamHandler := handler.NewTeamHandler(teamService, statsService, logger)
-	userHandler := handler.NewUserHandler(userService, prService, logger)
-	prHandler := handler.NewPullRequestHandler(prService, logger)
+	pagination := handler.PaginationConfig{DefaultPageSize: 50, MaxPageSize: 500}
+	teamHandler := handler.NewTeamHandler(teamService, statsService, prService, logger)
+	userHandler := handler.NewUserHandler(userService, prService, pagination, logger)
+	prHandler := handler.NewPullRequestHandler(prService, pagination, logger)
 	statsHandler := handler.NewStatsHandler(statsService, logger)
 
-	return handler.Router(teamHandler, userHandler, prHandler, statsHandler, logger)
+	return handler.Router(teamHandler, userHandler, prHandler, statsHandler, 60*time.Second, 1, logger)
 }
 
 // makeRequest выполняет HTTP запрос к тестовому серверу
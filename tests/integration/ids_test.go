@@ -0,0 +1,61 @@
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"reviewservice/internal/domain"
+)
+
+// TestIDNormalization_TeamRoundTrip проверяет, что пробелы по краям идентификаторов
+// обрезаются при создании команды и не мешают её последующему получению
+func TestIDNormalization_TeamRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ts := httptest.NewServer(setupTestServer(db, t))
+	defer ts.Close()
+
+	resp := makeRequest(t, ts, "POST", "/team/add", domain.Team{
+		TeamName: " backend ",
+		Members: []domain.TeamMember{
+			{UserID: "u1 ", Username: "Alice", IsActive: true},
+			{UserID: "\tu2", Username: "Bob", IsActive: true},
+		},
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+	resp.Body.Close()
+
+	resp = makeRequest(t, ts, "GET", "/team/get?team_name=backend", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	var team domain.Team
+	decodeJSON(t, resp, &team)
+
+	if team.TeamName != "backend" {
+		t.Errorf("expected team name %q, got %q", "backend", team.TeamName)
+	}
+	for _, userID := range []string{"u1", "u2"} {
+		if _, ok := findMember(team, userID); !ok {
+			t.Errorf("expected trimmed member %s in team", userID)
+		}
+	}
+
+	// Идентификатор из одних пробелов отклоняется
+	resp = makeRequest(t, ts, "POST", "/users/setIsActive", map[string]interface{}{
+		"user_id":   "   ",
+		"is_active": false,
+	})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for blank user_id, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+}
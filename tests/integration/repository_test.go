@@ -0,0 +1,1521 @@
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"reviewservice/internal/domain"
+	"reviewservice/internal/repository/postgres"
+	"reviewservice/internal/service"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// TestUserRepository_CreateWithMissingTeam проверяет маппинг FK-нарушения в ErrNotFound
+func TestUserRepository_CreateWithMissingTeam(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	userRepo := postgres.NewUserRepository(db)
+
+	err := userRepo.Create(context.Background(), &domain.User{
+		UserID:   "ghost",
+		Username: "Ghost",
+		TeamName: "no-such-team",
+		IsActive: true,
+	})
+
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestPullRequestRepository_GetTrends проверяет разбиение PR по дням, включая дни без активности
+func TestPullRequestRepository_GetTrends(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('trends')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('t1', 'Trend', 'trends')`,
+		// 10 мая: два созданных PR, один из них смерджен 12 мая
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
+		 VALUES ('tr-1', 'First', 't1', 'MERGED', '2024-05-10 09:00', '2024-05-12 15:00')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at)
+		 VALUES ('tr-2', 'Second', 't1', 'OPEN', '2024-05-10 18:30')`,
+		// 11 мая: один созданный PR
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at)
+		 VALUES ('tr-3', 'Third', 't1', 'OPEN', '2024-05-11 11:00')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	from := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC)
+
+	buckets, err := prRepo.GetTrends(ctx, from, to, domain.TrendIntervalDay, "")
+	if err != nil {
+		t.Fatalf("failed to get trends: %v", err)
+	}
+
+	want := []domain.TrendBucket{
+		{Date: "2024-05-10", Created: 2, Merged: 0},
+		{Date: "2024-05-11", Created: 1, Merged: 0},
+		{Date: "2024-05-12", Created: 0, Merged: 1},
+		{Date: "2024-05-13", Created: 0, Merged: 0},
+	}
+	if len(buckets) != len(want) {
+		t.Fatalf("expected %d buckets, got %d: %+v", len(want), len(buckets), buckets)
+	}
+	for i := range want {
+		if buckets[i] != want[i] {
+			t.Errorf("bucket %d: expected %+v, got %+v", i, want[i], buckets[i])
+		}
+	}
+}
+
+// TestPullRequestRepository_GetTrendsByTeam проверяет, что ряд по команде учитывает только PR её авторов
+func TestPullRequestRepository_GetTrendsByTeam(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('alpha'), ('beta')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('a1', 'Alpha', 'alpha')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('b1', 'Beta', 'beta')`,
+		// alpha: PR 10 мая смерджен 11 мая, ещё один PR 11 мая
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
+		 VALUES ('al-1', 'Alpha 1', 'a1', 'MERGED', '2024-05-10 09:00', '2024-05-11 10:00')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at)
+		 VALUES ('al-2', 'Alpha 2', 'a1', 'OPEN', '2024-05-11 12:00')`,
+		// beta: два PR 10 мая, один смерджен в тот же день
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
+		 VALUES ('be-1', 'Beta 1', 'b1', 'MERGED', '2024-05-10 11:00', '2024-05-10 17:00')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at)
+		 VALUES ('be-2', 'Beta 2', 'b1', 'OPEN', '2024-05-10 13:00')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	from := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		team string
+		want []domain.TrendBucket
+	}{
+		{team: "alpha", want: []domain.TrendBucket{
+			{Date: "2024-05-10", Created: 1, Merged: 0},
+			{Date: "2024-05-11", Created: 1, Merged: 1},
+		}},
+		{team: "beta", want: []domain.TrendBucket{
+			{Date: "2024-05-10", Created: 2, Merged: 1},
+			{Date: "2024-05-11", Created: 0, Merged: 0},
+		}},
+		{team: "", want: []domain.TrendBucket{
+			{Date: "2024-05-10", Created: 3, Merged: 1},
+			{Date: "2024-05-11", Created: 1, Merged: 1},
+		}},
+	}
+
+	for _, tt := range tests {
+		buckets, err := prRepo.GetTrends(ctx, from, to, domain.TrendIntervalDay, tt.team)
+		if err != nil {
+			t.Fatalf("failed to get trends for team %q: %v", tt.team, err)
+		}
+		if len(buckets) != len(tt.want) {
+			t.Fatalf("team %q: expected %d buckets, got %d: %+v", tt.team, len(tt.want), len(buckets), buckets)
+		}
+		for i := range tt.want {
+			if buckets[i] != tt.want[i] {
+				t.Errorf("team %q bucket %d: expected %+v, got %+v", tt.team, i, tt.want[i], buckets[i])
+			}
+		}
+	}
+}
+
+// TestTxManager_CreatePRWithReviewers проверяет, что создание PR и назначение ревьюверов
+// через контекст транзакции фиксируются вместе и вместе откатываются при ошибке
+func TestTxManager_CreatePRWithReviewers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('tx')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('tx-author', 'Author', 'tx')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('tx-r1', 'Reviewer1', 'tx')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('tx-r2', 'Reviewer2', 'tx')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	txManager := postgres.NewTxManager(db)
+
+	createWithReviewers := func(prID string, fail bool) error {
+		return txManager.WithinTransaction(ctx, func(ctx context.Context, _ *sql.Tx) error {
+			pr := &domain.PullRequest{
+				PullRequestID:   prID,
+				PullRequestName: "Tx PR",
+				AuthorID:        "tx-author",
+				Status:          domain.PRStatusOpen,
+			}
+			if err := prRepo.Create(ctx, pr); err != nil {
+				return err
+			}
+			if err := prRepo.AssignReviewers(ctx, prID, []string{"tx-r1", "tx-r2"}); err != nil {
+				return err
+			}
+			if fail {
+				return errors.New("forced rollback")
+			}
+			return nil
+		})
+	}
+
+	// Ошибка после назначения откатывает и PR, и ревьюверов
+	if err := createWithReviewers("tx-rollback", true); err == nil {
+		t.Fatal("expected rollback error")
+	}
+	exists, err := prRepo.Exists(ctx, "tx-rollback")
+	if err != nil {
+		t.Fatalf("failed to check PR: %v", err)
+	}
+	if exists {
+		t.Fatal("PR must be rolled back")
+	}
+	var orphaned int
+	if err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM pr_reviewers WHERE pull_request_id = 'tx-rollback'`).Scan(&orphaned); err != nil {
+		t.Fatalf("failed to count reviewers: %v", err)
+	}
+	if orphaned != 0 {
+		t.Fatalf("expected no reviewers after rollback, got %d", orphaned)
+	}
+
+	// Успешная транзакция фиксирует PR вместе с ревьюверами
+	if err := createWithReviewers("tx-commit", false); err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+	reviewers, err := prRepo.GetReviewers(ctx, "tx-commit")
+	if err != nil {
+		t.Fatalf("failed to get reviewers: %v", err)
+	}
+	if len(reviewers) != 2 {
+		t.Fatalf("expected 2 reviewers, got %v", reviewers)
+	}
+}
+
+// TestPullRequestRepository_GetByPrimaryReviewer проверяет, что выборка учитывает только
+// назначения с is_primary, а открытые PR идут раньше смердженных
+func TestPullRequestRepository_GetByPrimaryReviewer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('gpr')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('gpr-author', 'Author', 'gpr')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('gpr-r1', 'Reviewer1', 'gpr')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('gpr-r2', 'Reviewer2', 'gpr')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	// Первый назначенный ревьювер становится основным
+	create := func(prID string, reviewers ...string) {
+		pr := &domain.PullRequest{
+			PullRequestID:   prID,
+			PullRequestName: "GPR PR",
+			AuthorID:        "gpr-author",
+			Status:          domain.PRStatusOpen,
+		}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("failed to create PR %s: %v", prID, err)
+		}
+		if err := prRepo.AssignReviewers(ctx, prID, reviewers); err != nil {
+			t.Fatalf("failed to assign reviewers to %s: %v", prID, err)
+		}
+	}
+	create("gpr-merged", "gpr-r1", "gpr-r2")
+	create("gpr-open", "gpr-r1", "gpr-r2")
+	create("gpr-secondary", "gpr-r2", "gpr-r1")
+	if _, err := prRepo.Merge(ctx, "gpr-merged"); err != nil {
+		t.Fatalf("failed to merge PR: %v", err)
+	}
+
+	prs, err := prRepo.GetByPrimaryReviewer(ctx, "gpr-r1")
+	if err != nil {
+		t.Fatalf("failed to get primary reviews: %v", err)
+	}
+	if len(prs) != 2 || prs[0].PullRequestID != "gpr-open" || prs[1].PullRequestID != "gpr-merged" {
+		t.Fatalf("expected [gpr-open gpr-merged], got %+v", prs)
+	}
+	if prs[0].CreatedAt == nil || prs[0].MergedAt != nil {
+		t.Fatalf("open PR must have createdAt without mergedAt, got %+v", prs[0])
+	}
+	if prs[1].MergedAt == nil {
+		t.Fatalf("merged PR must have mergedAt, got %+v", prs[1])
+	}
+
+	reviews, err := prRepo.GetByReviewer(ctx, "gpr-r2", domain.Page{})
+	if err != nil {
+		t.Fatalf("failed to get reviews: %v", err)
+	}
+	for _, pr := range reviews {
+		if pr.CreatedAt == nil {
+			t.Fatalf("expected createdAt for %s", pr.PullRequestID)
+		}
+	}
+
+	prs, err = prRepo.GetByPrimaryReviewer(ctx, "gpr-author")
+	if err != nil {
+		t.Fatalf("failed to get primary reviews: %v", err)
+	}
+	if len(prs) != 0 {
+		t.Fatalf("expected no PRs, got %+v", prs)
+	}
+}
+
+// TestPullRequestRepository_StreamList проверяет, что потоковое чтение возвращает PR вместе
+// с ревьюверами как на отдельном соединении, так и внутри транзакции (одним курсором)
+func TestPullRequestRepository_StreamList(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('sl')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('sl-author', 'Author', 'sl')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('sl-r1', 'Reviewer1', 'sl')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('sl-r2', 'Reviewer2', 'sl')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	create := func(prID string, reviewers ...string) {
+		pr := &domain.PullRequest{
+			PullRequestID:   prID,
+			PullRequestName: "SL PR",
+			AuthorID:        "sl-author",
+			Status:          domain.PRStatusOpen,
+		}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("failed to create PR %s: %v", prID, err)
+		}
+		if err := prRepo.AssignReviewers(ctx, prID, reviewers); err != nil {
+			t.Fatalf("failed to assign reviewers to %s: %v", prID, err)
+		}
+	}
+	create("sl-two", "sl-r1", "sl-r2")
+	create("sl-none")
+	create("sl-merged", "sl-r2")
+	if _, err := prRepo.Merge(ctx, "sl-merged"); err != nil {
+		t.Fatalf("failed to merge PR: %v", err)
+	}
+
+	check := func(t *testing.T, stream func(fn func(pr *domain.PullRequest) error) error) {
+		t.Helper()
+
+		got := make(map[string]*domain.PullRequest)
+		if err := stream(func(pr *domain.PullRequest) error {
+			got[pr.PullRequestID] = pr
+			return nil
+		}); err != nil {
+			t.Fatalf("failed to stream PRs: %v", err)
+		}
+
+		if len(got) != 2 || got["sl-two"] == nil || got["sl-none"] == nil {
+			t.Fatalf("expected open PRs sl-two and sl-none, got %v", got)
+		}
+		two := got["sl-two"]
+		if len(two.AssignedReviewers) != 2 || !two.HasReviewer("sl-r1") || !two.HasReviewer("sl-r2") {
+			t.Fatalf("expected reviewers sl-r1 and sl-r2, got %v", two.AssignedReviewers)
+		}
+		if two.PrimaryReviewer != "sl-r1" {
+			t.Fatalf("expected primary reviewer sl-r1, got %q", two.PrimaryReviewer)
+		}
+		if two.CreatedAt == nil {
+			t.Fatal("expected createdAt to be set")
+		}
+		if none := got["sl-none"]; none.AssignedReviewers == nil || len(none.AssignedReviewers) != 0 {
+			t.Fatalf("expected empty reviewers for sl-none, got %v", none.AssignedReviewers)
+		}
+	}
+	open := []string{string(domain.PRStatusOpen)}
+
+	t.Run("без транзакции", func(t *testing.T) {
+		check(t, func(fn func(pr *domain.PullRequest) error) error {
+			return prRepo.StreamList(ctx, open, fn)
+		})
+	})
+
+	t.Run("внутри транзакции", func(t *testing.T) {
+		txManager := postgres.NewTxManager(db)
+		check(t, func(fn func(pr *domain.PullRequest) error) error {
+			return txManager.WithinTransaction(ctx, func(ctx context.Context, _ *sql.Tx) error {
+				return prRepo.StreamList(ctx, open, fn)
+			})
+		})
+	})
+
+	t.Run("ошибка fn прерывает чтение", func(t *testing.T) {
+		errStop := errors.New("stop")
+		calls := 0
+		err := prRepo.StreamList(ctx, nil, func(pr *domain.PullRequest) error {
+			calls++
+			return errStop
+		})
+		if !errors.Is(err, errStop) || calls != 1 {
+			t.Fatalf("expected stop after first PR, got err=%v calls=%d", err, calls)
+		}
+	})
+}
+
+// TestPullRequestRepository_GetOpenByAuthorTeam проверяет, что выборка ограничена открытыми
+// PR авторов команды и возвращает их вместе с ревьюверами
+func TestPullRequestRepository_GetOpenByAuthorTeam(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('oat'), ('oat-other')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('oat-author', 'Author', 'oat')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('oat-r1', 'Reviewer1', 'oat')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('oat-foreign', 'Foreign', 'oat-other')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	create := func(prID, authorID string, reviewers ...string) {
+		pr := &domain.PullRequest{
+			PullRequestID:   prID,
+			PullRequestName: "OAT PR",
+			AuthorID:        authorID,
+			Status:          domain.PRStatusOpen,
+		}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("failed to create PR %s: %v", prID, err)
+		}
+		if err := prRepo.AssignReviewers(ctx, prID, reviewers); err != nil {
+			t.Fatalf("failed to assign reviewers to %s: %v", prID, err)
+		}
+	}
+	create("oat-open", "oat-author", "oat-r1")
+	create("oat-merged", "oat-author", "oat-r1")
+	create("oat-foreign-pr", "oat-foreign", "oat-r1")
+	if _, err := prRepo.Merge(ctx, "oat-merged"); err != nil {
+		t.Fatalf("failed to merge PR: %v", err)
+	}
+
+	prs, err := prRepo.GetOpenByAuthorTeam(ctx, "oat")
+	if err != nil {
+		t.Fatalf("failed to get team open PRs: %v", err)
+	}
+	if len(prs) != 1 || prs[0].PullRequestID != "oat-open" {
+		t.Fatalf("expected [oat-open], got %+v", prs)
+	}
+	if len(prs[0].AssignedReviewers) != 1 || prs[0].PrimaryReviewer != "oat-r1" {
+		t.Fatalf("expected reviewer oat-r1, got %+v", prs[0])
+	}
+}
+
+// TestPullRequestRepository_ListPagination проверяет, что PR с одинаковым created_at
+// упорядочены по pull_request_id и соседние страницы не пересекаются
+func TestPullRequestRepository_ListPagination(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('lp')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('lp-author', 'Author', 'lp')`,
+		// Все PR созданы в один момент, порядок задаёт только pull_request_id
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at)
+		 SELECT 'lp-' || i, 'LP PR', 'lp-author', 'OPEN', '2025-01-01 10:00:00+00'
+		 FROM generate_series(1, 5) AS i`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	var got []string
+	for offset := 0; offset < 5; offset += 2 {
+		prs, err := prRepo.List(ctx, nil, domain.Page{Limit: 2, Offset: offset})
+		if err != nil {
+			t.Fatalf("failed to list PRs: %v", err)
+		}
+		for _, pr := range prs {
+			got = append(got, pr.PullRequestID)
+		}
+	}
+
+	want := []string{"lp-1", "lp-2", "lp-3", "lp-4", "lp-5"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected %v across pages, got %v", want, got)
+	}
+}
+
+// TestPullRequestService_RepairReviewers проверяет обнаружение записей pr_reviewers
+// на удалённых пользователей и их исправление: замену в открытом PR и удаление в смердженном
+func TestPullRequestService_RepairReviewers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('integrity')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('i-author', 'Author', 'integrity')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('i-r1', 'Reviewer', 'integrity')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('i-c1', 'Candidate', 'integrity')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
+		 VALUES ('i-open', 'Open', 'i-author', 'OPEN')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, merged_at)
+		 VALUES ('i-merged', 'Merged', 'i-author', 'MERGED', NOW())`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('i-open', 'i-r1')`,
+		// Внешний ключ не даёт сослаться на несуществующего пользователя - снимаем его,
+		// чтобы воспроизвести рассинхронизацию данных (миграции пересоздаются в каждом тесте)
+		`ALTER TABLE pr_reviewers DROP CONSTRAINT pr_reviewers_user_id_fkey`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('i-open', 'ghost')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('i-merged', 'ghost')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	userRepo := postgres.NewUserRepository(db)
+	svc := service.NewPullRequestService(prRepo, userRepo, postgres.NewTeamRepository(db), postgres.NewTxManager(db), service.ReviewerPolicy{}, service.AssignmentConfig{}, zap.NewNop())
+
+	orphans, err := svc.CheckIntegrity(ctx)
+	if err != nil {
+		t.Fatalf("CheckIntegrity failed: %v", err)
+	}
+	if len(orphans) != 2 {
+		t.Fatalf("expected 2 orphaned reviewers, got %+v", orphans)
+	}
+
+	repaired, err := svc.RepairReviewers(ctx)
+	if err != nil {
+		t.Fatalf("RepairReviewers failed: %v", err)
+	}
+	if len(repaired) != 2 {
+		t.Fatalf("expected 2 repaired rows, got %+v", repaired)
+	}
+
+	// В открытом PR осиротевший ревьювер заменён единственным свободным кандидатом
+	reviewers, err := prRepo.GetReviewers(ctx, "i-open")
+	if err != nil {
+		t.Fatalf("failed to get reviewers: %v", err)
+	}
+	if len(reviewers) != 2 || reviewers[0] != "i-r1" || reviewers[1] != "i-c1" {
+		t.Fatalf("expected [i-r1 i-c1], got %v", reviewers)
+	}
+
+	// В смердженном PR запись просто удалена
+	reviewers, err = prRepo.GetReviewers(ctx, "i-merged")
+	if err != nil {
+		t.Fatalf("failed to get reviewers: %v", err)
+	}
+	if len(reviewers) != 0 {
+		t.Fatalf("expected no reviewers in merged PR, got %v", reviewers)
+	}
+
+	orphans, err = svc.CheckIntegrity(ctx)
+	if err != nil {
+		t.Fatalf("CheckIntegrity failed: %v", err)
+	}
+	if len(orphans) != 0 {
+		t.Fatalf("expected no orphans after repair, got %+v", orphans)
+	}
+}
+
+// TestPullRequestService_SelfCheck проверяет, что самопроверка возвращает пустой отчёт
+// на согласованных данных и находит автора, назначенного ревьювером своего PR
+func TestPullRequestService_SelfCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('selfcheck')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('s-author', 'Author', 'selfcheck')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('s-r1', 'Reviewer', 'selfcheck')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
+		 VALUES ('s-open', 'Open', 's-author', 'OPEN')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, merged_at)
+		 VALUES ('s-merged', 'Merged', 's-author', 'MERGED', NOW() - INTERVAL '1 hour')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('s-open', 's-r1')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	svc := service.NewPullRequestService(prRepo, postgres.NewUserRepository(db), postgres.NewTeamRepository(db), postgres.NewTxManager(db), service.ReviewerPolicy{}, service.AssignmentConfig{}, zap.NewNop())
+
+	violations, err := svc.SelfCheck(ctx)
+	if err != nil {
+		t.Fatalf("SelfCheck failed: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("expected no violations, got %+v", violations)
+	}
+
+	// Схема не запрещает назначить автора ревьювером - вставляем запись в обход сервиса
+	if _, err := db.ExecContext(ctx, `INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('s-open', 's-author')`); err != nil {
+		t.Fatalf("failed to insert author as reviewer: %v", err)
+	}
+
+	violations, err = svc.SelfCheck(ctx)
+	if err != nil {
+		t.Fatalf("SelfCheck failed: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Kind != domain.ViolationAuthorIsReviewer ||
+		violations[0].PullRequestID != "s-open" || violations[0].UserID != "s-author" {
+		t.Fatalf("expected author_is_reviewer for s-open, got %+v", violations)
+	}
+}
+
+// TestTeamService_ImportTeams проверяет, что импорт создаёт команды и участников, пользователь
+// из двух команд остаётся в последней, повторный импорт идемпотентен, а ошибка откатывает всё
+func TestTeamService_ImportTeams(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, `INSERT INTO teams (team_name) VALUES ('im-old')`); err != nil {
+		t.Fatalf("failed to seed team: %v", err)
+	}
+	if _, err := db.ExecContext(ctx,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('im-shared', 'Old Name', 'im-old')`); err != nil {
+		t.Fatalf("failed to seed user: %v", err)
+	}
+
+	teamRepo := postgres.NewTeamRepository(db)
+	userRepo := postgres.NewUserRepository(db)
+	userService := service.NewUserService(userRepo, postgres.NewPullRequestRepository(db), service.AssignmentConfig{}, zap.NewNop())
+	teamService := service.NewTeamService(teamRepo, userRepo, userService, postgres.NewTxManager(db), zap.NewNop())
+
+	payload := func() []domain.Team {
+		return []domain.Team{
+			{
+				TeamName: "im-backend",
+				Members: []domain.TeamMember{
+					{UserID: "im-b1", Username: "B1", IsActive: true},
+					{UserID: "im-shared", Username: "Shared", IsActive: true},
+				},
+				BackupTeamName: "im-frontend",
+			},
+			{
+				TeamName: "im-frontend",
+				Members: []domain.TeamMember{
+					{UserID: "im-f1", Username: "F1", IsActive: true},
+					{UserID: "im-shared", Username: "Shared", IsActive: true},
+				},
+				ReviewerCount: 1,
+			},
+		}
+	}
+
+	results, err := teamService.ImportTeams(ctx, payload())
+	if err != nil {
+		t.Fatalf("ImportTeams failed: %v", err)
+	}
+	want := []service.ImportedTeam{
+		{TeamName: "im-backend", Created: true, CreatedUsers: 1, UpdatedUsers: 1},
+		{TeamName: "im-frontend", Created: true, CreatedUsers: 1, UpdatedUsers: 1},
+	}
+	if fmt.Sprint(results) != fmt.Sprint(want) {
+		t.Fatalf("expected %+v, got %+v", want, results)
+	}
+
+	// Общий пользователь переведён в последнюю команду, в которой он указан
+	members := func(teamName string) []string {
+		team, err := teamRepo.Get(ctx, teamName)
+		if err != nil {
+			t.Fatalf("failed to get team %s: %v", teamName, err)
+		}
+		ids := []string{}
+		for _, member := range team.Members {
+			ids = append(ids, member.UserID)
+		}
+		return ids
+	}
+	if got := fmt.Sprint(members("im-backend")); got != "[im-b1]" {
+		t.Fatalf("expected im-backend members [im-b1], got %s", got)
+	}
+	if got := fmt.Sprint(members("im-frontend")); got != "[im-f1 im-shared]" {
+		t.Fatalf("expected im-frontend members [im-f1 im-shared], got %s", got)
+	}
+	if got := fmt.Sprint(members("im-old")); got != "[]" {
+		t.Fatalf("expected im-old to lose its member, got %s", got)
+	}
+	backend, err := teamRepo.Get(ctx, "im-backend")
+	if err != nil {
+		t.Fatalf("failed to get team: %v", err)
+	}
+	if backend.BackupTeamName != "im-frontend" {
+		t.Fatalf("expected backup team im-frontend, got %q", backend.BackupTeamName)
+	}
+
+	// Повторный импорт ничего не создаёт и не считается ошибкой
+	results, err = teamService.ImportTeams(ctx, payload())
+	if err != nil {
+		t.Fatalf("repeated ImportTeams failed: %v", err)
+	}
+	if results[0].Created || results[0].CreatedUsers != 0 || results[1].Created || results[1].CreatedUsers != 0 {
+		t.Fatalf("expected nothing created on repeat, got %+v", results)
+	}
+
+	// Ошибка в последней команде откатывает и первую
+	broken := []domain.Team{
+		{TeamName: "im-new", Members: []domain.TeamMember{{UserID: "im-n1", Username: "N1", IsActive: true}}},
+		{TeamName: "im-broken", BackupTeamName: "im-missing"},
+	}
+	if _, err := teamService.ImportTeams(ctx, broken); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for missing backup team, got %v", err)
+	}
+	exists, err := teamRepo.Exists(ctx, "im-new")
+	if err != nil {
+		t.Fatalf("failed to check team: %v", err)
+	}
+	if exists {
+		t.Fatal("import must be rolled back")
+	}
+}
+
+// TestUserRepository_ReviewConflicts проверяет хранение конфликтов интересов: пара
+// находится с обеих сторон, повторное добавление не дублирует её, удаление пользователя удаляет пару
+func TestUserRepository_ReviewConflicts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('rc')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('rc-a', 'A', 'rc')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('rc-b', 'B', 'rc')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('rc-c', 'C', 'rc')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	userRepo := postgres.NewUserRepository(db)
+	for _, conflict := range []domain.ReviewConflict{
+		domain.NewReviewConflict("rc-b", "rc-a"),
+		domain.NewReviewConflict("rc-a", "rc-b"),
+		domain.NewReviewConflict("rc-c", "rc-b"),
+	} {
+		if err := userRepo.AddReviewConflict(ctx, conflict); err != nil {
+			t.Fatalf("AddReviewConflict failed: %v", err)
+		}
+	}
+	if err := userRepo.AddReviewConflict(ctx, domain.NewReviewConflict("rc-a", "ghost")); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown user, got %v", err)
+	}
+
+	conflicts, err := userRepo.ListReviewConflicts(ctx)
+	if err != nil {
+		t.Fatalf("ListReviewConflicts failed: %v", err)
+	}
+	if len(conflicts) != 2 || conflicts[0].UserA != "rc-a" || conflicts[1].UserA != "rc-b" || conflicts[1].UserB != "rc-c" {
+		t.Fatalf("expected [rc-a/rc-b rc-b/rc-c], got %+v", conflicts)
+	}
+
+	related, err := userRepo.GetReviewConflicts(ctx, "rc-b")
+	if err != nil {
+		t.Fatalf("GetReviewConflicts failed: %v", err)
+	}
+	if len(related) != 2 {
+		t.Fatalf("expected rc-b to conflict with rc-a and rc-c, got %v", related)
+	}
+
+	if err := userRepo.RemoveReviewConflict(ctx, domain.NewReviewConflict("rc-a", "rc-b")); err != nil {
+		t.Fatalf("RemoveReviewConflict failed: %v", err)
+	}
+	if err := userRepo.RemoveReviewConflict(ctx, domain.NewReviewConflict("rc-a", "rc-b")); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for removed conflict, got %v", err)
+	}
+
+	if _, err := db.ExecContext(ctx, `DELETE FROM users WHERE user_id = 'rc-c'`); err != nil {
+		t.Fatalf("failed to delete user: %v", err)
+	}
+	related, err = userRepo.GetReviewConflicts(ctx, "rc-b")
+	if err != nil {
+		t.Fatalf("GetReviewConflicts failed: %v", err)
+	}
+	if len(related) != 0 {
+		t.Fatalf("expected no conflicts after deleting the user, got %v", related)
+	}
+}
+
+// TestPullRequestRepository_RejectsMergedPRMutations проверяет, что репозиторий сам
+// отклоняет любые изменения ревьюверов смердженного PR, даже в обход сервиса
+func TestPullRequestRepository_RejectsMergedPRMutations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('frozen')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('f-author', 'Author', 'frozen')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('f-r1', 'Reviewer', 'frozen')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('f-r2', 'Other', 'frozen')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, merged_at)
+		 VALUES ('f-merged', 'Merged', 'f-author', 'MERGED', NOW())`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id, is_primary) VALUES ('f-merged', 'f-r1', TRUE)`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	mutations := map[string]func() error{
+		"assign":   func() error { return prRepo.AssignReviewers(ctx, "f-merged", []string{"f-r2"}) },
+		"replace":  func() error { return prRepo.ReplaceReviewers(ctx, "f-merged", []string{"f-r2"}) },
+		"add":      func() error { return prRepo.AddReviewer(ctx, "f-merged", "f-r2") },
+		"remove":   func() error { return prRepo.RemoveReviewer(ctx, "f-merged", "f-r1") },
+		"primary":  func() error { return prRepo.SetPrimaryReviewer(ctx, "f-merged", "f-r1") },
+		"reassign": func() error { return prRepo.ReassignReviewer(ctx, "f-merged", "f-r1", "f-r2") },
+	}
+	for name, mutate := range mutations {
+		if err := mutate(); !errors.Is(err, domain.ErrPRMerged) {
+			t.Errorf("%s: expected ErrPRMerged, got %v", name, err)
+		}
+	}
+
+	// Состав ревьюверов не изменился
+	reviewers, err := prRepo.GetReviewers(ctx, "f-merged")
+	if err != nil {
+		t.Fatalf("failed to get reviewers: %v", err)
+	}
+	if len(reviewers) != 1 || reviewers[0] != "f-r1" {
+		t.Fatalf("expected [f-r1], got %v", reviewers)
+	}
+}
+
+// TestPullRequestRepository_GetAuthorStats проверяет группировку PR по авторам,
+// подстановку username, порядок по убыванию и фильтр по команде
+func TestPullRequestRepository_GetAuthorStats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('alpha'), ('beta')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('a1', 'Alice', 'alpha')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('a2', 'Anna', 'alpha')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('b1', 'Bob', 'beta')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
+		 VALUES ('as-1', 'A1 first', 'a1', 'OPEN')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, merged_at)
+		 VALUES ('as-2', 'B1 first', 'b1', 'MERGED', NOW())`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, merged_at)
+		 VALUES ('as-3', 'B1 second', 'b1', 'MERGED', NOW())`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
+		 VALUES ('as-4', 'B1 third', 'b1', 'OPEN')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+
+	tests := []struct {
+		team string
+		want []domain.AuthorStats
+	}{
+		// Авторы без PR (a2) в выборку не попадают
+		{team: "", want: []domain.AuthorStats{
+			{AuthorID: "b1", Username: "Bob", TotalPRs: 3, OpenPRs: 1, MergedPRs: 2},
+			{AuthorID: "a1", Username: "Alice", TotalPRs: 1, OpenPRs: 1, MergedPRs: 0},
+		}},
+		{team: "alpha", want: []domain.AuthorStats{
+			{AuthorID: "a1", Username: "Alice", TotalPRs: 1, OpenPRs: 1, MergedPRs: 0},
+		}},
+	}
+
+	for _, tt := range tests {
+		stats, err := prRepo.GetAuthorStats(ctx, tt.team)
+		if err != nil {
+			t.Fatalf("failed to get author stats for team %q: %v", tt.team, err)
+		}
+		if len(stats) != len(tt.want) {
+			t.Fatalf("team %q: expected %d authors, got %+v", tt.team, len(tt.want), stats)
+		}
+		for i := range tt.want {
+			if stats[i] != tt.want[i] {
+				t.Errorf("team %q author %d: expected %+v, got %+v", tt.team, i, tt.want[i], stats[i])
+			}
+		}
+	}
+}
+
+// TestPullRequestRepository_GetTeamLoadStats проверяет подсчёт назначений на открытые PR
+// по командам ревьюверов, число активных участников и порядок по убыванию нагрузки
+func TestPullRequestRepository_GetTeamLoadStats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('alpha'), ('beta'), ('gamma')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('a1', 'Alice', 'alpha')`,
+		`INSERT INTO users (user_id, username, team_name, is_active) VALUES ('a2', 'Anna', 'alpha', false)`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('b1', 'Bob', 'beta')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('b2', 'Boris', 'beta')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('g1', 'Gleb', 'gamma')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
+		 VALUES ('tl-1', 'First', 'g1', 'OPEN')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
+		 VALUES ('tl-2', 'Second', 'g1', 'OPEN')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, merged_at)
+		 VALUES ('tl-3', 'Merged', 'g1', 'MERGED', NOW())`,
+		// beta: b1 на двух открытых PR, b2 на одном; назначение на смердженный PR не учитывается
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES
+		 ('tl-1', 'b1'), ('tl-2', 'b1'), ('tl-2', 'b2'), ('tl-3', 'b2')`,
+		// alpha: неактивный a2 ещё числится ревьювером открытого PR
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('tl-1', 'a2')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+
+	stats, err := prRepo.GetTeamLoadStats(ctx)
+	if err != nil {
+		t.Fatalf("failed to get team load stats: %v", err)
+	}
+
+	want := []domain.TeamLoadStats{
+		{TeamName: "beta", OpenAssignments: 3, ActiveReviewers: 2},
+		{TeamName: "alpha", OpenAssignments: 1, ActiveReviewers: 1},
+		{TeamName: "gamma", OpenAssignments: 0, ActiveReviewers: 1},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("expected %d teams, got %+v", len(want), stats)
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("team %d: expected %+v, got %+v", i, want[i], stats[i])
+		}
+	}
+}
+
+// TestPullRequestRepository_FreezeUntil проверяет сохранение, чтение и снятие заморозки автоназначения
+func TestPullRequestRepository_FreezeUntil(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	prRepo := postgres.NewPullRequestRepository(db)
+
+	until, err := prRepo.GetFreezeUntil(ctx)
+	if err != nil {
+		t.Fatalf("failed to get freeze: %v", err)
+	}
+	if until != nil {
+		t.Fatalf("expected no freeze, got %v", until)
+	}
+
+	want := time.Date(2030, 1, 2, 15, 30, 0, 0, time.UTC)
+	if err := prRepo.SetFreezeUntil(ctx, &want); err != nil {
+		t.Fatalf("failed to set freeze: %v", err)
+	}
+
+	until, err = prRepo.GetFreezeUntil(ctx)
+	if err != nil {
+		t.Fatalf("failed to get freeze: %v", err)
+	}
+	if until == nil || !until.Equal(want) {
+		t.Fatalf("expected freeze until %v, got %v", want, until)
+	}
+
+	// nil снимает заморозку
+	if err := prRepo.SetFreezeUntil(ctx, nil); err != nil {
+		t.Fatalf("failed to clear freeze: %v", err)
+	}
+
+	until, err = prRepo.GetFreezeUntil(ctx)
+	if err != nil {
+		t.Fatalf("failed to get freeze: %v", err)
+	}
+	if until != nil {
+		t.Fatalf("expected freeze cleared, got %v", until)
+	}
+}
+
+// TestPullRequestRepository_ReviewerHistory проверяет запись и порядок истории ревьюверов,
+// включая удалённых пользователей, которых нет в users
+func TestPullRequestRepository_ReviewerHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('history')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('h-author', 'Author', 'history')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('h-pr', 'History', 'h-author')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	entries := []domain.ReviewerHistoryEntry{
+		{PullRequestID: "h-pr", UserID: "h-r1", Action: domain.ReviewerActionAssign},
+		{PullRequestID: "h-pr", UserID: "h-r1", Action: domain.ReviewerActionReassign, ReplacedBy: "h-r2"},
+		{PullRequestID: "h-pr", UserID: "h-gone", Action: domain.ReviewerActionRemove},
+	}
+	if err := prRepo.RecordReviewerHistory(ctx, entries); err != nil {
+		t.Fatalf("failed to record history: %v", err)
+	}
+
+	history, err := prRepo.GetReviewerHistory(ctx, "h-pr")
+	if err != nil {
+		t.Fatalf("failed to get history: %v", err)
+	}
+	if len(history) != len(entries) {
+		t.Fatalf("expected %d entries, got %d", len(entries), len(history))
+	}
+	for i, entry := range entries {
+		got := history[i]
+		if got.UserID != entry.UserID || got.Action != entry.Action || got.ReplacedBy != entry.ReplacedBy {
+			t.Errorf("entry %d: expected %+v, got %+v", i, entry, got)
+		}
+		if got.CreatedAt.IsZero() {
+			t.Errorf("entry %d: created_at not set", i)
+		}
+	}
+
+	// История другого PR пуста, а не nil
+	empty, err := prRepo.GetReviewerHistory(ctx, "h-missing")
+	if err != nil || empty == nil || len(empty) != 0 {
+		t.Errorf("expected empty history, got %v (err %v)", empty, err)
+	}
+}
+
+// TestPullRequestService_SelectionDecisions проверяет, что решение отбора, записанное в фоне
+// при создании PR, сохраняется в selection_decisions и читается обратно
+func TestPullRequestService_SelectionDecisions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('decisions')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('d-author', 'Author', 'decisions')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('d-r1', 'R1', 'decisions')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('d-r2', 'R2', 'decisions')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	prService := service.NewPullRequestService(prRepo, postgres.NewUserRepository(db), postgres.NewTeamRepository(db), postgres.NewTxManager(db),
+		service.ReviewerPolicy{}, service.AssignmentConfig{}, zap.NewNop())
+
+	pr, err := prService.CreatePullRequest(ctx, "d-pr", "Decisions", "d-author", nil, nil, "")
+	if err != nil {
+		t.Fatalf("failed to create PR: %v", err)
+	}
+	prService.WaitSelectionDecisions()
+
+	decisions, err := prService.GetSelectionDecisions(ctx, "d-pr")
+	if err != nil {
+		t.Fatalf("failed to get selection decisions: %v", err)
+	}
+	if len(decisions) != 1 {
+		t.Fatalf("expected 1 decision, got %d", len(decisions))
+	}
+	got := decisions[0]
+	if got.PoolSize != 3 || got.Strategy != pr.SelectionStrategy || got.CreatedAt.IsZero() {
+		t.Errorf("unexpected decision %+v", got)
+	}
+	if len(got.Reviewers) != 2 || got.Reviewers[0] != pr.AssignedReviewers[0] || got.Reviewers[1] != pr.AssignedReviewers[1] {
+		t.Errorf("expected reviewers %v, got %v", pr.AssignedReviewers, got.Reviewers)
+	}
+}
+
+// TestPullRequestRepository_GetOpenAssignedBefore проверяет, что в отчёт о нарушениях SLA
+// попадает только открытый PR с давним назначением, а не свежий и не смердженный
+func TestPullRequestRepository_GetOpenAssignedBefore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('sla')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('sla-author', 'Author', 'sla')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('sla-r1', 'Reviewer1', 'sla')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('sla-r2', 'Reviewer2', 'sla')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('sla-old', 'Old', 'sla-author')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('sla-fresh', 'Fresh', 'sla-author')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, merged_at)
+		 VALUES ('sla-merged', 'Merged', 'sla-author', 'MERGED', '2024-05-10 12:00')`,
+		// Давнее назначение и более позднее на одном PR: отсчёт идёт от самого раннего
+		`INSERT INTO pr_reviewers (pull_request_id, user_id, assigned_at) VALUES ('sla-old', 'sla-r1', '2024-05-09 08:00')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id, assigned_at) VALUES ('sla-old', 'sla-r2', '2024-05-10 11:00')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id, assigned_at) VALUES ('sla-fresh', 'sla-r1', '2024-05-10 11:00')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id, assigned_at) VALUES ('sla-merged', 'sla-r2', '2024-05-08 08:00')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	before := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	breaches, err := prRepo.GetOpenAssignedBefore(ctx, before)
+	if err != nil {
+		t.Fatalf("failed to get SLA breaches: %v", err)
+	}
+	if len(breaches) != 1 {
+		t.Fatalf("expected 1 breach, got %d: %+v", len(breaches), breaches)
+	}
+
+	breach := breaches[0]
+	if breach.PullRequestID != "sla-old" || breach.PullRequestName != "Old" || breach.AuthorID != "sla-author" {
+		t.Errorf("unexpected breach: %+v", breach)
+	}
+	if want := time.Date(2024, 5, 9, 8, 0, 0, 0, time.UTC); !breach.OldestAssignedAt.Equal(want) {
+		t.Errorf("expected oldest assignment %v, got %v", want, breach.OldestAssignedAt)
+	}
+}
+
+// TestPullRequestRepository_CountOpenByUsers проверяет, что учитываются только открытые PR
+// и только запрошенные пользователи
+func TestPullRequestRepository_CountOpenByUsers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('load')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('load-author', 'Author', 'load')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('load-veteran', 'Veteran', 'load')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('load-busy', 'Busy', 'load')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, merged_at)
+		 VALUES ('load-m1', 'Merged1', 'load-author', 'MERGED', NOW())`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, merged_at)
+		 VALUES ('load-m2', 'Merged2', 'load-author', 'MERGED', NOW())`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('load-o1', 'Open1', 'load-author')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('load-m1', 'load-veteran')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('load-m2', 'load-veteran')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('load-o1', 'load-busy')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	counts, err := prRepo.CountOpenByUsers(ctx, []string{"load-veteran", "load-busy"})
+	if err != nil {
+		t.Fatalf("failed to count open reviews: %v", err)
+	}
+	if len(counts) != 1 || counts["load-busy"] != 1 {
+		t.Errorf("expected only load-busy with 1 open review, got %v", counts)
+	}
+
+	// Незапрошенные пользователи не учитываются
+	counts, err = prRepo.CountOpenByUsers(ctx, []string{"load-veteran"})
+	if err != nil || len(counts) != 0 {
+		t.Errorf("expected no open reviews, got %v (err %v)", counts, err)
+	}
+}
+
+// TestPullRequestRepository_GetReviewerPairCounts проверяет подсчёт совместных назначений
+// пар ревьюверов только на последних PR автора
+func TestPullRequestRepository_GetReviewerPairCounts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('pairs')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('p-author', 'Author', 'pairs')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('p-a', 'A', 'pairs')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('p-b', 'B', 'pairs')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('p-c', 'C', 'pairs')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, created_at)
+		 VALUES ('p-old', 'Old', 'p-author', NOW() - INTERVAL '3 days')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, created_at)
+		 VALUES ('p-1', 'One', 'p-author', NOW() - INTERVAL '2 days')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, created_at)
+		 VALUES ('p-2', 'Two', 'p-author', NOW() - INTERVAL '1 day')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('p-foreign', 'Foreign', 'p-c')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('p-old', 'p-a'), ('p-old', 'p-c')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('p-1', 'p-b'), ('p-1', 'p-a')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('p-2', 'p-a'), ('p-2', 'p-b')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('p-foreign', 'p-a'), ('p-foreign', 'p-author')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+
+	// Самый старый PR автора и чужой PR не учитываются
+	counts, err := prRepo.GetReviewerPairCounts(ctx, "p-author", 2)
+	if err != nil {
+		t.Fatalf("failed to count reviewer pairs: %v", err)
+	}
+	if len(counts) != 1 || counts[domain.NewReviewerPair("p-b", "p-a")] != 2 {
+		t.Errorf("expected only p-a+p-b paired twice, got %v", counts)
+	}
+
+	counts, err = prRepo.GetReviewerPairCounts(ctx, "p-author", 10)
+	if err != nil {
+		t.Fatalf("failed to count reviewer pairs: %v", err)
+	}
+	if len(counts) != 2 || counts[domain.NewReviewerPair("p-a", "p-c")] != 1 {
+		t.Errorf("expected p-a+p-c counted from the older PR, got %v", counts)
+	}
+}
+
+// TestPullRequestRepository_GetTeamPRSummary проверяет сводку по PR авторов команды
+func TestPullRequestRepository_GetTeamPRSummary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('health'), ('other')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('h-a', 'A', 'health'), ('h-b', 'B', 'health'), ('o-a', 'O', 'other')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('h-full', 'Full', 'h-a')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('h-empty', 'Empty', 'h-a')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
+		 VALUES ('h-m1', 'Merged1', 'h-b', 'MERGED', NOW() - INTERVAL '10 hours', NOW() - INTERVAL '6 hours')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
+		 VALUES ('h-m2', 'Merged2', 'h-b', 'MERGED', NOW() - INTERVAL '10 hours', NOW() - INTERVAL '8 hours')`,
+		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('o-open', 'Other', 'o-a')`,
+		`INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('h-full', 'h-b'), ('h-full', 'o-a')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	summary, err := prRepo.GetTeamPRSummary(ctx, "health", 2)
+	if err != nil {
+		t.Fatalf("failed to get team PR summary: %v", err)
+	}
+	if summary.OpenPRs != 2 || summary.UnderstaffedPRs != 1 || summary.MergedPRs != 2 {
+		t.Errorf("expected 2 open (1 understaffed) and 2 merged PRs, got %+v", summary)
+	}
+	if summary.AvgTimeToMerge.Round(time.Minute) != 3*time.Hour {
+		t.Errorf("expected 3h average time to merge, got %v", summary.AvgTimeToMerge)
+	}
+}
+
+// TestPullRequestService_ConcurrentReassign проверяет, что одновременные переназначения
+// одного PR выполняются по очереди и не теряют обновлений: оба заменённых ревьювера
+// уходят, а замены различны
+func TestPullRequestService_ConcurrentReassign(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	seed := []string{
+		`INSERT INTO teams (team_name) VALUES ('race')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('c-author', 'Author', 'race')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('c-r1', 'Reviewer 1', 'race')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('c-r2', 'Reviewer 2', 'race')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('c-n1', 'Candidate 1', 'race')`,
+		`INSERT INTO users (user_id, username, team_name) VALUES ('c-n2', 'Candidate 2', 'race')`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to seed data: %v", err)
+		}
+	}
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	userRepo := postgres.NewUserRepository(db)
+	teamRepo := postgres.NewTeamRepository(db)
+	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo, postgres.NewTxManager(db), service.ReviewerPolicy{}, service.AssignmentConfig{}, zap.NewNop())
+
+	// Повторяем несколько раз, чтобы запросы действительно пересекались
+	for i := 0; i < 10; i++ {
+		prID := fmt.Sprintf("c-pr-%d", i)
+		stmts := []string{
+			fmt.Sprintf(`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('%s', 'Race', 'c-author')`, prID),
+			fmt.Sprintf(`INSERT INTO pr_reviewers (pull_request_id, user_id, is_primary) VALUES ('%s', 'c-r1', TRUE), ('%s', 'c-r2', FALSE)`, prID, prID),
+		}
+		for _, stmt := range stmts {
+			if _, err := db.ExecContext(ctx, stmt); err != nil {
+				t.Fatalf("failed to seed PR: %v", err)
+			}
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for j, oldID := range []string{"c-r1", "c-r2"} {
+			wg.Add(1)
+			go func(j int, oldID string) {
+				defer wg.Done()
+				_, errs[j] = prService.ReassignReviewer(ctx, prID, oldID)
+			}(j, oldID)
+		}
+		wg.Wait()
+
+		for j, err := range errs {
+			if err != nil {
+				t.Fatalf("%s: reassign %d failed: %v", prID, j, err)
+			}
+		}
+
+		reviewers, err := prRepo.GetReviewers(ctx, prID)
+		if err != nil {
+			t.Fatalf("failed to get reviewers: %v", err)
+		}
+		if len(reviewers) != 2 || reviewers[0] == reviewers[1] {
+			t.Fatalf("%s: expected two distinct reviewers, got %v", prID, reviewers)
+		}
+		for _, reviewerID := range reviewers {
+			if reviewerID != "c-n1" && reviewerID != "c-n2" {
+				t.Errorf("%s: unexpected reviewer %s after both reassigns (lost update)", prID, reviewerID)
+			}
+		}
+	}
+
+	t.Run("same reviewer twice", func(t *testing.T) {
+		stmts := []string{
+			`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('c-same', 'Race', 'c-author')`,
+			`INSERT INTO pr_reviewers (pull_request_id, user_id, is_primary) VALUES ('c-same', 'c-r1', TRUE), ('c-same', 'c-r2', FALSE)`,
+		}
+		for _, stmt := range stmts {
+			if _, err := db.ExecContext(ctx, stmt); err != nil {
+				t.Fatalf("failed to seed PR: %v", err)
+			}
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for j := range errs {
+			wg.Add(1)
+			go func(j int) {
+				defer wg.Done()
+				_, errs[j] = prService.ReassignReviewer(ctx, "c-same", "c-r1")
+			}(j)
+		}
+		wg.Wait()
+
+		// Второй запрос видит, что c-r1 уже заменён
+		succeeded := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				succeeded++
+			case !errors.Is(err, domain.ErrNotAssigned):
+				t.Errorf("expected ErrNotAssigned for the losing request, got %v", err)
+			}
+		}
+		if succeeded != 1 {
+			t.Errorf("expected exactly one successful reassign, got %d", succeeded)
+		}
+
+		reviewers, err := prRepo.GetReviewers(ctx, "c-same")
+		if err != nil {
+			t.Fatalf("failed to get reviewers: %v", err)
+		}
+		if len(reviewers) != 2 || reviewers[0] == reviewers[1] {
+			t.Errorf("expected two distinct reviewers, got %v", reviewers)
+		}
+	})
+}
+
+// TestNewDB_StatementTimeout проверяет, что серверный statement_timeout прерывает долгий
+// запрос, даже когда контекст приложения без дедлайна
+func TestNewDB_StatementTimeout(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	_, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	db, err := postgres.NewDB(postgres.Config{
+		DSN:              testDSN(),
+		MaxOpenConns:     1,
+		MaxIdleConns:     1,
+		StatementTimeout: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to test DB: %v", err)
+	}
+	defer db.Close()
+
+	start := time.Now()
+	_, err = db.ExecContext(context.Background(), "SELECT pg_sleep(5)")
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "57014" {
+		t.Fatalf("expected query_canceled (57014) from statement_timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the server to abort the query quickly, took %v", elapsed)
+	}
+
+	// Обычные запросы укладываются в таймаут
+	var one int
+	if err := db.QueryRowContext(context.Background(), "SELECT 1").Scan(&one); err != nil {
+		t.Errorf("expected a fast query to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,347 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"reviewservice/internal/domain"
+	"reviewservice/internal/repository/postgres"
+)
+
+// teamResponse - ответ эндпоинтов, возвращающих команду
+type teamResponse struct {
+	Team domain.Team `json:"team"`
+}
+
+// findMember возвращает участника команды по user_id
+func findMember(team domain.Team, userID string) (domain.TeamMember, bool) {
+	for _, member := range team.Members {
+		if member.UserID == userID {
+			return member, true
+		}
+	}
+	return domain.TeamMember{}, false
+}
+
+// TestPatchTeam_AddOnly проверяет, что добавление участников не затрагивает остальных
+func TestPatchTeam_AddOnly(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ts := httptest.NewServer(setupTestServer(db, t))
+	defer ts.Close()
+
+	makeRequest(t, ts, "POST", "/team/add", domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: false},
+		},
+	})
+
+	resp := makeRequest(t, ts, "PATCH", "/team", domain.TeamPatch{
+		TeamName:   "backend",
+		AddMembers: []domain.TeamMember{{UserID: "u3", Username: "Charlie", IsActive: true}},
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	var result teamResponse
+	decodeJSON(t, resp, &result)
+
+	if len(result.Team.Members) != 3 {
+		t.Fatalf("expected 3 members, got %+v", result.Team.Members)
+	}
+	if _, ok := findMember(result.Team, "u3"); !ok {
+		t.Error("added member u3 is missing")
+	}
+	// Неупомянутый неактивный участник остаётся как был
+	if bob, _ := findMember(result.Team, "u2"); bob.IsActive {
+		t.Error("untouched member u2 must stay inactive")
+	}
+}
+
+// TestPatchTeam_RemoveOnly проверяет, что открытые ревью исключённого участника переназначаются
+func TestPatchTeam_RemoveOnly(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ts := httptest.NewServer(setupTestServer(db, t))
+	defer ts.Close()
+
+	makeRequest(t, ts, "POST", "/team/add", domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "a1", Username: "Author", IsActive: true},
+			{UserID: "r1", Username: "Reviewer1", IsActive: true},
+			{UserID: "r2", Username: "Reviewer2", IsActive: true},
+			{UserID: "r3", Username: "Reviewer3", IsActive: true},
+		},
+	})
+	makeRequest(t, ts, "POST", "/pullRequest/create", map[string]string{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Feature",
+		"author_id":         "a1",
+	})
+
+	prRepo := postgres.NewPullRequestRepository(db)
+	pr, err := prRepo.Get(context.Background(), "pr-1")
+	if err != nil {
+		t.Fatalf("failed to get PR: %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 {
+		t.Fatalf("expected 2 reviewers, got %v", pr.AssignedReviewers)
+	}
+	removed := pr.AssignedReviewers[0]
+
+	resp := makeRequest(t, ts, "PATCH", "/team", domain.TeamPatch{
+		TeamName:      "backend",
+		RemoveMembers: []string{removed},
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	var result teamResponse
+	decodeJSON(t, resp, &result)
+
+	if member, _ := findMember(result.Team, removed); member.IsActive {
+		t.Errorf("removed member %s must be deactivated", removed)
+	}
+
+	pr, err = prRepo.Get(context.Background(), "pr-1")
+	if err != nil {
+		t.Fatalf("failed to get PR: %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 {
+		t.Fatalf("expected 2 reviewers after reassignment, got %v", pr.AssignedReviewers)
+	}
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == removed {
+			t.Fatalf("removed member %s is still a reviewer: %v", removed, pr.AssignedReviewers)
+		}
+	}
+}
+
+// TestPatchTeam_Combined проверяет добавление, исключение и смену числа ревьюверов одним запросом
+func TestPatchTeam_Combined(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ts := httptest.NewServer(setupTestServer(db, t))
+	defer ts.Close()
+
+	makeRequest(t, ts, "POST", "/team/add", domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "a1", Username: "Author", IsActive: true},
+			{UserID: "r1", Username: "Reviewer1", IsActive: true},
+			{UserID: "r2", Username: "Reviewer2", IsActive: true},
+		},
+	})
+
+	reviewerCount := 3
+	resp := makeRequest(t, ts, "PATCH", "/team", domain.TeamPatch{
+		TeamName: "backend",
+		AddMembers: []domain.TeamMember{
+			{UserID: "r3", Username: "Reviewer3", IsActive: true},
+			{UserID: "r4", Username: "Reviewer4", IsActive: true},
+		},
+		RemoveMembers:    []string{"r1"},
+		SetReviewerCount: &reviewerCount,
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	var result teamResponse
+	decodeJSON(t, resp, &result)
+
+	if result.Team.ReviewerCount != 3 {
+		t.Errorf("expected reviewer_count 3, got %d", result.Team.ReviewerCount)
+	}
+	if len(result.Team.Members) != 5 {
+		t.Errorf("expected 5 members, got %+v", result.Team.Members)
+	}
+
+	// Новый PR получает трёх ревьюверов из активных участников, кроме исключённого
+	resp = makeRequest(t, ts, "POST", "/pullRequest/create", map[string]string{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Feature",
+		"author_id":         "a1",
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	var created struct {
+		PR domain.PullRequest `json:"pr"`
+	}
+	decodeJSON(t, resp, &created)
+
+	reviewers := created.PR.AssignedReviewers
+	sort.Strings(reviewers)
+	if len(reviewers) != 3 || reviewers[0] != "r2" || reviewers[1] != "r3" || reviewers[2] != "r4" {
+		t.Fatalf("expected reviewers [r2 r3 r4], got %v", reviewers)
+	}
+
+	// Некорректная дельта откатывается целиком: добавленный участник не создаётся
+	resp = makeRequest(t, ts, "PATCH", "/team", domain.TeamPatch{
+		TeamName:      "backend",
+		AddMembers:    []domain.TeamMember{{UserID: "r5", Username: "Reviewer5", IsActive: true}},
+		RemoveMembers: []string{"missing"},
+	})
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown member, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+	resp.Body.Close()
+
+	if _, err := postgres.NewUserRepository(db).Get(context.Background(), "r5"); err == nil {
+		t.Error("member r5 must be rolled back")
+	}
+}
+
+// TestPatchTeam_BackupTeam проверяет, что команда с заданной через PATCH резервной командой
+// добирает ревьюверов из неё, а не из других команд
+func TestPatchTeam_BackupTeam(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ts := httptest.NewServer(setupTestServer(db, t))
+	defer ts.Close()
+
+	makeRequest(t, ts, "POST", "/team/add", domain.Team{
+		TeamName: "platform",
+		Members:  []domain.TeamMember{{UserID: "p1", Username: "Paul", IsActive: true}},
+	})
+	makeRequest(t, ts, "POST", "/team/add", domain.Team{
+		TeamName: "web",
+		Members:  []domain.TeamMember{{UserID: "w1", Username: "Wendy", IsActive: true}},
+	})
+	makeRequest(t, ts, "POST", "/team/add", domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	})
+
+	backup := "platform"
+	resp := makeRequest(t, ts, "PATCH", "/team", domain.TeamPatch{TeamName: "backend", SetBackupTeamName: &backup})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	var result teamResponse
+	decodeJSON(t, resp, &result)
+	if result.Team.BackupTeamName != "platform" {
+		t.Fatalf("expected backup team platform, got %q", result.Team.BackupTeamName)
+	}
+
+	resp = makeRequest(t, ts, "POST", "/pullRequest/create", map[string]string{
+		"pull_request_id":   "pr-backup",
+		"pull_request_name": "Feature",
+		"author_id":         "u1",
+	})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	var prResp struct {
+		PR domain.PullRequest `json:"pr"`
+	}
+	decodeJSON(t, resp, &prResp)
+
+	reviewers := append([]string{}, prResp.PR.AssignedReviewers...)
+	sort.Strings(reviewers)
+	if len(reviewers) != 2 || reviewers[0] != "p1" || reviewers[1] != "u2" {
+		t.Errorf("expected reviewers [p1 u2], got %v", prResp.PR.AssignedReviewers)
+	}
+
+	// Команда не может быть резервной сама себе
+	self := "backend"
+	resp = makeRequest(t, ts, "PATCH", "/team", domain.TeamPatch{TeamName: "backend", SetBackupTeamName: &self})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for self backup, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+}
+
+// TestPatchTeam_Calendar проверяет сохранение рабочего календаря команды через PATCH,
+// его нормализацию, отклонение некорректных дат и снятие пустым календарём
+func TestPatchTeam_Calendar(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ts := httptest.NewServer(setupTestServer(db, t))
+	defer ts.Close()
+
+	makeRequest(t, ts, "POST", "/team/add", domain.Team{
+		TeamName: "backend",
+		Members:  []domain.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	})
+
+	calendar := &domain.TeamCalendar{
+		WorkingDays: []int{5, 1, 2, 3, 4, 1},
+		Holidays:    []string{"2025-12-31", "2025-01-01", "2025-12-31"},
+	}
+	resp := makeRequest(t, ts, "PATCH", "/team", domain.TeamPatch{TeamName: "backend", SetCalendar: calendar})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	var result teamResponse
+	decodeJSON(t, resp, &result)
+	if result.Team.Calendar == nil {
+		t.Fatal("expected calendar to be set")
+	}
+	if got := result.Team.Calendar.WorkingDays; len(got) != 5 || got[0] != 1 || got[4] != 5 {
+		t.Errorf("expected working days [1 2 3 4 5], got %v", got)
+	}
+	if got := result.Team.Calendar.Holidays; len(got) != 2 || got[0] != "2025-01-01" || got[1] != "2025-12-31" {
+		t.Errorf("expected holidays [2025-01-01 2025-12-31], got %v", got)
+	}
+
+	// Некорректная дата отклоняется, календарь не меняется
+	resp = makeRequest(t, ts, "PATCH", "/team", domain.TeamPatch{
+		TeamName:    "backend",
+		SetCalendar: &domain.TeamCalendar{Holidays: []string{"31.12.2025"}},
+	})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid holiday, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+
+	// Пустой календарь снимает ограничения
+	resp = makeRequest(t, ts, "PATCH", "/team", domain.TeamPatch{TeamName: "backend", SetCalendar: &domain.TeamCalendar{}})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, readBody(t, resp))
+	}
+	result = teamResponse{}
+	decodeJSON(t, resp, &result)
+	if result.Team.Calendar != nil {
+		t.Errorf("expected calendar to be cleared, got %+v", result.Team.Calendar)
+	}
+}